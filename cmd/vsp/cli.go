@@ -164,15 +164,23 @@ func getClient(params *systemParams) (*adt.Client, error) {
 			return nil, fmt.Errorf("failed to load cookies from %s: %w", params.CookieFile, err)
 		}
 		opts = append(opts, adt.WithCookies(cookies))
-		return adt.NewClient(params.URL, "", "", opts...), nil
+		return validatedClient(adt.NewClient(params.URL, "", "", opts...))
 	}
 	if params.CookieString != "" {
 		cookies := adt.ParseCookieString(params.CookieString)
 		opts = append(opts, adt.WithCookies(cookies))
-		return adt.NewClient(params.URL, "", "", opts...), nil
+		return validatedClient(adt.NewClient(params.URL, "", "", opts...))
 	}
 
-	return adt.NewClient(params.URL, params.User, params.Password, opts...), nil
+	return validatedClient(adt.NewClient(params.URL, params.User, params.Password, opts...))
+}
+
+// validatedClient rejects clients whose configuration failed validation.
+func validatedClient(client *adt.Client) (*adt.Client, error) {
+	if err := client.ConfigError(); err != nil {
+		return nil, err
+	}
+	return client, nil
 }
 
 // getWSClient creates an AMDP WebSocket client for GitExport.
@@ -64,6 +64,19 @@ Examples:
 	RunE: runSystemInfo,
 }
 
+var uriCmd = &cobra.Command{
+	Use:   "uri <type> <name>",
+	Short: "Resolve an object name to its ADT URI",
+	Long: `Print the ADT URI of an object, checking that the object exists.
+Useful for commands and tools that take an object URI.
+
+Examples:
+  vsp uri CLAS ZCL_MY_CLASS
+  vsp uri FUNC Z_MY_FUNCTION --parent ZMY_FUGR`,
+	Args: cobra.ExactArgs(2),
+	RunE: runURI,
+}
+
 var systemCmd = &cobra.Command{
 	Use:   "system",
 	Short: "System information and management",
@@ -269,6 +282,10 @@ func init() {
 	slimCmd.Flags().String("level", "objects", "Analysis depth: objects (fastest), methods (objects + dead methods), full (+ attributes)")
 	rootCmd.AddCommand(slimCmd)
 
+	// URI command (top-level)
+	uriCmd.Flags().String("parent", "", "Function group name (required for FUNC type)")
+	rootCmd.AddCommand(uriCmd)
+
 	// Graph flags
 	graphCmd.Flags().String("direction", "callees", "Direction: callees, callers, or both")
 	graphCmd.Flags().Int("depth", 1, "Maximum traversal depth")
@@ -445,6 +462,27 @@ func runGrep(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+func runURI(cmd *cobra.Command, args []string) error {
+	params, err := resolveSystemParams(cmd)
+	if err != nil {
+		return err
+	}
+
+	client, err := getClient(params)
+	if err != nil {
+		return err
+	}
+
+	parent, _ := cmd.Flags().GetString("parent")
+	uri, err := client.ResolveObjectURI(context.Background(), args[0], args[1], parent)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(uri)
+	return nil
+}
+
 func runSystemInfo(cmd *cobra.Command, args []string) error {
 	params, err := resolveSystemParams(cmd)
 	if err != nil {
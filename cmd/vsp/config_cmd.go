@@ -811,6 +811,7 @@ func GetAllToolNames() []string {
 		"SetBreakpoint", "GetBreakpoints", "DeleteBreakpoint",
 		"DebuggerListen", "DebuggerAttach", "DebuggerDetach",
 		"DebuggerStep", "DebuggerGetStack", "DebuggerGetVariables",
+		"DebuggerEvaluate",
 		// AMDP debugger (experimental)
 		"AMDPDebuggerStart", "AMDPDebuggerResume", "AMDPDebuggerStop",
 		"AMDPDebuggerStep", "AMDPGetVariables", "AMDPSetBreakpoint", "AMDPGetBreakpoints",
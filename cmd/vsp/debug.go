@@ -44,7 +44,10 @@ Examples:
   vsp debug --attach --user DEVELOPER
 
   # Set breakpoint and attach
-  vsp debug --program ZTEST --line 42`,
+  vsp debug --program ZTEST --line 42
+
+  # Keep breakpoints across sessions
+  vsp debug --bp-file ~/.vsp-breakpoints.json`,
 	RunE: runDebug,
 }
 
@@ -54,6 +57,7 @@ var (
 	debugProgram string
 	debugLine    int
 	debugTimeout int
+	debugBPFile  string
 )
 
 func init() {
@@ -62,6 +66,7 @@ func init() {
 	debugCmd.Flags().StringVarP(&debugProgram, "program", "p", "", "Program for initial breakpoint")
 	debugCmd.Flags().IntVarP(&debugLine, "line", "l", 0, "Line for initial breakpoint")
 	debugCmd.Flags().IntVarP(&debugTimeout, "timeout", "t", 120, "Listen timeout in seconds")
+	debugCmd.Flags().StringVar(&debugBPFile, "bp-file", "", "Save breakpoints to this JSON file and restore them on startup")
 
 	rootCmd.AddCommand(debugCmd)
 }
@@ -75,6 +80,10 @@ type debugSession struct {
 	debuggeeID string
 	ctx        context.Context
 	cancel     context.CancelFunc
+
+	// bpFile, when set, mirrors breakpoints so they survive restarts.
+	bpFile      string
+	breakpoints []adt.Breakpoint
 }
 
 func runDebug(cmd *cobra.Command, args []string) error {
@@ -92,7 +101,10 @@ func runDebug(cmd *cobra.Command, args []string) error {
 	}
 
 	// Create ADT client
-	client := createADTClient()
+	client, err := createADTClient()
+	if err != nil {
+		return err
+	}
 
 	// Get user for debugging
 	user := debugUser
@@ -142,6 +154,11 @@ func runDebug(cmd *cobra.Command, args []string) error {
 		user:     user,
 		ctx:      ctx,
 		cancel:   cancel,
+		bpFile:   debugBPFile,
+	}
+
+	if err := session.restoreBreakpoints(wsConnected); err != nil {
+		return err
 	}
 
 	// Set initial breakpoint if specified
@@ -515,7 +532,11 @@ func (s *debugSession) setBreakpoint(args []string) error {
 			return fmt.Errorf("set breakpoint failed: %w", err)
 		}
 		fmt.Printf("Breakpoint %s set at %s:%d\n", bpID, program, line)
-		return nil
+		bp := adt.NewLineBreakpoint(adt.GetObjectURL(adt.ObjectTypeProgram, program, ""), line)
+		bp.ID = bpID
+		bp.ObjectName = program
+		s.breakpoints = append(s.breakpoints, bp)
+		return s.saveBreakpoints()
 	}
 
 	return fmt.Errorf("WebSocket not connected - cannot set breakpoints")
@@ -536,12 +557,69 @@ func (s *debugSession) deleteBreakpoint(args []string) error {
 			return fmt.Errorf("delete breakpoint failed: %w", err)
 		}
 		fmt.Printf("Breakpoint %s deleted\n", bpID)
-		return nil
+		for i, bp := range s.breakpoints {
+			if bp.ID == bpID {
+				s.breakpoints = append(s.breakpoints[:i], s.breakpoints[i+1:]...)
+				break
+			}
+		}
+		return s.saveBreakpoints()
 	}
 
 	return fmt.Errorf("WebSocket not connected - cannot delete breakpoints")
 }
 
+// saveBreakpoints writes the session's breakpoints to --bp-file, if set.
+func (s *debugSession) saveBreakpoints() error {
+	if s.bpFile == "" {
+		return nil
+	}
+	return adt.SaveBreakpoints(s.bpFile, s.breakpoints)
+}
+
+// restoreBreakpoints re-registers the breakpoints saved in --bp-file. Line
+// breakpoints go through the WebSocket when it is connected, everything else
+// through the external breakpoint API.
+func (s *debugSession) restoreBreakpoints(wsConnected bool) error {
+	if s.bpFile == "" {
+		return nil
+	}
+	saved, err := adt.LoadBreakpoints(s.bpFile)
+	if err != nil {
+		return err
+	}
+	if len(saved) == 0 {
+		return nil
+	}
+
+	var external []adt.Breakpoint
+	for _, bp := range saved {
+		if wsConnected && bp.Kind == adt.BreakpointKindLine && bp.ObjectName != "" {
+			bpID, err := s.wsClient.SetLineBreakpoint(s.ctx, bp.ObjectName, bp.Line)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: skipping saved breakpoint at %s:%d: %v\n", bp.ObjectName, bp.Line, err)
+				continue
+			}
+			bp.ID = bpID
+			s.breakpoints = append(s.breakpoints, bp)
+			continue
+		}
+		external = append(external, bp)
+	}
+
+	restored, skipped, err := s.client.RestoreBreakpoints(s.ctx, external, s.user)
+	for _, bp := range skipped {
+		fmt.Fprintf(os.Stderr, "Warning: skipping saved breakpoint at %s:%d: object no longer exists\n", bp.URI, bp.Line)
+	}
+	if err != nil {
+		return fmt.Errorf("restoring breakpoints from %s: %w", s.bpFile, err)
+	}
+	s.breakpoints = append(s.breakpoints, restored...)
+	fmt.Printf("Restored %d of %d breakpoints from %s\n", len(s.breakpoints), len(saved), s.bpFile)
+
+	return s.saveBreakpoints()
+}
+
 func (s *debugSession) listBreakpoints() error {
 	if s.wsClient != nil {
 		ctx, cancel := context.WithTimeout(s.ctx, 10*time.Second)
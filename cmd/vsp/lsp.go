@@ -62,8 +62,11 @@ func runLSP(cmd *cobra.Command, args []string) error {
 	var client *adt.Client
 	if cfg.BaseURL != "" {
 		// Try to set up SAP connection for online diagnostics
-		if err := processCookieAuth(cmd.Root()); err == nil {
-			client = createADTClient()
+		err := processCookieAuth(cmd.Root())
+		if err == nil {
+			client, err = createADTClient()
+		}
+		if err == nil {
 			if cfg.Verbose {
 				fmt.Fprintf(os.Stderr, "[LSP] Connected to SAP: %s\n", cfg.BaseURL)
 			}
@@ -63,7 +63,10 @@ func runLua(cmd *cobra.Command, args []string) error {
 	}
 
 	// Create ADT client
-	client := createADTClient()
+	client, err := createADTClient()
+	if err != nil {
+		return err
+	}
 
 	// Create Lua engine
 	engine := scripting.NewLuaEngine(client)
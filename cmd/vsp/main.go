@@ -128,6 +128,7 @@ func init() {
 	rootCmd.Flags().StringVar(&cfg.AllowedOps, "allowed-ops", "", "Whitelist of allowed operation types (e.g., \"RSQ\" for Read, Search, Query only)")
 	rootCmd.Flags().StringVar(&cfg.DisallowedOps, "disallowed-ops", "", "Blacklist of operation types to block (e.g., \"CDUA\" for Create, Delete, Update, Activate)")
 	rootCmd.Flags().StringSliceVar(&cfg.AllowedPackages, "allowed-packages", nil, "Restrict operations to specific packages (comma-separated, supports wildcards like Z*)")
+	rootCmd.Flags().StringSliceVar(&cfg.DeniedPackages, "denied-packages", nil, "Always block operations on these packages, even if allowed (comma-separated globs like /SAP*)")
 	rootCmd.Flags().BoolVar(&cfg.EnableTransports, "enable-transports", false, "Enable transport management operations (disabled by default for safety)")
 	rootCmd.Flags().BoolVar(&cfg.TransportReadOnly, "transport-read-only", false, "Only allow read operations on transports (list, get)")
 	rootCmd.Flags().StringSliceVar(&cfg.AllowedTransports, "allowed-transports", nil, "Restrict transport operations to specific transports (comma-separated, supports wildcards like A4HK*)")
@@ -261,13 +262,16 @@ func runServer(cmd *cobra.Command, args []string) error {
 		if len(cfg.AllowedPackages) > 0 {
 			fmt.Fprintf(os.Stderr, "[VERBOSE] Safety: Allowed packages: %v\n", cfg.AllowedPackages)
 		}
+		if len(cfg.DeniedPackages) > 0 {
+			fmt.Fprintf(os.Stderr, "[VERBOSE] Safety: Denied packages: %v\n", cfg.DeniedPackages)
+		}
 		if cfg.EnableTransports {
 			fmt.Fprintf(os.Stderr, "[VERBOSE] Safety: Transport management ENABLED\n")
 		}
 		if cfg.AllowTransportableEdits {
 			fmt.Fprintf(os.Stderr, "[VERBOSE] Safety: Transportable edits ENABLED (can modify non-local objects)\n")
 		}
-		if !cfg.ReadOnly && !cfg.BlockFreeSQL && cfg.AllowedOps == "" && cfg.DisallowedOps == "" && len(cfg.AllowedPackages) == 0 {
+		if !cfg.ReadOnly && !cfg.BlockFreeSQL && cfg.AllowedOps == "" && cfg.DisallowedOps == "" && len(cfg.AllowedPackages) == 0 && len(cfg.DeniedPackages) == 0 {
 			fmt.Fprintf(os.Stderr, "[VERBOSE] Safety: UNRESTRICTED (no safety checks active)\n")
 		}
 		if cfg.KeepAliveInterval > 0 {
@@ -302,7 +306,10 @@ func runServer(cmd *cobra.Command, args []string) error {
 	}
 
 	// Create and start MCP server
-	srv := mcp.NewServer(cfg)
+	srv, err := mcp.NewServer(cfg)
+	if err != nil {
+		return err
+	}
 
 	switch cfg.Transport {
 	case "http":
@@ -409,6 +416,11 @@ func resolveConfig(cmd *cobra.Command) {
 			cfg.AllowedPackages = splitCommaSeparated(pkgStr)
 		}
 	}
+	if !cmd.Flags().Changed("denied-packages") {
+		if pkgStr := viper.GetString("DENIED_PACKAGES"); pkgStr != "" {
+			cfg.DeniedPackages = splitCommaSeparated(pkgStr)
+		}
+	}
 	if !cmd.Flags().Changed("enable-transports") {
 		cfg.EnableTransports = viper.GetBool("ENABLE_TRANSPORTS")
 	}
@@ -100,7 +100,10 @@ func runWorkflow(cmd *cobra.Command, args []string) error {
 	}
 
 	// Create ADT client
-	client := createADTClient()
+	client, err := createADTClient()
+	if err != nil {
+		return err
+	}
 
 	// Create workflow engine
 	engine := dsl.NewWorkflowEngine(client)
@@ -159,7 +162,10 @@ func runTestWorkflow(cmd *cobra.Command, args []string) error {
 	}
 
 	// Create ADT client
-	client := createADTClient()
+	client, err := createADTClient()
+	if err != nil {
+		return err
+	}
 
 	fmt.Fprintf(os.Stderr, "Discovering tests in: %s\n", packagePattern)
 
@@ -238,7 +244,9 @@ func runTestWorkflow(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
-func createADTClient() *adt.Client {
+// createADTClient builds the client from the resolved configuration and
+// fails if the configuration is invalid.
+func createADTClient() (*adt.Client, error) {
 	opts := []adt.Option{
 		adt.WithClient(cfg.Client),
 		adt.WithLanguage(cfg.Language),
@@ -252,7 +260,11 @@ func createADTClient() *adt.Client {
 		opts = append(opts, adt.WithCookies(cfg.Cookies))
 	}
 
-	return adt.NewClient(cfg.BaseURL, cfg.Username, cfg.Password, opts...)
+	client := adt.NewClient(cfg.BaseURL, cfg.Username, cfg.Password, opts...)
+	if err := client.ConfigError(); err != nil {
+		return nil, err
+	}
+	return client, nil
 }
 
 func printWorkflowResult(result *dsl.WorkflowResult) {
@@ -241,6 +241,10 @@ func (s *Server) handleCompareCallGraphs(ctx context.Context, request mcp.CallTo
 	// Compare
 	comparison := adt.CompareCallGraphs(staticEdges, actualEdges)
 
+	if format, _ := request.GetArguments()["format"].(string); format == "mermaid" {
+		return mcp.NewToolResultText(adt.ExportComparisonMermaid(comparison)), nil
+	}
+
 	output := map[string]interface{}{
 		"object_uri":     objectURI,
 		"static_edges":   len(staticEdges),
@@ -287,6 +291,10 @@ func (s *Server) handleTraceExecution(ctx context.Context, request mcp.CallToolR
 		opts.TraceUser = traceUser
 	}
 
+	if startTrace, ok := request.GetArguments()["start_trace"].(bool); ok {
+		opts.StartTrace = startTrace
+	}
+
 	result, err := s.adtClient.TraceExecution(ctx, opts)
 	if err != nil {
 		return newToolResultError(fmt.Sprintf("Trace execution failed: %v", err)), nil
@@ -30,6 +30,8 @@ func (s *Server) routeDebuggerLegacyAction(ctx context.Context, action, objectTy
 		return s.callHandler(ctx, s.handleDebuggerGetStack, params)
 	case "GET_VARIABLES":
 		return s.callHandler(ctx, s.handleDebuggerGetVariables, params)
+	case "EVALUATE":
+		return s.callHandler(ctx, s.handleDebuggerEvaluate, params)
 	}
 	return nil, false, nil
 }
@@ -163,6 +165,17 @@ func (s *Server) handleDebuggerStep(ctx context.Context, request mcp.CallToolReq
 
 	uri, _ := request.GetArguments()["uri"].(string)
 
+	if stepType == adt.DebugStepRunToLine || stepType == adt.DebugStepJumpToLine {
+		if line, ok := request.GetArguments()["line"].(float64); ok && line > 0 {
+			if uri == "" {
+				return newToolResultError(fmt.Sprintf("uri is required for %s", stepTypeStr)), nil
+			}
+			uri = adt.DebugLineURI(uri, int(line))
+		} else if !strings.Contains(uri, "#start=") {
+			return newToolResultError(fmt.Sprintf("line is required for %s", stepTypeStr)), nil
+		}
+	}
+
 	result, err := s.adtClient.DebuggerStep(ctx, stepType, uri)
 	if err != nil {
 		return newToolResultError(fmt.Sprintf("DebuggerStep failed: %v", err)), nil
@@ -186,7 +199,15 @@ func (s *Server) handleDebuggerStep(ctx context.Context, request mcp.CallToolReq
 }
 
 func (s *Server) handleDebuggerGetStack(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	result, err := s.adtClient.DebuggerGetStack(ctx, true)
+	withVariables, _ := request.GetArguments()["with_variables"].(bool)
+
+	var result *adt.DebugStackInfo
+	var err error
+	if withVariables {
+		result, err = s.adtClient.DebuggerGetStackWithVariables(ctx, true)
+	} else {
+		result, err = s.adtClient.DebuggerGetStack(ctx, true)
+	}
 	if err != nil {
 		return newToolResultError(fmt.Sprintf("DebuggerGetStack failed: %v", err)), nil
 	}
@@ -207,6 +228,9 @@ func (s *Server) handleDebuggerGetStack(ctx context.Context, request mcp.CallToo
 		if entry.SystemProgram {
 			sb.WriteString("      (system program)\n")
 		}
+		for _, v := range entry.Variables {
+			fmt.Fprintf(&sb, "      %s: %s = %s\n", v.Name, v.DeclaredTypeName, v.Value)
+		}
 		if i < len(result.Stack)-1 {
 			sb.WriteString("\n")
 		}
@@ -253,30 +277,71 @@ func (s *Server) handleDebuggerGetVariables(ctx context.Context, request mcp.Cal
 		return mcp.NewToolResultText(sb.String()), nil
 	}
 
+	depth := 0
+	if d, ok := request.GetArguments()["depth"].(float64); ok && d > 0 {
+		depth = int(d)
+	}
+
 	// Get specific variables
-	result, err := s.adtClient.DebuggerGetVariables(ctx, variableIDs)
+	result, err := s.adtClient.DebuggerGetVariablesDeep(ctx, variableIDs, depth)
 	if err != nil {
 		return newToolResultError(fmt.Sprintf("DebuggerGetVariables failed: %v", err)), nil
 	}
 
 	var sb strings.Builder
 	sb.WriteString("Variables:\n\n")
+	writeDebugVariables(&sb, result, "")
+
+	return mcp.NewToolResultText(sb.String()), nil
+}
+
+func (s *Server) handleDebuggerEvaluate(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	expression, _ := request.GetArguments()["expression"].(string)
+	if strings.TrimSpace(expression) == "" {
+		return newToolResultError("expression is required"), nil
+	}
+
+	result, err := s.adtClient.DebuggerEvaluate(ctx, expression)
+	if err != nil {
+		return newToolResultError(fmt.Sprintf("DebuggerEvaluate failed: %v", err)), nil
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%s = %s\n", result.Expression, result.Value)
+	fmt.Fprintf(&sb, "  Type: %s\n", result.Type)
+	if result.MetaType != "" {
+		fmt.Fprintf(&sb, "  MetaType: %s\n", result.MetaType)
+	}
+	if result.ID != "" {
+		fmt.Fprintf(&sb, "  ID: %s\n", result.ID)
+	}
+
+	return mcp.NewToolResultText(sb.String()), nil
+}
 
-	for _, v := range result {
-		fmt.Fprintf(&sb, "%s: %s = %s\n", v.Name, v.DeclaredTypeName, v.Value)
-		fmt.Fprintf(&sb, "  ID: %s\n", v.ID)
-		fmt.Fprintf(&sb, "  MetaType: %s, Kind: %s\n", v.MetaType, v.Kind)
+// writeDebugVariables formats variables and any expanded children, indenting
+// each nesting level.
+func writeDebugVariables(sb *strings.Builder, vars []adt.DebugVariable, indent string) {
+	for _, v := range vars {
+		fmt.Fprintf(sb, "%s%s: %s = %s\n", indent, v.Name, v.DeclaredTypeName, v.Value)
+		fmt.Fprintf(sb, "%s  ID: %s\n", indent, v.ID)
+		fmt.Fprintf(sb, "%s  MetaType: %s, Kind: %s\n", indent, v.MetaType, v.Kind)
 		if v.HexValue != "" {
-			fmt.Fprintf(&sb, "  Hex: %s\n", v.HexValue)
+			fmt.Fprintf(sb, "%s  Hex: %s\n", indent, v.HexValue)
 		}
 		if v.TableLines > 0 {
-			fmt.Fprintf(&sb, "  Table Lines: %d\n", v.TableLines)
+			fmt.Fprintf(sb, "%s  Table Lines: %d\n", indent, v.TableLines)
 		}
-		if v.IsComplexType() {
-			sb.WriteString("  (complex type - expandable)\n")
+		if len(v.Children) > 0 {
+			writeDebugVariables(sb, v.Children, indent+"    ")
+			if v.ChildrenTruncated {
+				fmt.Fprintf(sb, "%s    ... (children truncated)\n", indent)
+			}
+		} else if v.IsComplexType() {
+			fmt.Fprintf(sb, "%s  (complex type - expandable)\n", indent)
+		}
+		if indent == "" {
+			sb.WriteString("\n")
 		}
-		sb.WriteString("\n")
 	}
-
-	return mcp.NewToolResultText(sb.String()), nil
 }
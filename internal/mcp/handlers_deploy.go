@@ -158,7 +158,7 @@ func (s *Server) handleDeployZip(ctx context.Context, request mcp.CallToolReques
 		})
 		if err != nil {
 			// Tolerate "already exists" - GetPackage may fail for $ packages
-			if strings.Contains(err.Error(), "AlreadyExists") || strings.Contains(err.Error(), "already exist") {
+			if adt.IsAlreadyExists(err) {
 				fmt.Fprintf(&sb, "  ✓ Package already exists\n")
 			} else {
 				return newToolResultError(fmt.Sprintf("Failed to create package: %v", err)), nil
@@ -197,7 +197,7 @@ func (s *Server) handleDeployZip(ctx context.Context, request mcp.CallToolReques
 			PackageName: packageName,
 		})
 		if err != nil {
-			if strings.Contains(err.Error(), "AlreadyExists") || strings.Contains(err.Error(), "already exist") {
+			if adt.IsAlreadyExists(err) {
 				fmt.Fprintf(&sb, "exists\n")
 				createSkipped++
 			} else {
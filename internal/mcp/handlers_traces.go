@@ -66,7 +66,18 @@ func (s *Server) handleGetTrace(ctx context.Context, request mcp.CallToolRequest
 		toolType = tt
 	}
 
-	analysis, err := s.adtClient.GetTrace(ctx, traceID, toolType)
+	var filter adt.TraceFilter
+	if v, ok := request.GetArguments()["min_gross_time_us"].(float64); ok && v > 0 {
+		filter.MinGrossTimeUs = int64(v)
+	}
+	if v, ok := request.GetArguments()["program_pattern"].(string); ok {
+		filter.ProgramPattern = v
+	}
+	if v, ok := request.GetArguments()["top_n"].(float64); ok && v > 0 {
+		filter.TopN = int(v)
+	}
+
+	analysis, err := s.adtClient.GetTraceFiltered(ctx, traceID, toolType, filter)
 	if err != nil {
 		return newToolResultError(fmt.Sprintf("Failed to get trace: %v", err)), nil
 	}
@@ -267,20 +267,22 @@ func (s *Server) handleCreateTransport(ctx context.Context, request mcp.CallTool
 
 	transportLayer, _ := request.GetArguments()["transport_layer"].(string)
 	transportType, _ := request.GetArguments()["type"].(string)
+	targetSystem, _ := request.GetArguments()["target_system"].(string)
 
 	opts := adt.CreateTransportOptions{
 		Description:    description,
 		Package:        pkg,
 		TransportLayer: transportLayer,
 		Type:           transportType,
+		TargetSystem:   targetSystem,
 	}
 
-	transportNumber, err := s.adtClient.CreateTransportV2(ctx, opts)
+	tr, err := s.adtClient.CreateTransportV2(ctx, opts)
 	if err != nil {
 		return newToolResultError(fmt.Sprintf("CreateTransport failed: %v", err)), nil
 	}
 
-	return mcp.NewToolResultText(fmt.Sprintf("Transport created: %s", transportNumber)), nil
+	return mcp.NewToolResultText(fmt.Sprintf("Transport created: %s", tr.Number)), nil
 }
 
 func (s *Server) handleReleaseTransport(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -65,11 +65,12 @@ type Config struct {
 	DisabledGroups string
 
 	// Safety configuration
-	ReadOnly         bool
-	BlockFreeSQL     bool
-	AllowedOps       string
-	DisallowedOps    string
-	AllowedPackages  []string
+	ReadOnly                bool
+	BlockFreeSQL            bool
+	AllowedOps              string
+	DisallowedOps           string
+	AllowedPackages         []string
+	DeniedPackages          []string // Package globs that are always blocked (e.g. "/SAP*")
 	EnableTransports        bool     // Explicitly enable transport management (default: disabled)
 	TransportReadOnly       bool     // Only allow read operations on transports (list, get)
 	AllowedTransports       []string // Whitelist specific transports (supports wildcards like "A4HK*")
@@ -110,8 +111,9 @@ type Config struct {
 	ToolsConfig map[string]bool
 }
 
-// NewServer creates a new MCP server for ABAP ADT tools.
-func NewServer(cfg *Config) *Server {
+// NewServer creates a new MCP server for ABAP ADT tools. It fails when the
+// connection configuration is invalid (see adt.Config.Validate).
+func NewServer(cfg *Config) (*Server, error) {
 	// Create ADT client
 	opts := []adt.Option{
 		adt.WithClient(cfg.Client),
@@ -147,6 +149,9 @@ func NewServer(cfg *Config) *Server {
 	if len(cfg.AllowedPackages) > 0 {
 		safety.AllowedPackages = cfg.AllowedPackages
 	}
+	if len(cfg.DeniedPackages) > 0 {
+		safety.DenyPatterns = cfg.DeniedPackages
+	}
 	if cfg.EnableTransports {
 		safety.EnableTransports = true
 	}
@@ -162,6 +167,9 @@ func NewServer(cfg *Config) *Server {
 	opts = append(opts, adt.WithSafety(safety))
 
 	adtClient := adt.NewClient(cfg.BaseURL, cfg.Username, cfg.Password, opts...)
+	if err := adtClient.ConfigError(); err != nil {
+		return nil, err
+	}
 
 	// Set terminal ID for debugger operations
 	// Priority: 1) Custom ID (SAP GUI), 2) User-based ID
@@ -208,7 +216,7 @@ func NewServer(cfg *Config) *Server {
 		adtClient.StartKeepAlive(cfg.KeepAliveInterval, cfg.Verbose)
 	}
 
-	return s
+	return s, nil
 }
 
 // parseFeatureMode converts string to FeatureMode
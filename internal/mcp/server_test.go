@@ -2,9 +2,13 @@ package mcp
 
 import (
 	"context"
+	"io"
+	"net/http"
+	"strings"
 	"testing"
 
 	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/oisee/vibing-steampunk/pkg/adt"
 )
 
 func TestNewToolResultError(t *testing.T) {
@@ -71,7 +75,10 @@ func TestNewServer(t *testing.T) {
 		Language: "EN",
 	}
 
-	server := NewServer(cfg)
+	server, err := NewServer(cfg)
+	if err != nil {
+		t.Fatalf("NewServer failed: %v", err)
+	}
 
 	if server == nil {
 		t.Fatal("NewServer returned nil")
@@ -84,6 +91,22 @@ func TestNewServer(t *testing.T) {
 	}
 }
 
+func TestNewServer_InvalidConfig(t *testing.T) {
+	cfg := &Config{
+		BaseURL:  "sap.example.com:44300", // missing scheme
+		Username: "testuser",
+		Password: "testpass",
+	}
+
+	server, err := NewServer(cfg)
+	if err == nil || server != nil {
+		t.Fatalf("NewServer accepted an invalid config: %v", err)
+	}
+	if !strings.Contains(err.Error(), "invalid config") {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
 func TestDebuggerGetVariablesSchemaIncludesItems(t *testing.T) {
 	cfg := &Config{
 		BaseURL:  "https://sap.example.com:44300",
@@ -93,7 +116,10 @@ func TestDebuggerGetVariablesSchemaIncludesItems(t *testing.T) {
 		Language: "EN",
 	}
 
-	server := NewServer(cfg)
+	server, err := NewServer(cfg)
+	if err != nil {
+		t.Fatalf("NewServer failed: %v", err)
+	}
 	if server == nil || server.mcpServer == nil {
 		t.Fatal("server or MCP server is nil")
 	}
@@ -159,3 +185,128 @@ func TestDebuggerGetVariablesSchemaIncludesItems(t *testing.T) {
 		t.Fatalf("expected variable_ids.items.type to be 'string', got %v", items["type"])
 	}
 }
+
+type doerFunc func(*http.Request) (*http.Response, error)
+
+func (f doerFunc) Do(req *http.Request) (*http.Response, error) { return f(req) }
+
+func TestHandleDebuggerStep_RunToLine(t *testing.T) {
+	var stepURIs []string
+	doer := doerFunc(func(req *http.Request) (*http.Response, error) {
+		resp := &http.Response{StatusCode: http.StatusOK, Header: http.Header{}, Body: io.NopCloser(strings.NewReader(""))}
+		if strings.Contains(req.URL.Path, "discovery") {
+			resp.Header.Set("X-CSRF-Token", "test-token")
+			return resp, nil
+		}
+		if req.URL.Query().Get("method") == "stepRunToLine" {
+			stepURIs = append(stepURIs, req.URL.Query().Get("uri"))
+			resp.Body = io.NopCloser(strings.NewReader(`<dbg:step xmlns:dbg="http://www.sap.com/adt/debugger" debugSessionId="DBG1" isSteppingPossible="true"/>`))
+		}
+		return resp, nil
+	})
+	cfg := adt.NewConfig("https://sap.example.com:44300", "user", "pass")
+	server := &Server{adtClient: adt.NewClientWithTransport(cfg, adt.NewTransportWithClient(cfg, doer))}
+
+	call := func(args map[string]any) *mcp.CallToolResult {
+		var req mcp.CallToolRequest
+		req.Params.Arguments = args
+		result, err := server.handleDebuggerStep(context.Background(), req)
+		if err != nil {
+			t.Fatalf("handleDebuggerStep returned error: %v", err)
+		}
+		return result
+	}
+
+	result := call(map[string]any{
+		"step_type": "stepRunToLine",
+		"uri":       "/sap/bc/adt/programs/programs/ZDEMO_REPORT/source/main#start=10",
+		"line":      float64(42),
+	})
+	if result.IsError {
+		t.Fatalf("unexpected tool error: %+v", result.Content)
+	}
+	if len(stepURIs) != 1 || stepURIs[0] != "/sap/bc/adt/programs/programs/ZDEMO_REPORT/source/main#start=42" {
+		t.Errorf("DebuggerStep uri = %v", stepURIs)
+	}
+
+	result = call(map[string]any{
+		"step_type": "stepRunToLine",
+		"uri":       "/sap/bc/adt/programs/programs/ZDEMO_REPORT/source/main",
+	})
+	if !result.IsError {
+		t.Error("expected an error when no target line is given")
+	}
+	if len(stepURIs) != 1 {
+		t.Errorf("step without a line should not reach the debugger, calls = %v", stepURIs)
+	}
+}
+
+func TestHandleDebuggerEvaluate(t *testing.T) {
+	doer := doerFunc(func(req *http.Request) (*http.Response, error) {
+		resp := &http.Response{StatusCode: http.StatusOK, Header: http.Header{}, Body: io.NopCloser(strings.NewReader(""))}
+		if strings.Contains(req.URL.Path, "discovery") {
+			resp.Header.Set("X-CSRF-Token", "test-token")
+			return resp, nil
+		}
+		switch req.URL.Query().Get("method") {
+		case "attach":
+			resp.Body = io.NopCloser(strings.NewReader(`<dbg:attach xmlns:dbg="http://www.sap.com/adt/debugger" debugSessionId="DBG1" isSteppingPossible="true"/>`))
+		case "evaluate":
+			data, _ := io.ReadAll(req.Body)
+			if string(data) == "lv_unknown + 1" {
+				resp.StatusCode = http.StatusBadRequest
+				resp.Body = io.NopCloser(strings.NewReader(`<?xml version="1.0" encoding="utf-8"?>
+<exc:exception xmlns:exc="http://www.sap.com/abapxml/types/communicationframework">
+  <namespace id="com.sap.adt"/>
+  <type id="ExceptionEvaluation"/>
+  <message lang="EN">Field &quot;LV_UNKNOWN&quot; is unknown</message>
+</exc:exception>`))
+				return resp, nil
+			}
+			resp.Body = io.NopCloser(strings.NewReader(`<asx:abap xmlns:asx="http://www.sap.com/abapxml" version="1.0"><asx:values><DATA>
+<STPDA_ADT_VARIABLE><ID>@EVAL1</ID><NAME>lv_total * 2</NAME><DECLARED_TYPE_NAME>P</DECLARED_TYPE_NAME><META_TYPE>simple</META_TYPE><VALUE>84.00</VALUE></STPDA_ADT_VARIABLE>
+</DATA></asx:values></asx:abap>`))
+		}
+		return resp, nil
+	})
+	cfg := adt.NewConfig("https://sap.example.com:44300", "user", "pass")
+	server := &Server{adtClient: adt.NewClientWithTransport(cfg, adt.NewTransportWithClient(cfg, doer))}
+	ctx := context.Background()
+	if _, err := server.adtClient.DebuggerAttach(ctx, "DEBUGGEE1", "TESTUSER"); err != nil {
+		t.Fatalf("DebuggerAttach failed: %v", err)
+	}
+
+	call := func(expression string) *mcp.CallToolResult {
+		var req mcp.CallToolRequest
+		req.Params.Arguments = map[string]any{"expression": expression}
+		result, err := server.handleDebuggerEvaluate(ctx, req)
+		if err != nil {
+			t.Fatalf("handleDebuggerEvaluate returned error: %v", err)
+		}
+		return result
+	}
+	text := func(result *mcp.CallToolResult) string {
+		if len(result.Content) == 0 {
+			return ""
+		}
+		tc, _ := result.Content[0].(mcp.TextContent)
+		return tc.Text
+	}
+
+	result := call("lv_total * 2")
+	if result.IsError {
+		t.Fatalf("unexpected tool error: %s", text(result))
+	}
+	if out := text(result); !strings.Contains(out, "lv_total * 2 = 84.00") || !strings.Contains(out, "Type: P") {
+		t.Errorf("unexpected evaluation output: %s", out)
+	}
+
+	result = call("lv_unknown + 1")
+	if !result.IsError || !strings.Contains(text(result), `Field "LV_UNKNOWN" is unknown`) {
+		t.Errorf("expected the debugger's message, got %s", text(result))
+	}
+
+	if result := call(" "); !result.IsError {
+		t.Error("expected an error for an empty expression")
+	}
+}
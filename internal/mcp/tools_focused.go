@@ -93,13 +93,14 @@ func focusedToolSet() map[string]bool {
 		"CallRFC":          true, // Call function module via WebSocket (trigger execution)
 		"MoveObject":       true, // Move object to different package
 
-		// Debugger Session (6)
+		// Debugger Session (7)
 		"DebuggerListen":       true, // Wait for debuggee to hit breakpoint
 		"DebuggerAttach":       true, // Attach to debuggee
 		"DebuggerDetach":       true, // Detach from debug session
 		"DebuggerStep":         true, // Step through code
 		"DebuggerGetStack":     true, // Get call stack
 		"DebuggerGetVariables": true, // Get variable values
+		"DebuggerEvaluate":     true, // Evaluate an expression
 
 		// UI5/Fiori BSP Management (3 read-only - ADT filestore is read-only)
 		"UI5ListApps":       true, // List UI5 applications
@@ -19,6 +19,7 @@ func toolGroups() map[string][]string {
 		"D": { // ABAP debugger (session tools - breakpoints via WebSocket ZADT_VSP)
 			"DebuggerListen", "DebuggerAttach", "DebuggerDetach",
 			"DebuggerStep", "DebuggerGetStack", "DebuggerGetVariables",
+			"DebuggerEvaluate",
 		},
 		"C": { // CTS/Transport tools
 			"ListTransports", "GetTransport",
@@ -47,6 +48,7 @@ func toolGroups() map[string][]string {
 			"SetBreakpoint", "GetBreakpoints", "DeleteBreakpoint",
 			"DebuggerListen", "DebuggerAttach", "DebuggerDetach",
 			"DebuggerStep", "DebuggerGetStack", "DebuggerGetVariables",
+			"DebuggerEvaluate",
 			// AMDP/HANA Debugger - experimental, session management issues
 			"AMDPDebuggerStart", "AMDPDebuggerResume", "AMDPDebuggerStop",
 			"AMDPDebuggerStep", "AMDPGetVariables", "AMDPSetBreakpoint", "AMDPGetBreakpoints",
@@ -440,6 +440,9 @@ func (s *Server) registerAnalysisTools(shouldRegister func(string) bool) {
 				mcp.Required(),
 				mcp.Description("JSON array of trace edges from execution (format: [{caller_name, callee_name}, ...])"),
 			),
+			mcp.WithString("format",
+				mcp.Description("Output format: json (default) or mermaid"),
+			),
 		), s.handleCompareCallGraphs)
 	}
 
@@ -462,6 +465,9 @@ func (s *Server) registerAnalysisTools(shouldRegister func(string) bool) {
 			mcp.WithString("trace_user",
 				mcp.Description("Filter traces by user (defaults to current user)"),
 			),
+			mcp.WithBoolean("start_trace",
+				mcp.Description("Create a trace request before running tests and analyze its trace instead of the latest existing one (default: false)"),
+			),
 		), s.handleTraceExecution)
 	}
 
@@ -571,6 +577,15 @@ func (s *Server) registerDiagnosticsTools(shouldRegister func(string) bool) {
 			mcp.WithString("tool_type",
 				mcp.Description("Analysis type: 'hitlist' (default), 'statements', 'dbAccesses'"),
 			),
+			mcp.WithNumber("min_gross_time_us",
+				mcp.Description("Only entries with at least this gross time in microseconds"),
+			),
+			mcp.WithString("program_pattern",
+				mcp.Description("Only entries whose program matches (exact, or prefix with trailing *, e.g. 'ZCL_DEMO*')"),
+			),
+			mcp.WithNumber("top_n",
+				mcp.Description("Only the N entries with the highest gross time"),
+			),
 		), s.handleGetTrace)
 	}
 
@@ -708,12 +723,18 @@ func (s *Server) registerDebuggerTools(shouldRegister func(string) bool) {
 			mcp.WithString("uri",
 				mcp.Description("Target URI for stepRunToLine/stepJumpToLine (e.g., '/sap/bc/adt/programs/programs/ZTEST/source/main#start=42')"),
 			),
+			mcp.WithNumber("line",
+				mcp.Description("Target line for stepRunToLine/stepJumpToLine; combined with uri (required unless uri already has #start=)"),
+			),
 		), s.handleDebuggerStep)
 	}
 
 	if shouldRegister("DebuggerGetStack") {
 		s.mcpServer.AddTool(mcp.NewTool("DebuggerGetStack",
 			mcp.WithDescription("Get the current call stack during a debug session."),
+			mcp.WithBoolean("with_variables",
+				mcp.Description("Also return the top-level variables of each frame (top 10 frames)"),
+			),
 		), s.handleDebuggerGetStack)
 	}
 
@@ -724,8 +745,21 @@ func (s *Server) registerDebuggerTools(shouldRegister func(string) bool) {
 				mcp.Description("Variable IDs to retrieve (e.g., ['@ROOT'] for top-level, or specific IDs like ['LV_COUNT', 'LS_DATA'])"),
 				mcp.Items(map[string]interface{}{"type": "string"}),
 			),
+			mcp.WithNumber("depth",
+				mcp.Description("Expand structures/tables of the requested variables this many levels deep in one call (default 0)"),
+			),
 		), s.handleDebuggerGetVariables)
 	}
+
+	if shouldRegister("DebuggerEvaluate") {
+		s.mcpServer.AddTool(mcp.NewTool("DebuggerEvaluate",
+			mcp.WithDescription("Evaluate an ABAP expression in the attached debuggee and return its value and type."),
+			mcp.WithString("expression",
+				mcp.Required(),
+				mcp.Description("Expression to evaluate (e.g., 'lv_total * 2', 'ls_order-bukrs', 'lines( lt_items )')"),
+			),
+		), s.handleDebuggerEvaluate)
+	}
 }
 
 // registerSearchTools registers object search tools.
@@ -1831,6 +1865,9 @@ func (s *Server) registerTransportTools(shouldRegister func(string) bool) {
 			mcp.WithString("type",
 				mcp.Description("Type: 'workbench' (default) or 'customizing'"),
 			),
+			mcp.WithString("target_system",
+				mcp.Description("Target system ID (optional, defaults to the transport route)"),
+			),
 		), s.handleCreateTransport)
 	}
 
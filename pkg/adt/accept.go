@@ -0,0 +1,40 @@
+package adt
+
+// --- Content Negotiation ---
+
+// defaultSourceAccept is used for source reads of object types that have no
+// entry in sourceAcceptHeaders.
+const defaultSourceAccept = "text/plain"
+
+// sourceAcceptHeaders is the content negotiation table for source reads
+// (GET .../source/main). Release-specific deviations are applied on top of
+// this table via WithAcceptOverride.
+var sourceAcceptHeaders = map[CreatableObjectType]string{
+	ObjectTypeProgram:        "text/plain",
+	ObjectTypeInclude:        "text/plain",
+	ObjectTypeClass:          "text/plain",
+	ObjectTypeInterface:      "text/plain",
+	ObjectTypeFunctionGroup:  "text/plain",
+	ObjectTypeFunctionMod:    "text/plain",
+	ObjectTypeTable:          "text/plain",
+	ObjectTypeStructure:      "text/plain",
+	ObjectTypeView:           "text/plain",
+	ObjectTypeTransformation: "application/xml",
+	ObjectTypeTypeGroup:      "text/plain",
+	ObjectTypeDDLS:           "text/plain",
+	ObjectTypeBDEF:           "text/plain",
+	ObjectTypeSRVD:           "text/plain",
+	ObjectTypeSRVB:           "*/*", // Service bindings have no text source, only metadata
+}
+
+// sourceAcceptFor returns the Accept header to use when reading the source of
+// the given object type, honoring overrides from WithAcceptOverride.
+func (c *Client) sourceAcceptFor(objType CreatableObjectType) string {
+	if accept, ok := c.config.AcceptOverrides[objType]; ok && accept != "" {
+		return accept
+	}
+	if accept, ok := sourceAcceptHeaders[objType]; ok {
+		return accept
+	}
+	return defaultSourceAccept
+}
@@ -0,0 +1,67 @@
+package adt
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestSourceAcceptFor_Defaults(t *testing.T) {
+	client := NewClient("https://sap.example.com:44300", "user", "pass")
+
+	tests := []struct {
+		objType CreatableObjectType
+		want    string
+	}{
+		{ObjectTypeProgram, "text/plain"},
+		{ObjectTypeInclude, "text/plain"},
+		{ObjectTypeClass, "text/plain"},
+		{ObjectTypeInterface, "text/plain"},
+		{ObjectTypeFunctionGroup, "text/plain"},
+		{ObjectTypeFunctionMod, "text/plain"},
+		{ObjectTypeTable, "text/plain"},
+		{ObjectTypeStructure, "text/plain"},
+		{ObjectTypeView, "text/plain"},
+		{ObjectTypeDDLS, "text/plain"},
+		{ObjectTypeBDEF, "text/plain"},
+		{ObjectTypeSRVD, "text/plain"},
+		{ObjectTypeSRVB, "*/*"},
+		{CreatableObjectType("XXXX/YY"), defaultSourceAccept},
+	}
+
+	for _, tt := range tests {
+		if got := client.sourceAcceptFor(tt.objType); got != tt.want {
+			t.Errorf("sourceAcceptFor(%s) = %q, want %q", tt.objType, got, tt.want)
+		}
+	}
+}
+
+func TestSourceAcceptFor_Override(t *testing.T) {
+	client := NewClient("https://sap.example.com:44300", "user", "pass",
+		WithAcceptOverride(ObjectTypeDDLS, "application/vnd.sap.adt.ddlsource+text"))
+
+	if got := client.sourceAcceptFor(ObjectTypeDDLS); got != "application/vnd.sap.adt.ddlsource+text" {
+		t.Errorf("override not applied: %q", got)
+	}
+	if got := client.sourceAcceptFor(ObjectTypeProgram); got != "text/plain" {
+		t.Errorf("override leaked to other types: %q", got)
+	}
+}
+
+func TestGetDDLS_UsesAcceptOverride(t *testing.T) {
+	mock := &mockTransportClient{
+		responses: map[string]*http.Response{
+			"/sap/bc/adt/ddic/ddl/sources/ZDEMO_V/source/main": newTestResponse("define view ZDEMO_V"),
+		},
+	}
+	cfg := NewConfig("https://sap.example.com:44300", "user", "pass",
+		WithAcceptOverride(ObjectTypeDDLS, "text/x-ddl"))
+	client := NewClientWithTransport(cfg, NewTransportWithClient(cfg, mock))
+
+	if _, err := client.GetDDLS(context.Background(), "ZDEMO_V"); err != nil {
+		t.Fatalf("GetDDLS failed: %v", err)
+	}
+	if got := mock.requests[0].Header.Get("Accept"); got != "text/x-ddl" {
+		t.Errorf("Accept = %q, want text/x-ddl", got)
+	}
+}
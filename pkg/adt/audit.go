@@ -0,0 +1,118 @@
+package adt
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// AuditEntry records one mutating request sent to the ADT server.
+type AuditEntry struct {
+	Timestamp time.Time
+	Operation OperationType
+	ObjectURI string
+	User      string
+	Success   bool
+	Error     string
+}
+
+// auditExemptPrefixes lists endpoints that use modifying methods without
+// changing repository content: POST-based queries and checks, and the
+// debugger, whose state lives in the debuggee session. Every other
+// modifying request is reported to the audit hook.
+var auditExemptPrefixes = []string{
+	"/sap/bc/adt/abapsource/codecompletion/",
+	"/sap/bc/adt/abapsource/prettyprinter",
+	"/sap/bc/adt/abapsource/typehierarchy",
+	"/sap/bc/adt/abapunit/testruns",
+	"/sap/bc/adt/atc/runs",
+	"/sap/bc/adt/atc/worklists",
+	"/sap/bc/adt/cai/callgraph",
+	"/sap/bc/adt/checkruns",
+	"/sap/bc/adt/cts/transportchecks",
+	"/sap/bc/adt/datapreview/",
+	"/sap/bc/adt/debugger",
+	"/sap/bc/adt/navigation/target",
+	"/sap/bc/adt/oo/typehierarchy",
+	"/sap/bc/adt/repository/informationsystem/",
+	"/sap/bc/adt/repository/nodestructure",
+}
+
+// auditOperation classifies a request for the audit hook. It reports false
+// for reads, lock handling and the exempt endpoints above; any other POST,
+// PUT, PATCH or DELETE is audited, as OpUpdate unless a more specific
+// operation applies.
+func auditOperation(method, path string, query url.Values) (OperationType, bool) {
+	if !isModifyingMethod(method) {
+		return 0, false
+	}
+	for _, prefix := range auditExemptPrefixes {
+		if strings.HasPrefix(path, prefix) {
+			return 0, false
+		}
+	}
+
+	switch method {
+	case http.MethodDelete:
+		return OpDelete, true
+	case http.MethodPut, http.MethodPatch:
+		return OpUpdate, true
+	}
+
+	if _, ok := query["_action"]; ok {
+		return 0, false // LOCK / UNLOCK
+	}
+	switch {
+	case path == "/sap/bc/adt/activation":
+		return OpActivate, true
+	case strings.HasPrefix(path, "/sap/bc/adt/cts/transportrequests"):
+		return OpTransport, true
+	case isCreationPath(path):
+		return OpCreate, true
+	}
+	return OpUpdate, true
+}
+
+// isCreationPath reports whether path is the collection URL CreateObject
+// posts to for one of the supported object types.
+func isCreationPath(path string) bool {
+	for _, info := range objectTypes {
+		if info.creationPath == path {
+			return true
+		}
+		if prefix, suffix, ok := strings.Cut(info.creationPath, "%s"); ok &&
+			len(path) > len(prefix)+len(suffix) &&
+			strings.HasPrefix(path, prefix) && strings.HasSuffix(path, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// audit reports the outcome of a mutating request, after any retries, to
+// the configured AuditHook.
+func (t *Transport) audit(method, path string, query url.Values, reqErr error) {
+	if t.config.AuditHook == nil {
+		return
+	}
+	if idx := strings.IndexByte(path, '?'); idx >= 0 {
+		path = path[:idx]
+	}
+	op, ok := auditOperation(method, path, query)
+	if !ok {
+		return
+	}
+
+	entry := AuditEntry{
+		Timestamp: time.Now(),
+		Operation: op,
+		ObjectURI: path,
+		User:      t.config.Username,
+		Success:   reqErr == nil,
+	}
+	if reqErr != nil {
+		entry.Error = reqErr.Error()
+	}
+	t.config.AuditHook(entry)
+}
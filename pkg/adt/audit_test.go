@@ -0,0 +1,87 @@
+package adt
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+)
+
+func TestWithAuditHook(t *testing.T) {
+	var entries []AuditEntry
+	mock := &methodPathMock{routes: []routedResponse{
+		resp("", "/discovery", http.StatusOK, ""),
+		resp(http.MethodPost, "/sap/bc/adt/programs/programs/zdemo_report", http.StatusOK, lockResponseXML),
+		resp(http.MethodPut, "/source/main", http.StatusOK, ""),
+		resp(http.MethodDelete, "/sap/bc/adt/programs/programs/zdemo_report", http.StatusInternalServerError, "dump"),
+	}}
+	cfg := NewConfig("https://sap.example.com:44300", "TESTUSER", "pass",
+		WithAuditHook(func(e AuditEntry) { entries = append(entries, e) }))
+	client := NewClientWithTransport(cfg, NewTransportWithClient(cfg, mock))
+	ctx := context.Background()
+
+	objectURL := "/sap/bc/adt/programs/programs/zdemo_report"
+	if _, err := client.LockObject(ctx, objectURL, "MODIFY"); err != nil {
+		t.Fatalf("LockObject failed: %v", err)
+	}
+	if len(entries) != 0 {
+		t.Fatalf("lock must not be audited, got %+v", entries)
+	}
+
+	if err := client.UpdateSource(ctx, objectURL+"/source/main", "REPORT zdemo_report.", "TESTHANDLE", ""); err != nil {
+		t.Fatalf("UpdateSource failed: %v", err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("expected one audit entry after the write, got %d", len(entries))
+	}
+	e := entries[0]
+	if e.Operation != OpUpdate || !e.Success || e.Error != "" {
+		t.Errorf("write entry = %+v", e)
+	}
+	if e.ObjectURI != objectURL+"/source/main" || e.User != "TESTUSER" || e.Timestamp.IsZero() {
+		t.Errorf("write entry = %+v", e)
+	}
+
+	if err := client.DeleteObject(ctx, objectURL, "TESTHANDLE", ""); err == nil {
+		t.Fatal("expected DeleteObject to fail")
+	}
+	if len(entries) != 2 {
+		t.Fatalf("expected the failed delete to be audited, got %d entries", len(entries))
+	}
+	if e := entries[1]; e.Operation != OpDelete || e.Success || e.Error == "" {
+		t.Errorf("delete entry = %+v", e)
+	}
+}
+
+func TestAuditOperation(t *testing.T) {
+	tests := []struct {
+		method string
+		path   string
+		query  url.Values
+		want   OperationType
+		ok     bool
+	}{
+		{http.MethodGet, "/sap/bc/adt/oo/classes/zcl_demo_order", nil, 0, false},
+		{http.MethodPost, "/sap/bc/adt/oo/classes", nil, OpCreate, true},
+		{http.MethodPost, "/sap/bc/adt/functions/groups/zdemo_fg/fmodules", nil, OpCreate, true},
+		{http.MethodPost, "/sap/bc/adt/oo/classes/zcl_demo_order", url.Values{"_action": {"LOCK"}}, 0, false},
+		{http.MethodPost, "/sap/bc/adt/activation", nil, OpActivate, true},
+		{http.MethodPost, "/sap/bc/adt/cts/transportrequests/TR-EXAMPLE/newreleasejobs", nil, OpTransport, true},
+		{http.MethodPost, "/sap/bc/adt/checkruns", nil, 0, false},
+		{http.MethodPut, "/sap/bc/adt/debugger/stack/type/ABAP/position/3", nil, 0, false},
+		{http.MethodPost, "/sap/bc/adt/abapunit/testruns", nil, 0, false},
+		{http.MethodPost, "/sap/bc/adt/repository/nodestructure", nil, 0, false},
+		{http.MethodPost, "/sap/bc/adt/oo/classes/zcl_demo_order/includes", nil, OpUpdate, true},
+		{http.MethodPost, "/sap/bc/cts_abapvcs/repository/DEMO/clone", nil, OpUpdate, true},
+		{http.MethodPost, "/sap/bc/adt/filestore/ui5-bsp/objects/zdemo_app%2fwebapp/content", nil, OpUpdate, true},
+		{http.MethodPost, "/sap/bc/adt/runtime/traces/abaptraces/requests", nil, OpUpdate, true},
+		{http.MethodHead, "/sap/bc/adt/core/discovery", nil, 0, false},
+		{http.MethodDelete, "/sap/bc/adt/oo/classes/zcl_demo_order", nil, OpDelete, true},
+	}
+	for _, tt := range tests {
+		got, ok := auditOperation(tt.method, tt.path, tt.query)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("auditOperation(%s %s) = %q, %v; want %q, %v", tt.method, tt.path, got, ok, tt.want, tt.ok)
+		}
+	}
+}
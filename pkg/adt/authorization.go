@@ -0,0 +1,227 @@
+package adt
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/oisee/vibing-steampunk/pkg/abaplint"
+)
+
+// --- Authorization Object Operations ---
+
+// AuthField is a field of an authorization object.
+type AuthField struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	DataElement string `json:"dataElement,omitempty"`
+	CheckTable  string `json:"checkTable,omitempty"`
+	IsActivity  bool   `json:"isActivityField,omitempty"` // ACTVT
+}
+
+// AuthActivity is a permitted value of the ACTVT field.
+type AuthActivity struct {
+	Code        string `json:"code"` // e.g. "01", "02", "03"
+	Description string `json:"description,omitempty"`
+}
+
+// AuthObject represents an authorization object (SUSO) with its class,
+// fields and permitted activities.
+type AuthObject struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description,omitempty"`
+	Class       string         `json:"class"`
+	Fields      []AuthField    `json:"fields"`
+	Activities  []AuthActivity `json:"activities,omitempty"`
+}
+
+// GetAuthorizationObject retrieves an authorization object with its
+// authorization class, fields and permitted activities.
+func (c *Client) GetAuthorizationObject(ctx context.Context, name string) (*AuthObject, error) {
+	name = strings.ToUpper(name)
+
+	path := fmt.Sprintf("/sap/bc/adt/aps/iam/suso/%s", url.PathEscape(strings.ToLower(name)))
+	resp, err := c.transport.Request(ctx, path, &RequestOptions{
+		Method: http.MethodGet,
+		Accept: "application/xml",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("getting authorization object: %w", unsupportedIfEndpointMissing(err, "authorization objects"))
+	}
+
+	return parseAuthObject(resp.Body)
+}
+
+func parseAuthObject(data []byte) (*AuthObject, error) {
+	// Strip namespace prefixes
+	xmlStr := string(data)
+	xmlStr = strings.ReplaceAll(xmlStr, "suso:", "")
+	xmlStr = strings.ReplaceAll(xmlStr, "adtcore:", "")
+
+	type field struct {
+		Name        string `xml:"name,attr"`
+		Description string `xml:"description,attr"`
+		DataElement string `xml:"dataElement,attr"`
+		CheckTable  string `xml:"checkTable,attr"`
+	}
+	type activity struct {
+		Code        string `xml:"code,attr"`
+		Description string `xml:"description,attr"`
+	}
+	type authRoot struct {
+		Name        string     `xml:"name,attr"`
+		Description string     `xml:"description,attr"`
+		Class       string     `xml:"objectClass,attr"`
+		Fields      []field    `xml:"fields>field"`
+		Activities  []activity `xml:"activities>activity"`
+	}
+
+	var root authRoot
+	if err := xml.Unmarshal([]byte(xmlStr), &root); err != nil {
+		return nil, fmt.Errorf("parsing authorization object: %w", err)
+	}
+
+	obj := &AuthObject{
+		Name:        root.Name,
+		Description: root.Description,
+		Class:       root.Class,
+		Fields:      []AuthField{},
+	}
+	for _, f := range root.Fields {
+		obj.Fields = append(obj.Fields, AuthField{
+			Name:        f.Name,
+			Description: f.Description,
+			DataElement: f.DataElement,
+			CheckTable:  f.CheckTable,
+			IsActivity:  f.Name == "ACTVT",
+		})
+	}
+	for _, a := range root.Activities {
+		obj.Activities = append(obj.Activities, AuthActivity{Code: a.Code, Description: a.Description})
+	}
+
+	return obj, nil
+}
+
+// --- AUTHORITY-CHECK Scanner ---
+
+// AuthCheckField is one ID ... FIELD/DUMMY pair of an AUTHORITY-CHECK.
+type AuthCheckField struct {
+	ID    string `json:"id"`              // field name, e.g. "ACTVT"
+	Value string `json:"value,omitempty"` // checked value (literal or variable)
+	Dummy bool   `json:"dummy,omitempty"` // ID ... DUMMY (field not checked)
+}
+
+// AuthCheck is an AUTHORITY-CHECK statement found in source.
+type AuthCheck struct {
+	Object  string           `json:"object"`            // auth object name, quotes stripped for literals
+	Dynamic bool             `json:"dynamic,omitempty"` // object given by a variable
+	ForUser string           `json:"forUser,omitempty"` // FOR USER operand
+	Fields  []AuthCheckField `json:"fields"`
+	Line    int              `json:"line"`
+}
+
+// FindAuthorityChecks scans ABAP source for AUTHORITY-CHECK statements and
+// returns the checked object, its ID/FIELD pairs and the 1-based line of
+// each check. Chained statements (AUTHORITY-CHECK: OBJECT ..., OBJECT ...)
+// yield one AuthCheck per chain element.
+func FindAuthorityChecks(source string) []AuthCheck {
+	tokens := (&abaplint.Lexer{}).Run(source)
+	stmts := (&abaplint.StatementParser{}).Parse(tokens)
+
+	var checks []AuthCheck
+	for _, stmt := range stmts {
+		toks := stmt.Tokens
+		if len(toks) < 4 || stmt.FirstTokenStr() != "AUTHORITY" ||
+			toks[1].Str != "-" || !strings.EqualFold(toks[2].Str, "CHECK") {
+			continue
+		}
+		if check, ok := parseAuthorityCheck(toks[3:]); ok {
+			checks = append(checks, check)
+		}
+	}
+	return checks
+}
+
+// parseAuthorityCheck parses the tokens after AUTHORITY-CHECK.
+func parseAuthorityCheck(toks []abaplint.Token) (AuthCheck, bool) {
+	var check AuthCheck
+	found := false
+	for i := 0; i < len(toks); i++ {
+		switch strings.ToUpper(toks[i].Str) {
+		case "OBJECT":
+			if i+1 >= len(toks) {
+				return check, false
+			}
+			operand, n := joinAdjacentTokens(toks[i+1:])
+			check.Object, check.Dynamic = unquoteOperand(operand, toks[i+1])
+			check.Line = toks[i].Row
+			found = true
+			i += n
+		case "USER":
+			if i+1 < len(toks) {
+				operand, n := joinAdjacentTokens(toks[i+1:])
+				check.ForUser = operand
+				i += n
+			}
+		case "ID":
+			if i+1 >= len(toks) {
+				continue
+			}
+			id, n := joinAdjacentTokens(toks[i+1:])
+			id, _ = unquoteOperand(id, toks[i+1])
+			field := AuthCheckField{ID: id}
+			i += n
+			if i+1 >= len(toks) {
+				// Truncated clause: ID without DUMMY or FIELD.
+				check.Fields = append(check.Fields, field)
+				continue
+			}
+			switch strings.ToUpper(toks[i+1].Str) {
+			case "DUMMY":
+				field.Dummy = true
+				i++
+			case "FIELD":
+				if i+2 < len(toks) {
+					value, m := joinAdjacentTokens(toks[i+2:])
+					field.Value = value
+					i += 1 + m
+				}
+			}
+			check.Fields = append(check.Fields, field)
+		}
+	}
+	return check, found
+}
+
+// joinAdjacentTokens joins tokens that touch each other (no whitespace in
+// between), e.g. "ls", "-", "a" → "ls-a", and returns how many were used.
+func joinAdjacentTokens(tokens []abaplint.Token) (string, int) {
+	if len(tokens) == 0 {
+		return "", 0
+	}
+	joined := tokens[0].Str
+	prev := tokens[0]
+	n := 1
+	for _, tok := range tokens[1:] {
+		if tok.Str == "." || tok.Str == "," || tok.Row != prev.Row || tok.Col != prev.Col+len(prev.Str) {
+			break
+		}
+		joined += tok.Str
+		prev = tok
+		n++
+	}
+	return joined, n
+}
+
+// unquoteOperand strips the quotes of a string literal operand and reports
+// whether the operand is dynamic (not a literal).
+func unquoteOperand(operand string, first abaplint.Token) (string, bool) {
+	if first.Type == abaplint.TokenString && len(operand) >= 2 {
+		return operand[1 : len(operand)-1], false
+	}
+	return operand, true
+}
@@ -0,0 +1,96 @@
+package adt
+
+import "testing"
+
+const sampleAuthObjectXML = `<?xml version="1.0" encoding="UTF-8"?>
+<suso:authorizationObject xmlns:suso="http://www.sap.com/adt/aps/iam/suso" xmlns:adtcore="http://www.sap.com/adt/core"
+    adtcore:name="Z_DEMO_ORD" adtcore:description="Demo order authorization" suso:objectClass="ZDEM">
+  <suso:fields>
+    <suso:field suso:name="ZDEMO_BUKR" suso:description="Company code" suso:dataElement="BUKRS" suso:checkTable="T001"/>
+    <suso:field suso:name="ACTVT" suso:description="Activity" suso:dataElement="ACTIV_AUTH" suso:checkTable="TACT"/>
+  </suso:fields>
+  <suso:activities>
+    <suso:activity suso:code="01" suso:description="Create or generate"/>
+    <suso:activity suso:code="02" suso:description="Change"/>
+    <suso:activity suso:code="03" suso:description="Display"/>
+  </suso:activities>
+</suso:authorizationObject>`
+
+func TestParseAuthObject(t *testing.T) {
+	obj, err := parseAuthObject([]byte(sampleAuthObjectXML))
+	if err != nil {
+		t.Fatalf("parseAuthObject failed: %v", err)
+	}
+	if obj.Name != "Z_DEMO_ORD" || obj.Class != "ZDEM" {
+		t.Errorf("unexpected header: %+v", obj)
+	}
+	if len(obj.Fields) != 2 {
+		t.Fatalf("expected 2 fields, got %d", len(obj.Fields))
+	}
+	if f := obj.Fields[0]; f.Name != "ZDEMO_BUKR" || f.DataElement != "BUKRS" || f.CheckTable != "T001" || f.IsActivity {
+		t.Errorf("unexpected first field: %+v", f)
+	}
+	if !obj.Fields[1].IsActivity {
+		t.Error("ACTVT should be flagged as activity field")
+	}
+	if len(obj.Activities) != 3 || obj.Activities[2].Code != "03" {
+		t.Errorf("unexpected activities: %+v", obj.Activities)
+	}
+}
+
+func TestFindAuthorityChecks(t *testing.T) {
+	source := `REPORT zdemo_auth.
+DATA lv_tcode TYPE tcode.
+AUTHORITY-CHECK OBJECT 'S_TCODE' ID 'TCD' FIELD lv_tcode.
+IF sy-subrc <> 0.
+ENDIF.
+* AUTHORITY-CHECK OBJECT 'S_COMMENTED' ID 'X' FIELD 'Y'.
+AUTHORITY-CHECK:
+  OBJECT 'Z_DEMO_ORD' ID 'ZDEMO_BUKR' FIELD ls_order-bukrs
+                      ID 'ACTVT' FIELD '02',
+  OBJECT lv_object FOR USER lv_user ID 'ACTVT' DUMMY.
+`
+	checks := FindAuthorityChecks(source)
+	if len(checks) != 3 {
+		t.Fatalf("expected 3 checks, got %d: %+v", len(checks), checks)
+	}
+
+	first := checks[0]
+	if first.Object != "S_TCODE" || first.Dynamic || first.Line != 3 {
+		t.Errorf("unexpected first check: %+v", first)
+	}
+	if len(first.Fields) != 1 || first.Fields[0].ID != "TCD" || first.Fields[0].Value != "lv_tcode" {
+		t.Errorf("unexpected first check fields: %+v", first.Fields)
+	}
+
+	chained := checks[1]
+	if chained.Object != "Z_DEMO_ORD" || chained.Line != 8 {
+		t.Errorf("unexpected chained check: %+v", chained)
+	}
+	if len(chained.Fields) != 2 ||
+		chained.Fields[0].ID != "ZDEMO_BUKR" || chained.Fields[0].Value != "ls_order-bukrs" ||
+		chained.Fields[1].ID != "ACTVT" || chained.Fields[1].Value != "'02'" {
+		t.Errorf("unexpected chained fields: %+v", chained.Fields)
+	}
+
+	dynamic := checks[2]
+	if dynamic.Object != "lv_object" || !dynamic.Dynamic || dynamic.ForUser != "lv_user" || dynamic.Line != 10 {
+		t.Errorf("unexpected dynamic check: %+v", dynamic)
+	}
+	if len(dynamic.Fields) != 1 || !dynamic.Fields[0].Dummy {
+		t.Errorf("expected DUMMY field, got %+v", dynamic.Fields)
+	}
+}
+
+func TestFindAuthorityChecks_TruncatedID(t *testing.T) {
+	for _, source := range []string{
+		"AUTHORITY-CHECK OBJECT 'S_X' ID ls-a",
+		"AUTHORITY-CHECK OBJECT 'S_X' ID",
+		"AUTHORITY-CHECK OBJECT 'S_X' ID 'ACTVT' FIELD",
+	} {
+		checks := FindAuthorityChecks(source)
+		if len(checks) != 1 || checks[0].Object != "S_X" {
+			t.Errorf("FindAuthorityChecks(%q) = %+v", source, checks)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package adt
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// SaveBreakpoints writes breakpoints to path as JSON so a later session can
+// restore them with LoadBreakpoints and RestoreBreakpoints. The file is
+// replaced atomically.
+func SaveBreakpoints(path string, bps []Breakpoint) error {
+	if bps == nil {
+		bps = []Breakpoint{}
+	}
+	data, err := json.MarshalIndent(bps, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding breakpoints: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("saving breakpoints: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("saving breakpoints: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("saving breakpoints: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("saving breakpoints: %w", err)
+	}
+	return nil
+}
+
+// LoadBreakpoints reads breakpoints saved by SaveBreakpoints. A missing file
+// is not an error and yields no breakpoints.
+func LoadBreakpoints(path string) ([]Breakpoint, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("loading breakpoints: %w", err)
+	}
+
+	var bps []Breakpoint
+	if err := json.Unmarshal(data, &bps); err != nil {
+		return nil, fmt.Errorf("parsing breakpoint file %s: %w", path, err)
+	}
+	return bps, nil
+}
+
+// RestoreBreakpoints re-registers saved breakpoints as external breakpoints
+// for user and returns them with the IDs assigned by the server. Breakpoints
+// whose object no longer exists are returned in skipped; any other failure
+// aborts the restore.
+func (c *Client) RestoreBreakpoints(ctx context.Context, bps []Breakpoint, user string) (restored, skipped []Breakpoint, err error) {
+	for _, bp := range bps {
+		// Drop the server-assigned state of the previous session.
+		bp.ID = ""
+		bp.ActualLine = 0
+		bp.IsActive = false
+
+		resp, err := c.SetExternalBreakpoint(ctx, &BreakpointRequest{
+			User:        user,
+			Breakpoints: []Breakpoint{bp},
+		})
+		// A vanished object shows up either as 404 or as a breakpoint the
+		// server answers with an errorMessage, which the parser drops.
+		if IsNotFoundError(err) || (err == nil && (resp == nil || len(resp.Breakpoints) == 0)) {
+			skipped = append(skipped, bp)
+			continue
+		}
+		if err != nil {
+			return restored, skipped, err
+		}
+		bp.ID = resp.Breakpoints[0].ID
+		restored = append(restored, bp)
+	}
+	return restored, skipped, nil
+}
@@ -0,0 +1,97 @@
+package adt
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSaveLoadBreakpoints(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "breakpoints.json")
+
+	bps, err := LoadBreakpoints(path)
+	if err != nil || bps != nil {
+		t.Fatalf("missing file: got %v, %v", bps, err)
+	}
+
+	saved := []Breakpoint{
+		NewLineBreakpoint("/sap/bc/adt/programs/programs/zdemo_report", 42),
+		NewExceptionBreakpoint("CX_SY_ZERODIVIDE"),
+	}
+	if err := SaveBreakpoints(path, saved); err != nil {
+		t.Fatalf("SaveBreakpoints failed: %v", err)
+	}
+	bps, err = LoadBreakpoints(path)
+	if err != nil {
+		t.Fatalf("LoadBreakpoints failed: %v", err)
+	}
+	if len(bps) != 2 || bps[0].Line != 42 || bps[1].Exception != "CX_SY_ZERODIVIDE" {
+		t.Errorf("round trip = %+v", bps)
+	}
+}
+
+func TestClient_RestoreBreakpoints(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "breakpoints.json")
+	saved := []Breakpoint{
+		NewLineBreakpoint("/sap/bc/adt/programs/programs/zdemo_report", 42),
+		NewLineBreakpoint("/sap/bc/adt/programs/programs/zdemo_deleted", 7),
+		NewLineBreakpoint("/sap/bc/adt/oo/classes/zcl_demo_order", 15),
+	}
+	saved[0].ID = "OLD-SESSION-ID"
+	if err := SaveBreakpoints(path, saved); err != nil {
+		t.Fatalf("SaveBreakpoints failed: %v", err)
+	}
+
+	var bodies []string
+	mock := &funcMockClient{doFunc: func(req *http.Request) (*http.Response, error) {
+		if strings.Contains(req.URL.Path, "discovery") {
+			r := newTestResponse("ok")
+			r.Header.Set("X-CSRF-Token", "test-token")
+			return r, nil
+		}
+		data, _ := io.ReadAll(req.Body)
+		body := string(data)
+		bodies = append(bodies, body)
+		switch {
+		case strings.Contains(body, "zdemo_deleted"):
+			return newTestResponse(`<dbg:breakpoints xmlns:dbg="http://www.sap.com/adt/debugger">
+  <breakpoint kind="line" errorMessage="Program ZDEMO_DELETED does not exist"/>
+</dbg:breakpoints>`), nil
+		case strings.Contains(body, "zcl_demo_order"):
+			return newMockResponse(http.StatusNotFound, "not found", nil), nil
+		}
+		return newTestResponse(`<dbg:breakpoints xmlns:dbg="http://www.sap.com/adt/debugger">
+  <breakpoint kind="line" id="KIND=0.MAIN_PROGRAM=ZDEMO_REPORT.LINE_NR=42"/>
+</dbg:breakpoints>`), nil
+	}}
+	cfg := NewConfig("https://sap.example.com:44300", "user", "pass")
+	client := NewClientWithTransport(cfg, NewTransportWithClient(cfg, mock))
+
+	bps, err := LoadBreakpoints(path)
+	if err != nil {
+		t.Fatalf("LoadBreakpoints failed: %v", err)
+	}
+	restored, skipped, err := client.RestoreBreakpoints(context.Background(), bps, "TESTUSER")
+	if err != nil {
+		t.Fatalf("RestoreBreakpoints failed: %v", err)
+	}
+
+	if len(bodies) != 3 {
+		t.Fatalf("expected one registration per saved breakpoint, got %d", len(bodies))
+	}
+	if !strings.Contains(bodies[0], "zdemo_report") || strings.Contains(bodies[0], "OLD-SESSION-ID") {
+		t.Errorf("unexpected registration body: %s", bodies[0])
+	}
+	if len(restored) != 1 {
+		t.Fatalf("expected the missing objects to be skipped, got %+v", restored)
+	}
+	if restored[0].ID != "KIND=0.MAIN_PROGRAM=ZDEMO_REPORT.LINE_NR=42" || restored[0].Line != 42 {
+		t.Errorf("restored = %+v", restored[0])
+	}
+	if len(skipped) != 2 || skipped[0].Line != 7 || skipped[1].Line != 15 {
+		t.Errorf("skipped = %+v", skipped)
+	}
+}
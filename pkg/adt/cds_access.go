@@ -0,0 +1,111 @@
+package adt
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// --- CDS Access Controls (DCLS) ---
+
+// CDSAccessControl is an access control (DCL source) protecting a CDS entity.
+type CDSAccessControl struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Package     string `json:"package,omitempty"`
+}
+
+var (
+	dclCommentRegex = regexp.MustCompile(`(?s)/\*.*?\*/|//[^\n]*`)
+	dclGrantRegex   = regexp.MustCompile(`(?i)\bgrant\s+select\s+on\s+([A-Za-z0-9_/]+)`)
+)
+
+// GetDCLS retrieves the source of an access control (DCL source).
+// Supports namespaced access controls like /DMO/I_TRAVEL_U.
+func (c *Client) GetDCLS(ctx context.Context, dclsName string) (string, error) {
+	dclsName = strings.ToUpper(dclsName)
+
+	sourcePath := fmt.Sprintf("/sap/bc/adt/acm/dcl/sources/%s/source/main", url.PathEscape(dclsName))
+	resp, err := c.transport.Request(ctx, sourcePath, &RequestOptions{
+		Method: http.MethodGet,
+		Accept: "text/plain",
+	})
+	if err != nil {
+		return "", fmt.Errorf("getting DCLS source: %w", err)
+	}
+
+	return string(resp.Body), nil
+}
+
+// ParseDCLProtectedEntities returns the CDS entities a DCL source grants
+// access to, i.e. the ENTITY of every `grant select on ENTITY`, uppercased
+// and in source order. Comments are ignored.
+func ParseDCLProtectedEntities(source string) []string {
+	source = dclCommentRegex.ReplaceAllString(source, "")
+
+	var entities []string
+	seen := map[string]bool{}
+	for _, m := range dclGrantRegex.FindAllStringSubmatch(source, -1) {
+		entity := strings.ToUpper(m[1])
+		if !seen[entity] {
+			seen[entity] = true
+			entities = append(entities, entity)
+		}
+	}
+	return entities
+}
+
+// GetDCLSProtectedEntity returns the CDS entity protected by an access
+// control, parsed from the `define role ... grant select on ENTITY` of its
+// source. If the source defines several roles, the first entity is returned.
+func (c *Client) GetDCLSProtectedEntity(ctx context.Context, dclsName string) (string, error) {
+	source, err := c.GetDCLS(ctx, dclsName)
+	if err != nil {
+		return "", err
+	}
+
+	entities := ParseDCLProtectedEntities(source)
+	if len(entities) == 0 {
+		return "", fmt.Errorf("access control %s has no grant select statement", strings.ToUpper(dclsName))
+	}
+	return entities[0], nil
+}
+
+// GetEntityAccessControls finds the access controls protecting a CDS entity.
+// Candidates come from the where-used list of the entity; each DCL source is
+// then read to keep only those that actually grant select on the entity, not
+// ones that merely reference it in a condition.
+func (c *Client) GetEntityAccessControls(ctx context.Context, entityName string) ([]CDSAccessControl, error) {
+	entityName = strings.ToUpper(entityName)
+
+	usages, err := c.GetCDSImpactAnalysis(ctx, entityName)
+	if err != nil {
+		return nil, err
+	}
+
+	controls := []CDSAccessControl{}
+	for _, obj := range usages.ImpactedObjects {
+		if !strings.HasPrefix(obj.Type, "DCLS") {
+			continue
+		}
+		source, err := c.GetDCLS(ctx, obj.Name)
+		if err != nil {
+			return nil, err
+		}
+		for _, entity := range ParseDCLProtectedEntities(source) {
+			if entity == entityName {
+				controls = append(controls, CDSAccessControl{
+					Name:        strings.ToUpper(obj.Name),
+					Description: obj.Description,
+					Package:     obj.Package,
+				})
+				break
+			}
+		}
+	}
+
+	return controls, nil
+}
@@ -0,0 +1,80 @@
+package adt
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+const sampleDCLRole = `@EndUserText.label: 'Access control for demo orders'
+@MappingRole: true
+define role ZDEMO_I_ORDER_DCL {
+  // grant select on ZDEMO_I_COMMENTED_OUT
+  grant select on ZDEMO_I_ORDER
+    where ( SalesOrg ) = aspect pfcg_auth( Z_DEMO_ORD, VKORG, ACTVT = '03' );
+  /* grant select on ZDEMO_I_BLOCK_COMMENT */
+  grant
+    select on zdemo_i_order_item
+    where inheriting conditions from entity ZDEMO_I_ORDER;
+}`
+
+func TestParseDCLProtectedEntities(t *testing.T) {
+	got := ParseDCLProtectedEntities(sampleDCLRole)
+	want := []string{"ZDEMO_I_ORDER", "ZDEMO_I_ORDER_ITEM"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseDCLProtectedEntities = %v, want %v", got, want)
+	}
+	if got := ParseDCLProtectedEntities("define role ZDEMO_EMPTY { }"); len(got) != 0 {
+		t.Errorf("expected no entities, got %v", got)
+	}
+}
+
+func TestClient_GetEntityAccessControls(t *testing.T) {
+	usagesXML := `<?xml version="1.0" encoding="UTF-8"?>
+<usageReferences:usageReferenceResult xmlns:usageReferences="http://www.sap.com/adt/ris/usageReferences" xmlns:adtcore="http://www.sap.com/adt/core">
+  <usageReferences:referencedObjects>
+    <usageReferences:referencedObject usageReferences:isResult="true">
+      <adtcore:adtObject adtcore:type="DCLS/DL" adtcore:name="ZDEMO_I_ORDER_DCL" adtcore:description="Demo order access">
+        <adtcore:packageRef adtcore:name="$ZDEMO"/>
+      </adtcore:adtObject>
+    </usageReferences:referencedObject>
+    <usageReferences:referencedObject usageReferences:isResult="true">
+      <adtcore:adtObject adtcore:type="DCLS/DL" adtcore:name="ZDEMO_I_CUSTOMER_DCL"/>
+    </usageReferences:referencedObject>
+    <usageReferences:referencedObject usageReferences:isResult="true">
+      <adtcore:adtObject adtcore:type="DDLS/DF" adtcore:name="ZDEMO_C_ORDER"/>
+    </usageReferences:referencedObject>
+  </usageReferences:referencedObjects>
+</usageReferences:usageReferenceResult>`
+	customerRole := `define role ZDEMO_I_CUSTOMER_DCL {
+  grant select on ZDEMO_I_CUSTOMER where exists ( ZDEMO_I_ORDER );
+}`
+
+	mock := &methodPathMock{routes: []routedResponse{
+		resp("", "discovery", http.StatusOK, "ok"),
+		resp(http.MethodPost, "/usageReferences", http.StatusOK, usagesXML),
+		resp(http.MethodGet, "/acm/dcl/sources/ZDEMO_I_ORDER_DCL/source/main", http.StatusOK, sampleDCLRole),
+		resp(http.MethodGet, "/acm/dcl/sources/ZDEMO_I_CUSTOMER_DCL/source/main", http.StatusOK, customerRole),
+	}}
+	cfg := NewConfig("https://sap.example.com:44300", "user", "pass")
+	client := NewClientWithTransport(cfg, NewTransportWithClient(cfg, mock))
+	ctx := context.Background()
+
+	controls, err := client.GetEntityAccessControls(ctx, "zdemo_i_order")
+	if err != nil {
+		t.Fatalf("GetEntityAccessControls failed: %v", err)
+	}
+	want := []CDSAccessControl{{Name: "ZDEMO_I_ORDER_DCL", Description: "Demo order access", Package: "$ZDEMO"}}
+	if !reflect.DeepEqual(controls, want) {
+		t.Errorf("controls = %+v, want %+v", controls, want)
+	}
+
+	entity, err := client.GetDCLSProtectedEntity(ctx, "ZDEMO_I_CUSTOMER_DCL")
+	if err != nil {
+		t.Fatalf("GetDCLSProtectedEntity failed: %v", err)
+	}
+	if entity != "ZDEMO_I_CUSTOMER" {
+		t.Errorf("protected entity = %q, want ZDEMO_I_CUSTOMER", entity)
+	}
+}
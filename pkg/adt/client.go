@@ -3,16 +3,23 @@ package adt
 import (
 	"context"
 	"encoding/xml"
+	"errors"
 	"fmt"
 	"html"
 	"net/http"
 	"net/url"
 	"os"
+	"path"
+	"regexp"
 	"sort"
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
+	"unicode"
+
+	"github.com/oisee/vibing-steampunk/pkg/abaplint"
 )
 
 // Client is the main ADT API client.
@@ -24,23 +31,70 @@ type Client struct {
 	keepAliveCancel context.CancelFunc
 	keepAliveDone   chan struct{}
 	keepAliveMu     sync.Mutex
+
+	// Pending confirmation tokens for destructive batch operations
+	confirmations *confirmationStore
+
+	// Result of Config.Validate at construction time (see ConfigError)
+	configErr error
+
+	// Parsed class objectstructures shared between structure readers
+	structures *structureCache
+
+	// Client-wide limit on in-flight batch requests (see WithMaxConcurrency)
+	batchSlots *batchLimiter
+
+	// Object URL → package, consulted by safety and transport checks
+	packages *packageCache
+
+	// Parsed ADT discovery document (see GetDiscovery)
+	discovery *discoveryCache
+
+	// Set between DebuggerAttach and DebuggerDetach; debugger writes
+	// require an attached debuggee
+	debugAttached atomic.Bool
 }
 
 // NewClient creates a new ADT client with the given configuration.
+// The configuration is validated up front; callers should check
+// ConfigError before issuing requests.
 func NewClient(baseURL, username, password string, opts ...Option) *Client {
 	cfg := NewConfig(baseURL, username, password, opts...)
 	return &Client{
-		transport: NewTransport(cfg),
-		config:    cfg,
+		transport:     NewTransport(cfg),
+		config:        cfg,
+		confirmations: newConfirmationStore(),
+		configErr:     cfg.Validate(),
+		structures:    newStructureCache(cfg.StructureCacheTTL),
+		batchSlots:    newBatchLimiter(cfg.MaxConcurrency),
+		packages:      newPackageCache(cfg.PackageCacheSize),
+		discovery:     &discoveryCache{},
 	}
 }
 
+// ConfigError returns the validation error of the configuration the client
+// was created with, or nil if the configuration is valid.
+func (c *Client) ConfigError() error {
+	return c.configErr
+}
+
+// RefreshCookies runs the configured login (see WithSAMLLogin) and switches
+// the client to the session cookies it returns.
+func (c *Client) RefreshCookies(ctx context.Context) error {
+	return c.transport.RefreshCookies(ctx)
+}
+
 // NewClientWithTransport creates a new client with a custom transport.
 // This is useful for testing.
 func NewClientWithTransport(cfg *Config, transport *Transport) *Client {
 	return &Client{
-		transport: transport,
-		config:    cfg,
+		transport:     transport,
+		config:        cfg,
+		confirmations: newConfirmationStore(),
+		structures:    newStructureCache(cfg.StructureCacheTTL),
+		batchSlots:    newBatchLimiter(cfg.MaxConcurrency),
+		packages:      newPackageCache(cfg.PackageCacheSize),
+		discovery:     &discoveryCache{},
 	}
 }
 
@@ -114,6 +168,12 @@ func (c *Client) checkSafety(op OperationType, opName string) error {
 	return c.config.Safety.CheckOperation(op, opName)
 }
 
+// checkWriteSafety checks a modifying call whose operation type also covers
+// reads (transports, gCTS), so that DryRun refuses it.
+func (c *Client) checkWriteSafety(op OperationType, opName string) error {
+	return c.config.Safety.CheckWriteOperation(op, opName)
+}
+
 // checkPackageSafety checks if operations on a package are allowed.
 func (c *Client) checkPackageSafety(pkg string) error {
 	return c.config.Safety.CheckPackage(pkg)
@@ -122,7 +182,7 @@ func (c *Client) checkPackageSafety(pkg string) error {
 // checkObjectPackageSafety resolves the package for an existing object and
 // validates it against the configured package whitelist.
 func (c *Client) checkObjectPackageSafety(ctx context.Context, objectURL string) error {
-	if len(c.config.Safety.AllowedPackages) == 0 {
+	if !c.config.Safety.HasPackageRestrictions() {
 		return nil
 	}
 
@@ -146,6 +206,10 @@ func (c *Client) getObjectPackage(ctx context.Context, objectURL string) (string
 		return "", err
 	}
 
+	if pkg, ok := c.packages.get(normalized); ok {
+		return pkg, nil
+	}
+
 	results, err := c.SearchObject(ctx, objectName, 20)
 	if err != nil {
 		return "", err
@@ -157,6 +221,7 @@ func (c *Client) getObjectPackage(ctx context.Context, objectURL string) (string
 			continue
 		}
 		if canonicalizeObjectURL(result.URI) == canonicalURL {
+			c.packages.put(normalized, result.PackageName)
 			return result.PackageName, nil
 		}
 	}
@@ -215,7 +280,7 @@ func (c *Client) Safety() *SafetyConfig {
 // SAP_ALLOWED_PACKAGES restrictions.
 func (c *Client) AllowPackageTemporarily(pkg string) func() {
 	// If no package restrictions are configured, nothing to do
-	if len(c.config.Safety.AllowedPackages) == 0 {
+	if !c.config.Safety.HasPackageRestrictions() {
 		return func() {}
 	}
 
@@ -268,6 +333,109 @@ func (c *Client) SearchObject(ctx context.Context, query string, maxResults int)
 	return ParseSearchResults(resp.Body)
 }
 
+// SearchPage is one page of SearchObjectPaged results.
+type SearchPage struct {
+	Results    []SearchResult `json:"results"`
+	HasMore    bool           `json:"hasMore"`
+	NextOffset int            `json:"nextOffset,omitempty"` // Offset of the next page when HasMore is set
+}
+
+// SearchObjectPaged returns the page of SearchObject results that starts at
+// offset (0-based). One result beyond pageSize is requested to find out
+// whether another page follows, so HasMore is exact.
+func (c *Client) SearchObjectPaged(ctx context.Context, query string, pageSize, offset int) (*SearchPage, error) {
+	if pageSize <= 0 {
+		pageSize = 100
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	params := url.Values{}
+	params.Set("operation", "quickSearch")
+	params.Set("query", query)
+	params.Set("startRow", fmt.Sprintf("%d", offset))
+	params.Set("maxResults", fmt.Sprintf("%d", pageSize+1))
+
+	resp, err := c.transport.Request(ctx, "/sap/bc/adt/repository/informationsystem/search", &RequestOptions{
+		Method: http.MethodGet,
+		Query:  params,
+		Accept: "application/xml",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("search request failed: %w", err)
+	}
+
+	results, err := ParseSearchResults(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	page := &SearchPage{Results: results}
+	if len(results) > pageSize {
+		page.Results = results[:pageSize]
+		page.HasMore = true
+		page.NextOffset = offset + pageSize
+	}
+	return page, nil
+}
+
+// SearchObjectRanked runs SearchObject and orders the results by closeness
+// to the query (see rankSearchResults).
+func (c *Client) SearchObjectRanked(ctx context.Context, query string, maxResults int) ([]SearchResult, error) {
+	results, err := c.SearchObject(ctx, query, maxResults)
+	if err != nil {
+		return nil, err
+	}
+	rankSearchResults(query, results)
+	return results, nil
+}
+
+// rankSearchResults sorts results in place: exact matches first, then
+// prefix matches, then substring matches, then the rest. Wildcards in the
+// query are ignored. Names are also compared without their namespace, so a
+// CL_FOO query ranks /DMO/CL_FOO right after CL_FOO itself. Ties go to the
+// shorter name, and otherwise keep the server order.
+func rankSearchResults(query string, results []SearchResult) {
+	q := strings.ToUpper(strings.NewReplacer("*", "", "?", "").Replace(strings.TrimSpace(query)))
+	scores := make(map[string]int, len(results))
+	for _, r := range results {
+		scores[r.Name] = searchMatchScore(q, strings.ToUpper(r.Name))
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		si, sj := scores[results[i].Name], scores[results[j].Name]
+		if si != sj {
+			return si < sj
+		}
+		return len(results[i].Name) < len(results[j].Name)
+	})
+}
+
+// searchMatchScore rates how well name matches q; lower is better.
+func searchMatchScore(q, name string) int {
+	short := name
+	if strings.HasPrefix(name, "/") {
+		if end := strings.Index(name[1:], "/"); end >= 0 {
+			short = name[end+2:]
+		}
+	}
+	switch {
+	case q == "":
+		return 6
+	case name == q:
+		return 0
+	case short == q:
+		return 1
+	case strings.HasPrefix(name, q):
+		return 2
+	case strings.HasPrefix(short, q):
+		return 3
+	case strings.Contains(name, q):
+		return 4
+	default:
+		return 5
+	}
+}
+
 // --- Program Operations ---
 
 // GetProgram retrieves the source code of an ABAP program.
@@ -279,6 +447,7 @@ func (c *Client) GetProgram(ctx context.Context, programName string) (string, er
 	sourcePath := fmt.Sprintf("/sap/bc/adt/programs/programs/%s/source/main", url.PathEscape(programName))
 	resp, err := c.transport.Request(ctx, sourcePath, &RequestOptions{
 		Method: http.MethodGet,
+		Accept: c.sourceAcceptFor(ObjectTypeProgram),
 	})
 	if err != nil {
 		return "", fmt.Errorf("getting program source: %w", err)
@@ -287,6 +456,198 @@ func (c *Client) GetProgram(ctx context.Context, programName string) (string, er
 	return string(resp.Body), nil
 }
 
+// GetObjectSource returns the main source of an object whose type is only
+// known at runtime, e.g. from SearchObject results. It dispatches to the
+// type-specific getter; for classes the main include is returned. Function
+// modules require the function group as parent.
+//
+// With WithAutoMasterLanguage, an empty or failed read is retried once in
+// the master language of the object before giving up.
+func (c *Client) GetObjectSource(ctx context.Context, objType CreatableObjectType, name, parent string) (string, error) {
+	source, err := c.getObjectSource(ctx, objType, name, parent)
+	if !c.config.AutoMasterLanguage {
+		return source, err
+	}
+
+	var apiErr *APIError
+	if err != nil && !errors.As(err, &apiErr) {
+		return source, err // not a server response, e.g. missing parent
+	}
+	if err == nil && strings.TrimSpace(source) != "" {
+		return source, nil
+	}
+
+	sourceURL := GetSourceURL(objType, name, parent)
+	lang, langErr := c.objectMasterLanguage(ctx, GetObjectURL(objType, name, parent))
+	if sourceURL == "" || langErr != nil || lang == "" || strings.EqualFold(lang, c.config.Language) {
+		return source, err
+	}
+
+	resp, retryErr := c.transport.Request(ctx, sourceURL, &RequestOptions{
+		Method:           http.MethodGet,
+		Accept:           "text/plain",
+		OverrideLanguage: lang,
+	})
+	if retryErr != nil {
+		return source, err
+	}
+	return string(resp.Body), nil
+}
+
+// objectMasterLanguage reads the master language from the metadata of an object.
+func (c *Client) objectMasterLanguage(ctx context.Context, objectURL string) (string, error) {
+	resp, err := c.transport.Request(ctx, objectURL, &RequestOptions{
+		Method: http.MethodGet,
+		Accept: "application/xml",
+	})
+	if err != nil {
+		return "", err
+	}
+
+	var meta struct {
+		MasterLanguage string `xml:"masterLanguage,attr"`
+	}
+	if err := xml.Unmarshal([]byte(strings.ReplaceAll(string(resp.Body), "adtcore:", "")), &meta); err != nil {
+		return "", fmt.Errorf("parsing object metadata: %w", err)
+	}
+	return strings.ToUpper(meta.MasterLanguage), nil
+}
+
+// ObjectMetadata holds the catalog attributes of an object as returned by
+// its ADT base URL, without the source.
+type ObjectMetadata struct {
+	Name             string `json:"name"`
+	Type             string `json:"type"`
+	Description      string `json:"description,omitempty"`
+	Package          string `json:"package,omitempty"`
+	Responsible      string `json:"responsible,omitempty"`
+	MasterLanguage   string `json:"masterLanguage,omitempty"`
+	CreatedAt        string `json:"createdAt,omitempty"`
+	ChangedAt        string `json:"changedAt,omitempty"`
+	ChangedBy        string `json:"changedBy,omitempty"`
+	TransportRequest string `json:"transportRequest,omitempty"` // Only set when the server links one
+}
+
+// GetObjectMetadata reads the package, responsible user and other catalog
+// attributes of an object without downloading its source. objType is a
+// short type such as "CLAS" or "PROG/P". Function modules are not
+// supported, since their URL needs the function group.
+func (c *Client) GetObjectMetadata(ctx context.Context, objType, name string) (*ObjectMetadata, error) {
+	t, ok := objectTypeFromShort(objType)
+	if !ok || t == ObjectTypeFunctionMod {
+		return nil, fmt.Errorf("unsupported object type for metadata: %s", objType)
+	}
+	objectURL := GetObjectURL(t, name, "")
+	if objectURL == "" {
+		return nil, fmt.Errorf("unsupported object type for metadata: %s", objType)
+	}
+
+	resp, err := c.transport.Request(ctx, objectURL, &RequestOptions{
+		Method: http.MethodGet,
+		Accept: "application/xml",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("getting metadata of %s: %w", strings.ToUpper(name), err)
+	}
+
+	meta, err := parseObjectMetadata(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if meta.Package != "" {
+		c.packages.put(normalizeObjectURLForPackageCheck(objectURL), meta.Package)
+	}
+	return meta, nil
+}
+
+func parseObjectMetadata(data []byte) (*ObjectMetadata, error) {
+	xmlStr := strings.ReplaceAll(string(data), "adtcore:", "")
+	xmlStr = strings.ReplaceAll(xmlStr, "atom:", "")
+
+	type link struct {
+		Href string `xml:"href,attr"`
+		Rel  string `xml:"rel,attr"`
+	}
+	var root struct {
+		Name           string `xml:"name,attr"`
+		Type           string `xml:"type,attr"`
+		Description    string `xml:"description,attr"`
+		Responsible    string `xml:"responsible,attr"`
+		MasterLanguage string `xml:"masterLanguage,attr"`
+		CreatedAt      string `xml:"createdAt,attr"`
+		ChangedAt      string `xml:"changedAt,attr"`
+		ChangedBy      string `xml:"changedBy,attr"`
+		PackageRef     struct {
+			Name string `xml:"name,attr"`
+		} `xml:"packageRef"`
+		Links []link `xml:"link"`
+	}
+	if err := xml.Unmarshal([]byte(xmlStr), &root); err != nil {
+		return nil, fmt.Errorf("parsing object metadata: %w", err)
+	}
+	if root.Name == "" {
+		return nil, fmt.Errorf("parsing object metadata: no object name in response")
+	}
+
+	meta := &ObjectMetadata{
+		Name:           root.Name,
+		Type:           root.Type,
+		Description:    root.Description,
+		Package:        root.PackageRef.Name,
+		Responsible:    root.Responsible,
+		MasterLanguage: strings.ToUpper(root.MasterLanguage),
+		CreatedAt:      root.CreatedAt,
+		ChangedAt:      root.ChangedAt,
+		ChangedBy:      root.ChangedBy,
+	}
+	for _, l := range root.Links {
+		if !strings.Contains(l.Rel, "/transport") {
+			continue
+		}
+		if i := strings.LastIndex(l.Href, "/"); i >= 0 && i < len(l.Href)-1 {
+			meta.TransportRequest = strings.ToUpper(l.Href[i+1:])
+			break
+		}
+	}
+	return meta, nil
+}
+
+func (c *Client) getObjectSource(ctx context.Context, objType CreatableObjectType, name, parent string) (string, error) {
+	switch objType {
+	case ObjectTypeProgram:
+		return c.GetProgram(ctx, name)
+	case ObjectTypeInclude:
+		return c.GetInclude(ctx, name)
+	case ObjectTypeClass:
+		return c.GetClassSource(ctx, name)
+	case ObjectTypeInterface:
+		return c.GetInterface(ctx, name)
+	case ObjectTypeFunctionMod:
+		if parent == "" {
+			return "", fmt.Errorf("function module %s: parent function group is required", strings.ToUpper(name))
+		}
+		return c.GetFunction(ctx, name, parent)
+	case ObjectTypeTable:
+		return c.GetTable(ctx, name)
+	case ObjectTypeStructure:
+		return c.GetStructure(ctx, name)
+	case ObjectTypeView:
+		return c.GetView(ctx, name)
+	case ObjectTypeTransformation:
+		return c.GetTransformation(ctx, name)
+	case ObjectTypeTypeGroup:
+		return c.GetTypeGroup(ctx, name)
+	case ObjectTypeDDLS:
+		return c.GetDDLS(ctx, name)
+	case ObjectTypeBDEF:
+		return c.GetBDEF(ctx, name)
+	case ObjectTypeSRVD:
+		return c.GetSRVD(ctx, name)
+	default:
+		return "", fmt.Errorf("object type %s has no source to read", objType)
+	}
+}
+
 // --- Class Operations ---
 
 // GetClass retrieves the source code of an ABAP class.
@@ -299,6 +660,7 @@ func (c *Client) GetClass(ctx context.Context, className string) (map[string]str
 	sourcePath := fmt.Sprintf("/sap/bc/adt/oo/classes/%s/source/main", url.PathEscape(className))
 	resp, err := c.transport.Request(ctx, sourcePath, &RequestOptions{
 		Method: http.MethodGet,
+		Accept: c.sourceAcceptFor(ObjectTypeClass),
 	})
 	if err != nil {
 		return nil, fmt.Errorf("getting class source: %w", err)
@@ -322,9 +684,27 @@ func (c *Client) GetClassSource(ctx context.Context, className string) (string,
 // GetClassMethods retrieves the list of methods in a class with their source line boundaries.
 // This is useful for method-level source operations (GetSource with method, EditSource with method).
 func (c *Client) GetClassMethods(ctx context.Context, className string) ([]MethodInfo, error) {
+	structure, err := c.classObjectStructure(ctx, className)
+	if err != nil {
+		return nil, err
+	}
+	return structure.GetMethods(), nil
+}
+
+// GetClassObjectStructure returns the full parsed class structure (methods, attributes, types, events).
+func (c *Client) GetClassObjectStructure(ctx context.Context, className string) (*ClassObjectStructure, error) {
+	return c.classObjectStructure(ctx, className)
+}
+
+// classObjectStructure fetches and parses the objectstructure of a class.
+// All structure readers share it, so within the WithStructureCache TTL one
+// request serves every caller.
+func (c *Client) classObjectStructure(ctx context.Context, className string) (*ClassObjectStructure, error) {
 	className = strings.ToUpper(className)
+	if structure, ok := c.structures.get(className); ok {
+		return structure, nil
+	}
 
-	// Fetch objectstructure endpoint
 	path := fmt.Sprintf("/sap/bc/adt/oo/classes/%s/objectstructure", url.PathEscape(className))
 	resp, err := c.transport.Request(ctx, path, &RequestOptions{
 		Method: http.MethodGet,
@@ -339,23 +719,108 @@ func (c *Client) GetClassMethods(ctx context.Context, className string) ([]Metho
 		return nil, fmt.Errorf("parsing class object structure: %w", err)
 	}
 
-	return structure.GetMethods(), nil
-}
-
-// GetClassObjectStructure returns the full parsed class structure (methods, attributes, types, events).
-func (c *Client) GetClassObjectStructure(ctx context.Context, className string) (*ClassObjectStructure, error) {
-	className = strings.ToUpper(className)
-
-	path := fmt.Sprintf("/sap/bc/adt/oo/classes/%s/objectstructure", url.PathEscape(className))
-	resp, err := c.transport.Request(ctx, path, &RequestOptions{
+	c.structures.put(className, structure)
+	return structure, nil
+}
+
+// StructureNode is one element of an object's outline, such as a method,
+// attribute, local class, include or function module.
+type StructureNode struct {
+	Name       string          `json:"name"`
+	Type       string          `json:"type"`                // ADT type, e.g. "CLAS/OM"
+	Kind       string          `json:"kind"`                // Readable kind, e.g. "method"
+	StartLine  int             `json:"startLine,omitempty"` // 1-based, in the source the element belongs to
+	EndLine    int             `json:"endLine,omitempty"`
+	Visibility string          `json:"visibility,omitempty"`
+	Children   []StructureNode `json:"children,omitempty"`
+}
+
+// structureKinds maps ADT structure element types to readable kinds.
+var structureKinds = map[string]string{
+	"CLAS/OC": "class",
+	"CLAS/OM": "method",
+	"CLAS/OA": "attribute",
+	"CLAS/OT": "type",
+	"CLAS/OE": "event",
+	"INTF/OI": "interface",
+	"INTF/IO": "method",
+	"INTF/IA": "attribute",
+	"INTF/IT": "type",
+	"INTF/IE": "event",
+	"FUGR/F":  "function group",
+	"FUGR/FF": "function module",
+	"FUGR/I":  "include",
+	"PROG/I":  "include",
+	"PROG/PU": "form",
+}
+
+// GetObjectStructure returns the outline of any object that has an ADT
+// objectstructure resource (classes, interfaces, programs, function
+// groups): its elements with their source line ranges, nested as SAP
+// reports them. For classes, GetClassObjectStructure gives the typed view.
+func (c *Client) GetObjectStructure(ctx context.Context, objectURI string) ([]StructureNode, error) {
+	objectURI = strings.TrimSuffix(strings.TrimSuffix(objectURI, "/"), "/source/main")
+	resp, err := c.transport.Request(ctx, objectURI+"/objectstructure", &RequestOptions{
 		Method: http.MethodGet,
 		Accept: "application/vnd.sap.adt.objectstructure.v2+xml",
 	})
 	if err != nil {
-		return nil, fmt.Errorf("getting class object structure: %w", err)
+		return nil, fmt.Errorf("getting object structure: %w", err)
+	}
+	return parseStructureNodes(resp.Body)
+}
+
+// parseStructureNodes converts an objectstructure document into the
+// elements below its root. Line ranges come from the implementation block
+// where there is one (method bodies) and from the definition otherwise.
+func parseStructureNodes(data []byte) ([]StructureNode, error) {
+	type link struct {
+		Rel  string `xml:"rel,attr"`
+		Href string `xml:"href,attr"`
+	}
+	type element struct {
+		Name       string    `xml:"name,attr"`
+		Type       string    `xml:"type,attr"`
+		Visibility string    `xml:"visibility,attr"`
+		Links      []link    `xml:"link"`
+		Children   []element `xml:"objectStructureElement"`
+	}
+	var root element
+	if err := xml.Unmarshal(data, &root); err != nil {
+		return nil, fmt.Errorf("parsing object structure: %w", err)
 	}
 
-	return ParseClassObjectStructure(resp.Body)
+	var convert func([]element) []StructureNode
+	convert = func(elems []element) []StructureNode {
+		var nodes []StructureNode
+		for _, e := range elems {
+			node := StructureNode{Name: e.Name, Type: e.Type, Kind: structureKinds[e.Type], Visibility: e.Visibility}
+			if node.Kind == "" {
+				node.Kind = "element"
+			}
+			var defStart, defEnd, idStart, idEnd int
+			for _, l := range e.Links {
+				switch {
+				case strings.HasSuffix(l.Rel, "/implementationBlock"):
+					node.StartLine, node.EndLine = parseSourceRange(l.Href)
+				case strings.HasSuffix(l.Rel, "/definitionBlock"):
+					defStart, defEnd = parseSourceRange(l.Href)
+				case strings.HasSuffix(l.Rel, "/definitionIdentifier"):
+					idStart, idEnd = parseSourceRange(l.Href)
+				}
+			}
+			if node.StartLine == 0 {
+				node.StartLine, node.EndLine = defStart, defEnd
+			}
+			if node.StartLine == 0 {
+				node.StartLine, node.EndLine = idStart, idEnd
+			}
+			node.Children = convert(e.Children)
+			nodes = append(nodes, node)
+		}
+		return nodes
+	}
+	return convert(root.Children), nil
 }
 
 // GetClassMethodSource retrieves the source code of a specific method in a class.
@@ -392,18 +857,131 @@ func (c *Client) GetClassMethodSource(ctx context.Context, className, methodName
 		return "", fmt.Errorf("getting class source: %w", err)
 	}
 
-	// Extract method lines
+	// Extract method lines; an end slightly past the source is one of the
+	// off-by-one errors snapping below corrects
 	lines := strings.Split(fullSource, "\n")
-	if method.ImplementationEnd > len(lines) {
-		return "", fmt.Errorf("method line range (%d-%d) exceeds source lines (%d)",
-			method.ImplementationStart, method.ImplementationEnd, len(lines))
+	reportedEnd := method.ImplementationEnd
+	if reportedEnd > len(lines) {
+		if reportedEnd > len(lines)+methodSnapWindow {
+			return "", fmt.Errorf("method line range (%d-%d) exceeds source lines (%d)",
+				method.ImplementationStart, method.ImplementationEnd, len(lines))
+		}
+		reportedEnd = len(lines)
+	}
+
+	// Snap the reported range to the actual METHOD/ENDMETHOD statements
+	start, end := methodBlockRange(fullSource, methodName, method.ImplementationStart, reportedEnd)
+	if s, e, ok := snapMethodLines(lines, methodName, start, end); ok {
+		start, end = s, e
 	}
 
 	// Line numbers are 1-based, slice indices are 0-based
-	methodLines := lines[method.ImplementationStart-1 : method.ImplementationEnd]
+	methodLines := lines[start-1 : end]
 	return strings.Join(methodLines, "\n"), nil
 }
 
+// methodBlockRange corrects an objectstructure implementation range (1-based,
+// inclusive) to the real METHOD … ENDMETHOD statement boundaries. The reported
+// range can be off when blank lines or comments separate methods, which would
+// otherwise include a neighbour's lines or drop the ENDMETHOD. The source is
+// tokenized so keywords inside strings and comments are ignored. If no matching
+// block is found, the reported range is returned unchanged.
+func methodBlockRange(source, methodName string, start, end int) (int, int) {
+	tokens := (&abaplint.Lexer{}).Run(source)
+	stmts := (&abaplint.StatementParser{}).Parse(tokens)
+
+	methodIdx := -1
+	bestDistance := 0
+	for i, st := range stmts {
+		if st.FirstTokenStr() != "METHOD" || len(st.Tokens) < 2 {
+			continue
+		}
+		if !strings.EqualFold(statementName(st.Tokens[1:]), methodName) {
+			continue
+		}
+		distance := st.Tokens[0].Row - start
+		if distance < 0 {
+			distance = -distance
+		}
+		if methodIdx == -1 || distance < bestDistance {
+			methodIdx, bestDistance = i, distance
+		}
+	}
+	if methodIdx == -1 {
+		return start, end
+	}
+
+	for _, st := range stmts[methodIdx+1:] {
+		if st.FirstTokenStr() == "ENDMETHOD" {
+			last := st.Tokens[len(st.Tokens)-1]
+			return stmts[methodIdx].Tokens[0].Row, last.Row
+		}
+	}
+	return start, end
+}
+
+// methodSnapWindow is how many lines around a reported method boundary
+// snapMethodLines searches.
+const methodSnapWindow = 3
+
+// snapMethodLines verifies that lines start..end (1-based, inclusive) hold
+// a METHOD … ENDMETHOD block and otherwise looks for the real METHOD and
+// ENDMETHOD lines within methodSnapWindow lines of the given boundaries. It
+// backs up methodBlockRange for source the tokenizer cannot place, e.g.
+// unusual macro code; ok is false when no block is found nearby.
+func snapMethodLines(lines []string, methodName string, start, end int) (int, int, bool) {
+	isMethod := func(i int) bool {
+		fields := strings.Fields(strings.ToUpper(lines[i-1]))
+		return len(fields) >= 2 && fields[0] == "METHOD" &&
+			strings.EqualFold(strings.TrimSuffix(fields[1], "."), methodName)
+	}
+	isEndMethod := func(i int) bool {
+		fields := strings.Fields(strings.ToUpper(lines[i-1]))
+		return len(fields) >= 1 && strings.TrimSuffix(fields[0], ".") == "ENDMETHOD"
+	}
+	clamp := func(i int) int {
+		if i < 1 {
+			return 1
+		}
+		if i > len(lines) {
+			return len(lines)
+		}
+		return i
+	}
+	if len(lines) == 0 {
+		return start, end, false
+	}
+	if start >= 1 && end <= len(lines) && start <= end && isMethod(start) && isEndMethod(end) {
+		return start, end, true
+	}
+
+	methodLine := 0
+	for d := 0; d <= methodSnapWindow && methodLine == 0; d++ {
+		for _, i := range []int{start - d, start + d} {
+			if i >= 1 && i <= len(lines) && isMethod(i) {
+				methodLine = i
+				break
+			}
+		}
+	}
+	if methodLine == 0 {
+		return start, end, false
+	}
+	for i := methodLine + 1; i <= clamp(end+methodSnapWindow); i++ {
+		if isEndMethod(i) {
+			return methodLine, i, true
+		}
+	}
+	return start, end, false
+}
+
+// statementName joins adjacent tokens (no whitespace in between) into one
+// name, e.g. "zif_demo", "~", "run" → "zif_demo~run".
+func statementName(tokens []abaplint.Token) string {
+	name, _ := joinAdjacentTokens(tokens)
+	return name
+}
+
 // --- Interface Operations ---
 
 // GetInterface retrieves the source code of an ABAP interface.
@@ -415,6 +993,7 @@ func (c *Client) GetInterface(ctx context.Context, interfaceName string) (string
 	sourcePath := fmt.Sprintf("/sap/bc/adt/oo/interfaces/%s/source/main", url.PathEscape(interfaceName))
 	resp, err := c.transport.Request(ctx, sourcePath, &RequestOptions{
 		Method: http.MethodGet,
+		Accept: c.sourceAcceptFor(ObjectTypeInterface),
 	})
 	if err != nil {
 		return "", fmt.Errorf("getting interface source: %w", err)
@@ -546,13 +1125,14 @@ func (c *Client) GetFunctionGroupAllSources(ctx context.Context, groupName strin
 		go func() {
 			defer wg.Done()
 			for idx := range jobCh {
-				if ctx.Err() != nil {
+				if c.batchSlots.acquire(ctx) != nil {
 					return
 				}
 				r, err := c.transport.Request(ctx, srcURIs[idx], &RequestOptions{
 					Method: http.MethodGet,
 					Accept: "text/plain",
 				})
+				c.batchSlots.release()
 				if err != nil {
 					resCh <- fetchResult{idx: idx}
 					continue
@@ -604,7 +1184,7 @@ func (c *Client) GetFunction(ctx context.Context, functionName, groupName string
 
 	resp, err := c.transport.Request(ctx, sourcePath, &RequestOptions{
 		Method: http.MethodGet,
-		Accept: "text/plain",
+		Accept: c.sourceAcceptFor(ObjectTypeFunctionMod),
 	})
 	if err != nil {
 		return "", fmt.Errorf("getting function source: %w", err)
@@ -624,7 +1204,7 @@ func (c *Client) GetInclude(ctx context.Context, includeName string) (string, er
 	sourcePath := fmt.Sprintf("/sap/bc/adt/programs/includes/%s/source/main", url.PathEscape(includeName))
 	resp, err := c.transport.Request(ctx, sourcePath, &RequestOptions{
 		Method: http.MethodGet,
-		Accept: "text/plain",
+		Accept: c.sourceAcceptFor(ObjectTypeInclude),
 	})
 	if err != nil {
 		return "", fmt.Errorf("getting include source: %w", err)
@@ -643,7 +1223,7 @@ func (c *Client) GetDDLS(ctx context.Context, ddlsName string) (string, error) {
 	sourcePath := fmt.Sprintf("/sap/bc/adt/ddic/ddl/sources/%s/source/main", url.PathEscape(ddlsName))
 	resp, err := c.transport.Request(ctx, sourcePath, &RequestOptions{
 		Method: http.MethodGet,
-		Accept: "text/plain",
+		Accept: c.sourceAcceptFor(ObjectTypeDDLS),
 	})
 	if err != nil {
 		return "", fmt.Errorf("getting DDLS source: %w", err)
@@ -652,6 +1232,40 @@ func (c *Client) GetDDLS(ctx context.Context, ddlsName string) (string, error) {
 	return string(resp.Body), nil
 }
 
+// --- Transformation Operations ---
+
+// GetTransformation retrieves the source of an XSLT program or Simple
+// Transformation. Supports namespaced transformations like /DMO/ST_FLIGHT.
+func (c *Client) GetTransformation(ctx context.Context, name string) (string, error) {
+	sourcePath := GetSourceURL(ObjectTypeTransformation, name, "")
+	resp, err := c.transport.Request(ctx, sourcePath, &RequestOptions{
+		Method: http.MethodGet,
+		Accept: c.sourceAcceptFor(ObjectTypeTransformation),
+	})
+	if err != nil {
+		return "", fmt.Errorf("getting transformation source: %w", err)
+	}
+
+	return string(resp.Body), nil
+}
+
+// --- Type Group Operations ---
+
+// GetTypeGroup retrieves the source of a type group (the TYPE-POOL
+// declarations). Supports namespaced type groups.
+func (c *Client) GetTypeGroup(ctx context.Context, name string) (string, error) {
+	sourcePath := GetSourceURL(ObjectTypeTypeGroup, name, "")
+	resp, err := c.transport.Request(ctx, sourcePath, &RequestOptions{
+		Method: http.MethodGet,
+		Accept: c.sourceAcceptFor(ObjectTypeTypeGroup),
+	})
+	if err != nil {
+		return "", fmt.Errorf("getting type group source: %w", err)
+	}
+
+	return string(resp.Body), nil
+}
+
 // --- RAP Object Operations (BDEF, SRVD, SRVB) ---
 
 // GetBDEF retrieves the source code of a Behavior Definition.
@@ -665,7 +1279,7 @@ func (c *Client) GetBDEF(ctx context.Context, bdefName string) (string, error) {
 	sourcePath := fmt.Sprintf("/sap/bc/adt/bo/behaviordefinitions/%s/source/main", url.PathEscape(bdefName))
 	resp, err := c.transport.Request(ctx, sourcePath, &RequestOptions{
 		Method: http.MethodGet,
-		Accept: "text/plain",
+		Accept: c.sourceAcceptFor(ObjectTypeBDEF),
 	})
 	if err != nil {
 		return "", fmt.Errorf("getting BDEF source: %w", err)
@@ -683,7 +1297,7 @@ func (c *Client) GetSRVD(ctx context.Context, srvdName string) (string, error) {
 	sourcePath := fmt.Sprintf("/sap/bc/adt/ddic/srvd/sources/%s/source/main", url.PathEscape(srvdName))
 	resp, err := c.transport.Request(ctx, sourcePath, &RequestOptions{
 		Method: http.MethodGet,
-		Accept: "text/plain",
+		Accept: c.sourceAcceptFor(ObjectTypeSRVD),
 	})
 	if err != nil {
 		return "", fmt.Errorf("getting SRVD source: %w", err)
@@ -713,7 +1327,7 @@ func (c *Client) GetSRVB(ctx context.Context, srvbName string) (*ServiceBinding,
 	path := fmt.Sprintf("/sap/bc/adt/businessservices/bindings/%s", url.PathEscape(srvbName))
 	resp, err := c.transport.Request(ctx, path, &RequestOptions{
 		Method: http.MethodGet,
-		Accept: "*/*", // Service bindings may require accepting any format
+		Accept: c.sourceAcceptFor(ObjectTypeSRVB),
 	})
 	if err != nil {
 		return nil, fmt.Errorf("getting SRVB metadata: %w", err)
@@ -883,6 +1497,7 @@ func parsePackageNodeStructure(data []byte, packageName string) (*PackageContent
 				Name:        node.ObjectName,
 				URI:         node.ObjectURI,
 				Description: node.Desc,
+				Package:     packageName,
 			})
 		}
 	}
@@ -890,6 +1505,189 @@ func parsePackageNodeStructure(data []byte, packageName string) (*PackageContent
 	return pkg, nil
 }
 
+// GetPackageRecursive walks a package and its subpackages breadth-first and
+// returns all objects found, each with Package set to the package it was
+// listed in. SubPackages of the result holds every subpackage visited, in
+// walk order. maxDepth limits how many subpackage levels below the root are
+// read; <= 0 means no limit. Packages reachable more than once (cycles) are
+// read only once.
+func (c *Client) GetPackageRecursive(ctx context.Context, packageName string, maxDepth int) (*PackageContent, error) {
+	root := strings.ToUpper(packageName)
+	result := &PackageContent{
+		Name:        root,
+		Objects:     []PackageObject{},
+		SubPackages: []string{},
+	}
+
+	type queued struct {
+		name  string
+		depth int
+	}
+	queue := []queued{{name: root}}
+	visited := map[string]bool{root: true}
+
+	for len(queue) > 0 {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		next := queue[0]
+		queue = queue[1:]
+
+		content, err := c.GetPackage(ctx, next.name)
+		if err != nil {
+			return nil, fmt.Errorf("reading package %s: %w", next.name, err)
+		}
+		if next.name == root {
+			result.URI, result.Type = content.URI, content.Type
+		}
+		result.Objects = append(result.Objects, content.Objects...)
+
+		if maxDepth > 0 && next.depth >= maxDepth {
+			continue
+		}
+		for _, sub := range content.SubPackages {
+			sub = strings.ToUpper(sub)
+			if visited[sub] {
+				continue
+			}
+			visited[sub] = true
+			result.SubPackages = append(result.SubPackages, sub)
+			queue = append(queue, queued{name: sub, depth: next.depth + 1})
+		}
+	}
+
+	return result, nil
+}
+
+// PackageQueryOptions filters the result of ListPackages.
+type PackageQueryOptions struct {
+	// Query is a package name pattern (supports * and ?). Defaults to "*".
+	Query string
+	// SoftwareComponent restricts results to packages of this software
+	// component (e.g. "HOME", "LOCAL").
+	SoftwareComponent string
+	// SuperPackage restricts results to direct subpackages of this package.
+	SuperPackage string
+	// MaxResults limits the number of packages searched (default 100).
+	MaxResults int
+}
+
+// PackageSummary describes a package found by ListPackages.
+type PackageSummary struct {
+	Name              string `json:"name"`
+	Description       string `json:"description,omitempty"`
+	SuperPackage      string `json:"superPackage,omitempty"`
+	SoftwareComponent string `json:"softwareComponent,omitempty"`
+}
+
+// ListPackages lists packages via the repository information system,
+// optionally filtered by software component or super-package.
+// The software component is not part of the search result, so each
+// candidate's package metadata is read to resolve it.
+func (c *Client) ListPackages(ctx context.Context, opts *PackageQueryOptions) ([]PackageSummary, error) {
+	if err := c.checkSafety(OpSearch, "ListPackages"); err != nil {
+		return nil, err
+	}
+	if opts == nil {
+		opts = &PackageQueryOptions{}
+	}
+	query := opts.Query
+	if query == "" {
+		query = "*"
+	}
+	maxResults := opts.MaxResults
+	if maxResults <= 0 {
+		maxResults = 100
+	}
+
+	params := url.Values{}
+	params.Set("operation", "quickSearch")
+	params.Set("query", strings.ToUpper(query))
+	params.Set("objectType", "DEVC/K")
+	params.Set("maxResults", fmt.Sprintf("%d", maxResults))
+	if opts.SuperPackage != "" {
+		params.Set("packageName", strings.ToUpper(opts.SuperPackage))
+	}
+
+	resp, err := c.transport.Request(ctx, "/sap/bc/adt/repository/informationsystem/search", &RequestOptions{
+		Method: http.MethodGet,
+		Query:  params,
+		Accept: "application/xml",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("searching packages: %w", err)
+	}
+
+	results, err := ParseSearchResults(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("parsing package search results: %w", err)
+	}
+
+	var packages []PackageSummary
+	for _, r := range results {
+		if r.Type != "DEVC/K" {
+			continue
+		}
+
+		summary, err := c.getPackageSummary(ctx, r.Name)
+		if err != nil {
+			// Fall back to what the search returned
+			summary = &PackageSummary{Name: r.Name, Description: r.Description, SuperPackage: r.PackageName}
+		}
+
+		if opts.SoftwareComponent != "" && !strings.EqualFold(summary.SoftwareComponent, opts.SoftwareComponent) {
+			continue
+		}
+		if opts.SuperPackage != "" && !strings.EqualFold(summary.SuperPackage, opts.SuperPackage) {
+			continue
+		}
+		packages = append(packages, *summary)
+	}
+
+	return packages, nil
+}
+
+// getPackageSummary reads a package's metadata (super-package, software component).
+func (c *Client) getPackageSummary(ctx context.Context, packageName string) (*PackageSummary, error) {
+	path := fmt.Sprintf("/sap/bc/adt/packages/%s", url.PathEscape(strings.ToUpper(packageName)))
+	resp, err := c.transport.Request(ctx, path, &RequestOptions{
+		Method: http.MethodGet,
+		Accept: "application/vnd.sap.adt.packages.v1+xml, application/xml;q=0.9",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("getting package metadata: %w", err)
+	}
+	return parsePackageSummary(resp.Body)
+}
+
+// parsePackageSummary parses the package metadata XML (pak:package).
+func parsePackageSummary(data []byte) (*PackageSummary, error) {
+	type packageXML struct {
+		Name         string `xml:"name,attr"`
+		Description  string `xml:"description,attr"`
+		SuperPackage struct {
+			Name string `xml:"name,attr"`
+		} `xml:"superPackage"`
+		Transport struct {
+			SoftwareComponent struct {
+				Name string `xml:"name,attr"`
+			} `xml:"softwareComponent"`
+		} `xml:"transport"`
+	}
+
+	var p packageXML
+	if err := xml.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("parsing package metadata: %w", err)
+	}
+
+	return &PackageSummary{
+		Name:              p.Name,
+		Description:       p.Description,
+		SuperPackage:      p.SuperPackage.Name,
+		SoftwareComponent: p.Transport.SoftwareComponent.Name,
+	}, nil
+}
+
 // --- Table Operations ---
 
 // GetTable retrieves the source/definition of a database table.
@@ -900,6 +1698,7 @@ func (c *Client) GetTable(ctx context.Context, tableName string) (string, error)
 	sourcePath := fmt.Sprintf("/sap/bc/adt/ddic/tables/%s/source/main", url.PathEscape(tableName))
 	resp, err := c.transport.Request(ctx, sourcePath, &RequestOptions{
 		Method: http.MethodGet,
+		Accept: c.sourceAcceptFor(ObjectTypeTable),
 	})
 	if err != nil {
 		return "", fmt.Errorf("getting table source: %w", err)
@@ -917,6 +1716,7 @@ func (c *Client) GetView(ctx context.Context, viewName string) (string, error) {
 	sourcePath := fmt.Sprintf("/sap/bc/adt/ddic/views/%s/source/main", url.PathEscape(viewName))
 	resp, err := c.transport.Request(ctx, sourcePath, &RequestOptions{
 		Method: http.MethodGet,
+		Accept: c.sourceAcceptFor(ObjectTypeView),
 	})
 	if err != nil {
 		return "", fmt.Errorf("getting view source: %w", err)
@@ -933,6 +1733,7 @@ func (c *Client) GetStructure(ctx context.Context, structName string) (string, e
 	sourcePath := fmt.Sprintf("/sap/bc/adt/ddic/structures/%s/source/main", url.PathEscape(structName))
 	resp, err := c.transport.Request(ctx, sourcePath, &RequestOptions{
 		Method: http.MethodGet,
+		Accept: c.sourceAcceptFor(ObjectTypeStructure),
 	})
 	if err != nil {
 		return "", fmt.Errorf("getting structure source: %w", err)
@@ -958,15 +1759,61 @@ type TableColumn struct {
 	IsKey       bool
 }
 
+// TableData is a table contents result as ordered string rows, with the
+// cells of each row in Columns order.
+type TableData struct {
+	Columns []string   `json:"columns"`
+	Rows    [][]string `json:"rows"`
+}
+
+// Data returns the rows in column order. Missing cells are empty strings.
+func (r *TableContentsResult) Data() TableData {
+	data := TableData{
+		Columns: make([]string, len(r.Columns)),
+		Rows:    make([][]string, len(r.Rows)),
+	}
+	for i, col := range r.Columns {
+		data.Columns[i] = col.Name
+	}
+	for i, row := range r.Rows {
+		cells := make([]string, len(r.Columns))
+		for j, col := range r.Columns {
+			if v, ok := row[col.Name]; ok {
+				cells[j] = fmt.Sprint(v)
+			}
+		}
+		data.Rows[i] = cells
+	}
+	return data
+}
+
+// tableNamePattern matches DDIC table names, including namespaced ones.
+var tableNamePattern = regexp.MustCompile(`^[A-Z0-9_/]+$`)
+
 // GetTableContents retrieves data from a database table.
-// Optional sqlQuery can be a full SELECT statement to filter/transform results
-// (e.g., "SELECT * FROM T000 WHERE MANDT = '001'").
+// sqlFilter is optional and is either a full SELECT statement to
+// filter/transform results (e.g., "SELECT * FROM T000 WHERE MANDT = '001'")
+// or just a WHERE condition (e.g., "MANDT = '001'"). A WHERE condition is
+// checked by sanitizeWhereClause so it cannot append further statements.
+// maxRows is enforced by the server via the rowNumber parameter.
 func (c *Client) GetTableContents(ctx context.Context, tableName string, maxRows int, sqlFilter string) (*TableContentsResult, error) {
-	tableName = strings.ToUpper(tableName)
+	tableName = strings.ToUpper(strings.TrimSpace(tableName))
+	if !tableNamePattern.MatchString(tableName) {
+		return nil, fmt.Errorf("invalid table name %q", tableName)
+	}
 	if maxRows <= 0 {
 		maxRows = 100
 	}
 
+	sqlFilter = strings.TrimSpace(sqlFilter)
+	if sqlFilter != "" && !strings.HasPrefix(strings.ToUpper(sqlFilter), "SELECT") {
+		where, err := sanitizeWhereClause(sqlFilter)
+		if err != nil {
+			return nil, err
+		}
+		sqlFilter = fmt.Sprintf("SELECT * FROM %s WHERE %s", tableName, where)
+	}
+
 	params := url.Values{}
 	params.Set("rowNumber", fmt.Sprintf("%d", maxRows))
 	params.Set("ddicEntityName", tableName)
@@ -991,6 +1838,111 @@ func (c *Client) GetTableContents(ctx context.Context, tableName string, maxRows
 	return parseTableContents(resp.Body)
 }
 
+// whereForbiddenWords are keywords that would turn a WHERE condition into
+// a query on other tables.
+var whereForbiddenWords = map[string]bool{
+	"SELECT": true, "FROM": true, "UNION": true, "INTO": true,
+	"INSERT": true, "UPDATE": true, "DELETE": true, "MODIFY": true,
+}
+
+// sanitizeWhereClause validates a WHERE condition for GetTableContents and
+// returns it without a leading WHERE keyword. Outside of string literals it
+// rejects statement terminators, comments, line breaks and keywords that
+// would query other tables (subqueries, UNION).
+func sanitizeWhereClause(clause string) (string, error) {
+	clause = strings.TrimSpace(clause)
+	if len(clause) >= 6 && strings.EqualFold(clause[:6], "WHERE ") {
+		clause = strings.TrimSpace(clause[6:])
+	}
+	if clause == "" {
+		return "", fmt.Errorf("empty WHERE clause")
+	}
+
+	outside, err := sqlOutsideLiterals(clause)
+	if err != nil {
+		return "", fmt.Errorf("WHERE clause: %w", err)
+	}
+	runes := []rune(outside)
+	for i, r := range runes {
+		switch {
+		case r == ';' || r == '"' || r == '\n' || r == '\r':
+			return "", fmt.Errorf("WHERE clause must not contain %q outside a literal", r)
+		case r == '.' && (i == len(runes)-1 || unicode.IsSpace(runes[i+1])):
+			return "", fmt.Errorf("WHERE clause must not end a statement")
+		}
+	}
+
+	for _, word := range sqlWords(outside) {
+		if whereForbiddenWords[word] {
+			return "", fmt.Errorf("WHERE clause must not contain %s", word)
+		}
+	}
+	return clause, nil
+}
+
+// sqlOutsideLiterals returns sql with every '…' and `…` literal replaced by
+// a single space, so keyword and punctuation checks only see SQL syntax.
+func sqlOutsideLiterals(sql string) (string, error) {
+	var outside strings.Builder
+	var quote rune
+	for _, r := range sql {
+		if quote != 0 {
+			if r == quote {
+				quote = 0
+			}
+			continue
+		}
+		if r == '\'' || r == '`' {
+			quote = r
+			outside.WriteRune(' ')
+			continue
+		}
+		outside.WriteRune(r)
+	}
+	if quote != 0 {
+		return "", fmt.Errorf("unterminated literal")
+	}
+	return outside.String(), nil
+}
+
+// sqlWords splits SQL text into upper-cased identifiers and keywords.
+func sqlWords(sql string) []string {
+	return strings.FieldsFunc(strings.ToUpper(sql), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_'
+	})
+}
+
+// sqlModifyingWords are statements RunSQLQuery refuses to send.
+var sqlModifyingWords = map[string]bool{
+	"INSERT": true, "UPDATE": true, "DELETE": true, "MODIFY": true,
+	"COMMIT": true, "ROLLBACK": true,
+}
+
+// validateSelectStatement checks that sql is a single read-only SELECT
+// (optionally introduced by WITH for common table expressions).
+func validateSelectStatement(sql string) error {
+	outside, err := sqlOutsideLiterals(sql)
+	if err != nil {
+		return fmt.Errorf("invalid SQL: %w", err)
+	}
+	words := sqlWords(outside)
+	if len(words) == 0 {
+		return fmt.Errorf("SQL query is required")
+	}
+	if words[0] != "SELECT" && words[0] != "WITH" {
+		return fmt.Errorf("only SELECT statements are allowed, got %s", words[0])
+	}
+	if strings.Contains(outside, ";") {
+		return fmt.Errorf("only a single SELECT statement is allowed")
+	}
+	for _, word := range words {
+		if sqlModifyingWords[word] {
+			return fmt.Errorf("only read-only SELECT statements are allowed, found %s", word)
+		}
+	}
+	return nil
+}
+
 // RunQuery executes a freestyle SQL query against the SAP database.
 // Example: "SELECT * FROM T000 WHERE MANDT = '001'"
 func (c *Client) RunQuery(ctx context.Context, sqlQuery string, maxRows int) (*TableContentsResult, error) {
@@ -1023,6 +1975,19 @@ func (c *Client) RunQuery(ctx context.Context, sqlQuery string, maxRows int) (*T
 	return parseTableContents(resp.Body)
 }
 
+// RunSQLQuery executes a read-only freestyle SELECT and returns typed
+// columns and rows. Anything but a single SELECT (e.g. UPDATE, DELETE or
+// MODIFY) is rejected before a request is sent. The query is subject to the
+// same free SQL safety check as RunQuery; maxRows caps the rows returned by
+// the server (default 100).
+func (c *Client) RunSQLQuery(ctx context.Context, sql string, maxRows int) (*TableContentsResult, error) {
+	sql = strings.TrimSpace(sql)
+	if err := validateSelectStatement(sql); err != nil {
+		return nil, err
+	}
+	return c.RunQuery(ctx, sql, maxRows)
+}
+
 // parseTableContents parses the XML response for table contents.
 func parseTableContents(data []byte) (*TableContentsResult, error) {
 	// The ADT table data response is complex XML
@@ -1179,12 +2144,22 @@ type SystemInfo struct {
 	HostName        string `json:"hostName,omitempty"`
 	InstallNumber   string `json:"installNumber,omitempty"`
 	ABAPRelease     string `json:"abapRelease,omitempty"`
+	// SupportPackageLevel is the SAP_BASIS support package, e.g. "0002".
+	SupportPackageLevel string `json:"supportPackageLevel,omitempty"`
+	// ABAPPlatform tells on-premise systems from the ABAP cloud environment,
+	// when the system reports it.
+	ABAPPlatform  string `json:"abapPlatform,omitempty"`
+	UnicodeSystem bool   `json:"unicodeSystem,omitempty"`
 }
 
 // GetSystemInfo retrieves SAP system information.
-// Uses SQL queries to CVERS and T000 tables for reliable info across SAP versions.
+// Reads the ADT system information resource first, then fills the gaps with
+// SQL queries to CVERS and T000 tables for reliable info across SAP versions.
 func (c *Client) GetSystemInfo(ctx context.Context) (*SystemInfo, error) {
-	info := &SystemInfo{}
+	info, infoErr := c.readSystemInformation(ctx)
+	if infoErr != nil {
+		info = &SystemInfo{}
+	}
 
 	// Helper to get string from row
 	getString := func(row map[string]interface{}, key string) string {
@@ -1196,16 +2171,19 @@ func (c *Client) GetSystemInfo(ctx context.Context) (*SystemInfo, error) {
 		return ""
 	}
 
-	// Get client info from T000 - this is the primary query, propagate errors
+	// Get client info from T000 - without the system information resource
+	// this is the primary query, propagate errors
 	clientResult, err := c.RunQuery(ctx, "SELECT MANDT, MTEXT, LOGSYS FROM T000 WHERE MANDT = '"+c.config.Client+"'", 1)
-	if err != nil {
+	if err != nil && infoErr != nil {
 		return nil, fmt.Errorf("getting system info: %w", err)
 	}
-	if len(clientResult.Rows) > 0 {
+	if err == nil && len(clientResult.Rows) > 0 {
 		row := clientResult.Rows[0]
-		info.Client = getString(row, "MANDT")
+		if info.Client == "" {
+			info.Client = getString(row, "MANDT")
+		}
 		// LOGSYS format is typically <SID>CLNT<client>, e.g., A4HCLNT001
-		if logsys := getString(row, "LOGSYS"); len(logsys) >= 3 {
+		if logsys := getString(row, "LOGSYS"); len(logsys) >= 3 && info.SystemID == "" {
 			info.SystemID = logsys[:3] // First 3 chars are SID
 		}
 	}
@@ -1214,17 +2192,27 @@ func (c *Client) GetSystemInfo(ctx context.Context) (*SystemInfo, error) {
 	basisResult, err := c.RunQuery(ctx, "SELECT RELEASE, EXTRELEASE FROM CVERS WHERE COMPONENT = 'SAP_BASIS'", 1)
 	if err == nil && len(basisResult.Rows) > 0 {
 		row := basisResult.Rows[0]
-		info.SAPRelease = getString(row, "RELEASE")
-		info.ABAPRelease = getString(row, "RELEASE")
+		if info.SAPRelease == "" {
+			info.SAPRelease = getString(row, "RELEASE")
+		}
+		if info.ABAPRelease == "" {
+			info.ABAPRelease = getString(row, "RELEASE")
+		}
+		if info.SupportPackageLevel == "" {
+			info.SupportPackageLevel = getString(row, "EXTRELEASE")
+		}
 	}
 
 	// Try to get kernel info from CVERS (optional)
 	kernelResult, err := c.RunQuery(ctx, "SELECT RELEASE FROM CVERS WHERE COMPONENT = 'SAP_ABA'", 1)
-	if err == nil && len(kernelResult.Rows) > 0 {
+	if err == nil && len(kernelResult.Rows) > 0 && info.KernelRelease == "" {
 		info.KernelRelease = getString(kernelResult.Rows[0], "RELEASE")
 	}
 
 	// Try to detect HANA from CVERS (optional)
+	if info.DatabaseSystem != "" {
+		return finishSystemInfo(info, c.config.Client), nil
+	}
 	hanaResult, err := c.RunQuery(ctx,
 		"SELECT RELEASE FROM CVERS WHERE COMPONENT LIKE '%HDB%' OR COMPONENT LIKE '%HANA%'", 1)
 	if err == nil && len(hanaResult.Rows) > 0 {
@@ -1241,14 +2229,78 @@ func (c *Client) GetSystemInfo(ctx context.Context) (*SystemInfo, error) {
 		}
 	}
 
+	return finishSystemInfo(info, c.config.Client), nil
+}
+
+// finishSystemInfo fills fields no source could provide with fallbacks.
+func finishSystemInfo(info *SystemInfo, client string) *SystemInfo {
 	// If we couldn't get SystemID from T000, use fallback
 	if info.SystemID == "" {
 		info.SystemID = "???"
 	}
 	if info.Client == "" {
-		info.Client = c.config.Client
+		info.Client = client
 	}
+	return info
+}
+
+// readSystemInformation reads the ADT system information resource, an Atom
+// feed with one entry per attribute (id = attribute name, title = value).
+func (c *Client) readSystemInformation(ctx context.Context) (*SystemInfo, error) {
+	resp, err := c.transport.Request(ctx, "/sap/bc/adt/system/information", &RequestOptions{
+		Method: http.MethodGet,
+		Accept: "application/atom+xml;type=feed",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("getting system information: %w", err)
+	}
+	return parseSystemInformation(resp.Body)
+}
 
+func parseSystemInformation(data []byte) (*SystemInfo, error) {
+	type entry struct {
+		ID    string `xml:"id"`
+		Title string `xml:"title"`
+	}
+	var feed struct {
+		Entries []entry `xml:"entry"`
+	}
+	if err := xml.Unmarshal(data, &feed); err != nil {
+		return nil, fmt.Errorf("parsing system information: %w", err)
+	}
+	if len(feed.Entries) == 0 {
+		return nil, fmt.Errorf("parsing system information: no entries")
+	}
+
+	info := &SystemInfo{}
+	for _, e := range feed.Entries {
+		value := strings.TrimSpace(e.Title)
+		switch strings.ToLower(strings.TrimSpace(e.ID)) {
+		case "systemid", "sid":
+			info.SystemID = value
+		case "client":
+			info.Client = value
+		case "release", "saprelease":
+			info.SAPRelease = value
+			info.ABAPRelease = value
+		case "supportpackagelevel", "supportpackage":
+			info.SupportPackageLevel = value
+		case "kernelrelease":
+			info.KernelRelease = value
+		case "dbrelease", "databaserelease":
+			info.DatabaseRelease = value
+		case "dbsystem", "databasesystem":
+			info.DatabaseSystem = value
+		case "applicationservername", "hostname":
+			info.HostName = value
+		case "installationnumber", "installnumber":
+			info.InstallNumber = value
+		case "abapplatform", "platform":
+			info.ABAPPlatform = value
+		case "isunicodesystem", "unicodesystem", "unicode":
+			info.UnicodeSystem = strings.EqualFold(value, "true") || strings.EqualFold(value, "X")
+		}
+	}
 	return info, nil
 }
 
@@ -1439,30 +2491,69 @@ type CallGraphEdge struct {
 	CalleeURI  string `json:"callee_uri"`
 	CalleeName string `json:"callee_name"`
 	Line       int    `json:"line,omitempty"`
+	// IsBackEdge marks a call back into a node already on the current path
+	// (recursion). The callee's subtree is not traversed again.
+	IsBackEdge bool `json:"is_back_edge,omitempty"`
+}
+
+// callGraphNodeKey identifies a node for cycle detection.
+func callGraphNodeKey(n *CallGraphNode) string {
+	if n.URI != "" {
+		return n.URI
+	}
+	return n.Name
+}
+
+// walkCallGraph visits every edge of the graph once. Recursive calls show up
+// as a callee that is already on the path from the root; such an edge is
+// reported as a back edge and not descended into, and repeated edges from
+// unrolled recursion or shared subtrees are reported only once.
+func walkCallGraph(root *CallGraphNode, visit func(parent, child *CallGraphNode, depth int, backEdge bool)) {
+	onPath := make(map[string]bool)
+	seenEdges := make(map[string]bool)
+
+	var traverse func(parent *CallGraphNode, depth int)
+	traverse = func(parent *CallGraphNode, depth int) {
+		parentKey := callGraphNodeKey(parent)
+		onPath[parentKey] = true
+		defer delete(onPath, parentKey)
+
+		for i := range parent.Children {
+			child := &parent.Children[i]
+			childKey := callGraphNodeKey(child)
+			backEdge := onPath[childKey]
+			edgeKey := fmt.Sprintf("%s->%s:%d", parentKey, childKey, child.Line)
+			if seenEdges[edgeKey] {
+				continue
+			}
+			seenEdges[edgeKey] = true
+			visit(parent, child, depth+1, backEdge)
+			if !backEdge {
+				traverse(child, depth+1)
+			}
+		}
+	}
+	traverse(root, 0)
 }
 
 // FlattenCallGraph converts a hierarchical call graph to a flat list of edges.
+// Each edge appears once; calls closing a cycle are marked with IsBackEdge.
 func FlattenCallGraph(root *CallGraphNode) []CallGraphEdge {
 	var edges []CallGraphEdge
 	if root == nil {
 		return edges
 	}
 
-	var traverse func(parent *CallGraphNode)
-	traverse = func(parent *CallGraphNode) {
-		for _, child := range parent.Children {
-			edges = append(edges, CallGraphEdge{
-				CallerURI:  parent.URI,
-				CallerName: parent.Name,
-				CalleeURI:  child.URI,
-				CalleeName: child.Name,
-				Line:       child.Line,
-			})
-			childCopy := child
-			traverse(&childCopy)
-		}
-	}
-	traverse(root)
+	walkCallGraph(root, func(parent, child *CallGraphNode, _ int, backEdge bool) {
+		edges = append(edges, CallGraphEdge{
+			CallerURI:  parent.URI,
+			CallerName: parent.Name,
+			CalleeURI:  child.URI,
+			CalleeName: child.Name,
+			Line:       child.Line,
+			IsBackEdge: backEdge,
+		})
+	})
 	return edges
 }
 
@@ -1473,9 +2564,12 @@ type CallGraphStats struct {
 	MaxDepth    int            `json:"max_depth"`
 	NodesByType map[string]int `json:"nodes_by_type"`
 	UniqueNodes []string       `json:"unique_nodes"`
+	HasCycles   bool           `json:"has_cycles"`
 }
 
-// AnalyzeCallGraph computes statistics for a call graph.
+// AnalyzeCallGraph computes statistics for a call graph. Edges are counted
+// once, and recursion is reported through HasCycles instead of inflating the
+// edge count and depth.
 func AnalyzeCallGraph(root *CallGraphNode) *CallGraphStats {
 	stats := &CallGraphStats{
 		NodesByType: make(map[string]int),
@@ -1485,27 +2579,29 @@ func AnalyzeCallGraph(root *CallGraphNode) *CallGraphStats {
 	}
 
 	seen := make(map[string]bool)
-	var maxDepth int
-
-	var traverse func(node *CallGraphNode, depth int)
-	traverse = func(node *CallGraphNode, depth int) {
-		if depth > maxDepth {
-			maxDepth = depth
+	addNode := func(node *CallGraphNode) {
+		key := callGraphNodeKey(node)
+		if seen[key] {
+			return
 		}
-		if !seen[node.URI] {
-			seen[node.URI] = true
-			stats.TotalNodes++
-			stats.NodesByType[node.Type]++
-			stats.UniqueNodes = append(stats.UniqueNodes, node.Name)
+		seen[key] = true
+		stats.TotalNodes++
+		stats.NodesByType[node.Type]++
+		stats.UniqueNodes = append(stats.UniqueNodes, node.Name)
+	}
+
+	addNode(root)
+	walkCallGraph(root, func(_, child *CallGraphNode, depth int, backEdge bool) {
+		stats.TotalEdges++
+		if backEdge {
+			stats.HasCycles = true
+			return
 		}
-		for _, child := range node.Children {
-			stats.TotalEdges++
-			childCopy := child
-			traverse(&childCopy, depth+1)
+		if depth > stats.MaxDepth {
+			stats.MaxDepth = depth
 		}
-	}
-	traverse(root, 0)
-	stats.MaxDepth = maxDepth
+		addNode(child)
+	})
 	return stats
 }
 
@@ -1558,35 +2654,178 @@ func CompareCallGraphs(staticEdges, actualEdges []CallGraphEdge) *CallGraphCompa
 	return comp
 }
 
+// ExportComparisonMermaid renders a call graph comparison as a Mermaid
+// flowchart: common edges are solid, static-only (untested) edges dashed
+// grey, and actual-only (dynamic) edges dotted red.
+func ExportComparisonMermaid(comp *CallGraphComparison) string {
+	var sb strings.Builder
+	sb.WriteString("graph LR\n")
+	if comp == nil {
+		return sb.String()
+	}
+
+	nodeIDs := make(map[string]string)
+	nodeID := func(name string) string {
+		if id, ok := nodeIDs[name]; ok {
+			return id
+		}
+		id := fmt.Sprintf("N%d", len(nodeIDs))
+		nodeIDs[name] = id
+		fmt.Fprintf(&sb, "    %s[\"%s\"]\n", id, strings.ReplaceAll(name, `"`, "'"))
+		return id
+	}
+
+	// The comparison is built from maps, so sort for a stable diagram.
+	sorted := func(edges []CallGraphEdge) []CallGraphEdge {
+		out := append([]CallGraphEdge(nil), edges...)
+		sort.SliceStable(out, func(i, j int) bool {
+			if out[i].CallerName != out[j].CallerName {
+				return out[i].CallerName < out[j].CallerName
+			}
+			return out[i].CalleeName < out[j].CalleeName
+		})
+		return out
+	}
+
+	groups := []struct {
+		edges []CallGraphEdge
+		arrow string
+		style string
+	}{
+		{comp.CommonEdges, "-->", "stroke:#16a34a,stroke-width:2px"},
+		{comp.StaticOnly, "-.->", "stroke:#9ca3af,stroke-dasharray:6 4"},
+		{comp.ActualOnly, "-. dynamic .->", "stroke:#dc2626,stroke-width:2px,stroke-dasharray:2 2"},
+	}
+
+	var linkStyles []string
+	link := 0
+	for _, g := range groups {
+		for _, e := range sorted(g.edges) {
+			from := nodeID(e.CallerName)
+			to := nodeID(e.CalleeName)
+			fmt.Fprintf(&sb, "    %s %s %s\n", from, g.arrow, to)
+			linkStyles = append(linkStyles, fmt.Sprintf("    linkStyle %d %s\n", link, g.style))
+			link++
+		}
+	}
+	for _, ls := range linkStyles {
+		sb.WriteString(ls)
+	}
+
+	return sb.String()
+}
+
+// traceCallKinds are the trace events that enter a new procedure, with the
+// statement prefix as it appears in TraceEntry.Event.
+var traceCallKinds = []string{"PERFORM", "CALL METHOD", "CALL FUNCTION", "SUBMIT"}
+
+// traceReturnEvents are the trace events that leave the current procedure.
+var traceReturnEvents = []string{"ENDFORM", "ENDMETHOD", "ENDFUNCTION", "RETURN", "LEAVE PROGRAM"}
+
+// traceFrame is a procedure on the call stack rebuilt from a trace.
+type traceFrame struct {
+	name string
+	uri  string
+}
+
+// parseTraceCall returns the procedure a call event enters. ok is false for
+// events that are not calls.
+func parseTraceCall(event, program string) (frame traceFrame, ok bool) {
+	upper := strings.ToUpper(strings.TrimSpace(event))
+	for _, kind := range traceCallKinds {
+		if !strings.HasPrefix(upper, kind+" ") {
+			continue
+		}
+		fields := strings.Fields(upper[len(kind):])
+		if len(fields) == 0 {
+			return traceFrame{}, false
+		}
+		target := strings.Trim(fields[0], "'`")
+
+		switch kind {
+		case "PERFORM":
+			// PERFORM form [IN PROGRAM prog]
+			owner := program
+			if len(fields) >= 4 && fields[1] == "IN" && fields[2] == "PROGRAM" {
+				owner = fields[3]
+			}
+			return traceFrame{name: target, uri: traceProgramURI(owner)}, true
+		case "CALL METHOD":
+			uri := ""
+			if i := strings.Index(target, "=>"); i > 0 {
+				uri = "/sap/bc/adt/oo/classes/" + strings.ToLower(target[:i])
+			}
+			return traceFrame{name: target, uri: uri}, true
+		case "CALL FUNCTION":
+			// The function group is not part of the event, so there is no URI.
+			return traceFrame{name: target}, true
+		case "SUBMIT":
+			return traceFrame{name: target, uri: traceProgramURI(target)}, true
+		}
+	}
+	return traceFrame{}, false
+}
+
+// isTraceReturn reports whether event leaves the current procedure.
+func isTraceReturn(event string) bool {
+	upper := strings.ToUpper(strings.TrimSpace(event))
+	for _, ret := range traceReturnEvents {
+		if upper == ret || strings.HasPrefix(upper, ret+" ") || strings.HasPrefix(upper, ret+".") {
+			return true
+		}
+	}
+	return false
+}
+
+func traceProgramURI(program string) string {
+	if program == "" {
+		return ""
+	}
+	return "/sap/bc/adt/programs/programs/" + strings.ToLower(program)
+}
+
 // ExtractCallEdgesFromTrace converts trace entries to call graph edges.
-// It analyzes Program and Event fields to identify caller-callee relationships.
+// It replays the Event field as a call stack: PERFORM, CALL METHOD,
+// CALL FUNCTION and SUBMIT push the callee and add an edge from the
+// procedure on top of the stack, while ENDFORM, ENDMETHOD, ENDFUNCTION and
+// RETURN pop it. The first traced program is the root caller. Each
+// caller-callee pair is reported once.
 func ExtractCallEdgesFromTrace(entries []TraceEntry) []CallGraphEdge {
 	var edges []CallGraphEdge
 	seen := make(map[string]bool)
+	var stack []traceFrame
 
-	// Group entries by program to detect call relationships
-	var prevProgram string
 	for _, entry := range entries {
-		if entry.Program == "" {
-			continue
+		if len(stack) == 0 && entry.Program != "" {
+			stack = append(stack, traceFrame{name: entry.Program, uri: traceProgramURI(entry.Program)})
 		}
 
-		// Event field contains call type info (PERFORM, CALL METHOD, etc.)
-		// When program changes, we have a call edge
-		if prevProgram != "" && prevProgram != entry.Program {
-			edgeKey := prevProgram + "->" + entry.Program
-			if !seen[edgeKey] {
-				seen[edgeKey] = true
-				edges = append(edges, CallGraphEdge{
-					CallerURI:  "/sap/bc/adt/programs/programs/" + strings.ToLower(prevProgram),
-					CallerName: prevProgram,
-					CalleeURI:  "/sap/bc/adt/programs/programs/" + strings.ToLower(entry.Program),
-					CalleeName: entry.Program,
-					Line:       entry.Line,
-				})
+		if isTraceReturn(entry.Event) {
+			// Never pop the root program.
+			if len(stack) > 1 {
+				stack = stack[:len(stack)-1]
 			}
+			continue
+		}
+
+		callee, ok := parseTraceCall(entry.Event, entry.Program)
+		if !ok || len(stack) == 0 {
+			continue
+		}
+
+		caller := stack[len(stack)-1]
+		edgeKey := caller.name + "->" + callee.name
+		if !seen[edgeKey] {
+			seen[edgeKey] = true
+			edges = append(edges, CallGraphEdge{
+				CallerURI:  caller.uri,
+				CallerName: caller.name,
+				CalleeURI:  callee.uri,
+				CalleeName: callee.name,
+				Line:       entry.Line,
+			})
 		}
-		prevProgram = entry.Program
+		stack = append(stack, callee)
 	}
 
 	return edges
@@ -1603,6 +2842,9 @@ type TraceExecutionResult struct {
 	// Extracted call edges from trace
 	ActualEdges []CallGraphEdge `json:"actual_edges,omitempty"`
 
+	// Trace entries aggregated per program and event, slowest first
+	HotMethods []TraceAggregate `json:"hot_methods,omitempty"`
+
 	// Comparison between static and actual
 	Comparison *CallGraphComparison `json:"comparison,omitempty"`
 
@@ -1630,6 +2872,11 @@ type TraceExecutionOptions struct {
 
 	// TraceUser filters traces by user (optional)
 	TraceUser string
+
+	// StartTrace creates a trace request for TraceUser before running the
+	// tests and analyzes the trace it produced, instead of the user's most
+	// recent pre-existing trace.
+	StartTrace bool
 }
 
 // TraceExecution performs a traced execution and compares actual vs static call graphs.
@@ -1654,6 +2901,21 @@ func (c *Client) TraceExecution(ctx context.Context, opts *TraceExecutionOptions
 		}
 	}
 
+	traceUser := opts.TraceUser
+	if traceUser == "" {
+		// Use current user from config
+		traceUser = c.config.Username
+	}
+
+	var traceRequest *TraceRequest
+	if opts.StartTrace {
+		req, err := c.StartTrace(ctx, StartTraceOptions{User: traceUser})
+		if err != nil {
+			return nil, err
+		}
+		traceRequest = req
+	}
+
 	// Step 2: Run unit tests if requested (to trigger execution)
 	if opts.RunTests && opts.TestObjectURI != "" {
 		testResult, err := c.RunUnitTests(ctx, opts.TestObjectURI, nil)
@@ -1668,19 +2930,28 @@ func (c *Client) TraceExecution(ctx context.Context, opts *TraceExecutionOptions
 		}
 	}
 
-	// Step 3: Get latest trace for user
-	traceUser := opts.TraceUser
-	if traceUser == "" {
-		// Use current user from config
-		traceUser = c.config.Username
+	if traceRequest != nil {
+		if err := c.StopTrace(ctx, traceRequest.ID); err != nil {
+			return nil, err
+		}
 	}
 
+	// Step 3: Get the trace for user: the one our request produced, or the
+	// most recent one
 	traces, err := c.ListTraces(ctx, &TraceQueryOptions{
 		User:       traceUser,
 		MaxResults: 5,
 	})
+	if traceRequest != nil && err == nil {
+		var own []ABAPTrace
+		for _, t := range traces {
+			if t.Title == traceRequest.Description || t.Description == traceRequest.Description {
+				own = append(own, t)
+			}
+		}
+		traces = own
+	}
 	if err == nil && len(traces) > 0 {
-		// Get the most recent trace
 		latestTrace := traces[0]
 
 		// Get hitlist analysis
@@ -1691,6 +2962,7 @@ func (c *Client) TraceExecution(ctx context.Context, opts *TraceExecutionOptions
 
 			// Step 4: Extract actual call edges from trace
 			result.ActualEdges = ExtractCallEdgesFromTrace(analysis.Entries)
+			result.HotMethods = AggregateTrace(analysis.Entries)
 
 			// Step 5: Compare static vs actual if we have both
 			if result.StaticGraph != nil {
@@ -2209,6 +3481,256 @@ func (c *Client) GetTrace(ctx context.Context, traceID string, toolType string)
 	return parseTraceAnalysis(resp.Body, traceID, toolType)
 }
 
+// StartTraceOptions configures an ABAP trace request created by StartTrace.
+type StartTraceOptions struct {
+	// User whose work processes are traced (default: the client user).
+	User string
+	// ProcessType restricts the traced processes: ANY (default), DIALOG,
+	// HTTP, RFC, BATCH, ...
+	ProcessType string
+	// ObjectType restricts what is traced: ANY (default), URL, TRANSACTION,
+	// REPORT, FUNCTION_MODULE.
+	ObjectType string
+	// Description identifies the request and the traces it produces.
+	Description string
+	// MaxExecutions is how many executions are traced (default 1).
+	MaxExecutions int
+	// Expires ends the request if StopTrace is never called (default 1h).
+	Expires time.Duration
+	// Aggregate records an aggregated hitlist instead of the full call
+	// sequence. Leave false to extract call edges from the trace.
+	Aggregate bool
+}
+
+// TraceRequest is an active trace request created by StartTrace.
+type TraceRequest struct {
+	ID          string `json:"id"`
+	URI         string `json:"uri,omitempty"`
+	Description string `json:"description,omitempty"`
+	User        string `json:"user"`
+}
+
+// StartTrace activates an ABAP trace (SAT) for the next executions of a
+// user. It registers the trace parameters, then creates a trace request;
+// the resulting traces show up in ListTraces. Call StopTrace with the
+// returned ID to end the request before it expires.
+func (c *Client) StartTrace(ctx context.Context, opts StartTraceOptions) (*TraceRequest, error) {
+	if err := c.checkSafety(OpCreate, "StartTrace"); err != nil {
+		return nil, err
+	}
+	if opts.User == "" {
+		opts.User = c.config.Username
+	}
+	if opts.ProcessType == "" {
+		opts.ProcessType = "ANY"
+	}
+	if opts.ObjectType == "" {
+		opts.ObjectType = "ANY"
+	}
+	if opts.Description == "" {
+		opts.Description = "vsp trace " + time.Now().Format("20060102-150405")
+	}
+	if opts.MaxExecutions <= 0 {
+		opts.MaxExecutions = 1
+	}
+	if opts.Expires <= 0 {
+		opts.Expires = time.Hour
+	}
+
+	resp, err := c.transport.Request(ctx, "/sap/bc/adt/runtime/traces/abaptraces/parameters", &RequestOptions{
+		Method:      http.MethodPost,
+		ContentType: "application/xml",
+		Body:        []byte(buildTraceParametersXML(opts)),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("setting trace parameters: %w", err)
+	}
+	parametersID := resp.Headers.Get("Location")
+	if parametersID == "" {
+		return nil, fmt.Errorf("setting trace parameters: no parameters location returned")
+	}
+
+	query := url.Values{}
+	query.Set("server", "*")
+	query.Set("description", opts.Description)
+	query.Set("traceUser", strings.ToUpper(opts.User))
+	query.Set("traceClient", c.config.Client)
+	query.Set("processType", opts.ProcessType)
+	query.Set("objectType", opts.ObjectType)
+	query.Set("expires", time.Now().Add(opts.Expires).UTC().Format(time.RFC3339))
+	query.Set("maximalExecutions", strconv.Itoa(opts.MaxExecutions))
+	query.Set("parametersId", parametersID)
+
+	resp, err = c.transport.Request(ctx, "/sap/bc/adt/runtime/traces/abaptraces/requests", &RequestOptions{
+		Method: http.MethodPost,
+		Accept: "application/xml",
+		Query:  query,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("creating trace request: %w", err)
+	}
+
+	req := &TraceRequest{Description: opts.Description, User: strings.ToUpper(opts.User)}
+	if traces, err := parseTracesFeed(resp.Body); err == nil && len(traces) > 0 {
+		req.ID = traces[0].ID
+		req.URI = traces[0].URI
+	}
+	if req.ID == "" {
+		if loc := resp.Headers.Get("Location"); loc != "" {
+			req.URI = loc
+			req.ID = path.Base(loc)
+		}
+	}
+	if req.ID == "" {
+		return nil, fmt.Errorf("creating trace request: no request ID returned")
+	}
+	return req, nil
+}
+
+// StopTrace ends a trace request created by StartTrace. Traces already
+// recorded for it are kept.
+func (c *Client) StopTrace(ctx context.Context, requestID string) error {
+	if err := c.checkSafety(OpDelete, "StopTrace"); err != nil {
+		return err
+	}
+	if requestID == "" {
+		return fmt.Errorf("trace request ID is required")
+	}
+	id := path.Base(requestID)
+	_, err := c.transport.Request(ctx, "/sap/bc/adt/runtime/traces/abaptraces/requests/"+url.PathEscape(id), &RequestOptions{
+		Method: http.MethodDelete,
+	})
+	if err != nil {
+		return fmt.Errorf("stopping trace request %s: %w", id, err)
+	}
+	return nil
+}
+
+// buildTraceParametersXML returns the trace parameters for StartTrace:
+// procedural units and DB access with RFC tracing, the settings needed to
+// rebuild a call graph.
+func buildTraceParametersXML(opts StartTraceOptions) string {
+	return fmt.Sprintf(`<?xml version="1.0" encoding="UTF-8"?>
+<trc:parameters xmlns:trc="http://www.sap.com/adt/runtime/traces/abaptraces">
+  <trc:allMiscAbapStatements value="false"/>
+  <trc:allProceduralUnits value="true"/>
+  <trc:allInternalTableEvents value="false"/>
+  <trc:allDynproEvents value="false"/>
+  <trc:description value="%s"/>
+  <trc:aggregate value="%t"/>
+  <trc:explicitOnOff value="false"/>
+  <trc:withRfcTracing value="true"/>
+  <trc:allSystemKernelEvents value="false"/>
+  <trc:sqlTrace value="false"/>
+  <trc:allDbEvents value="true"/>
+  <trc:maxSizeForTraceFile value="30720"/>
+  <trc:amdpTrace value="false"/>
+  <trc:maxTimeForTracing value="1800"/>
+</trc:parameters>`, xmlEscape(opts.Description), opts.Aggregate)
+}
+
+// TraceFilter narrows the entries returned by GetTraceFiltered. Zero values
+// disable a dimension.
+type TraceFilter struct {
+	// MinGrossTimeUs drops entries below this gross time in microseconds.
+	MinGrossTimeUs int64
+	// ProgramPattern keeps entries whose program matches, case-insensitively.
+	// A trailing * matches by prefix (e.g. "ZCL_DEMO*").
+	ProgramPattern string
+	// TopN keeps only the N entries with the highest gross time.
+	TopN int
+}
+
+// GetTraceFiltered retrieves a trace analysis like GetTrace and applies
+// filter to its entries. TotalTime and TotalCalls still describe the whole
+// trace, so percentages remain comparable.
+func (c *Client) GetTraceFiltered(ctx context.Context, traceID string, toolType string, filter TraceFilter) (*TraceAnalysis, error) {
+	analysis, err := c.GetTrace(ctx, traceID, toolType)
+	if err != nil {
+		return nil, err
+	}
+	analysis.Entries = FilterTraceEntries(analysis.Entries, filter)
+	return analysis, nil
+}
+
+// FilterTraceEntries applies filter to trace entries. Without TopN the
+// original order is kept; with TopN the result is sorted by gross time,
+// highest first.
+func FilterTraceEntries(entries []TraceEntry, filter TraceFilter) []TraceEntry {
+	pattern := strings.ToUpper(filter.ProgramPattern)
+	prefix, isPrefix := strings.CutSuffix(pattern, "*")
+
+	var result []TraceEntry
+	for _, e := range entries {
+		if filter.MinGrossTimeUs > 0 && e.GrossTime < filter.MinGrossTimeUs {
+			continue
+		}
+		if pattern != "" {
+			program := strings.ToUpper(e.Program)
+			if isPrefix && !strings.HasPrefix(program, prefix) || !isPrefix && program != pattern {
+				continue
+			}
+		}
+		result = append(result, e)
+	}
+
+	if filter.TopN > 0 {
+		sort.SliceStable(result, func(i, j int) bool {
+			return result[i].GrossTime > result[j].GrossTime
+		})
+		if len(result) > filter.TopN {
+			result = result[:filter.TopN]
+		}
+	}
+	return result
+}
+
+// TraceAggregate sums the trace entries of one program and event (the
+// method, form or function module being measured).
+type TraceAggregate struct {
+	Program   string `json:"program"`
+	Event     string `json:"event,omitempty"`
+	GrossTime int64  `json:"grossTime"` // microseconds
+	NetTime   int64  `json:"netTime"`   // microseconds
+	Calls     int    `json:"calls"`
+}
+
+// AggregateTrace groups trace entries by program and event, summing gross
+// and net time and the number of calls. An entry without a call count counts
+// as one call. The result is sorted by gross time, highest first, then by
+// program and event.
+func AggregateTrace(entries []TraceEntry) []TraceAggregate {
+	index := make(map[string]int)
+	var result []TraceAggregate
+	for _, e := range entries {
+		key := e.Program + "\x00" + e.Event
+		i, ok := index[key]
+		if !ok {
+			i = len(result)
+			index[key] = i
+			result = append(result, TraceAggregate{Program: e.Program, Event: e.Event})
+		}
+		calls := e.Calls
+		if calls == 0 {
+			calls = 1
+		}
+		result[i].GrossTime += e.GrossTime
+		result[i].NetTime += e.NetTime
+		result[i].Calls += calls
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].GrossTime != result[j].GrossTime {
+			return result[i].GrossTime > result[j].GrossTime
+		}
+		if result[i].Program != result[j].Program {
+			return result[i].Program < result[j].Program
+		}
+		return result[i].Event < result[j].Event
+	})
+	return result
+}
+
 // traceEntryXML is used for parsing trace feed entries.
 type traceEntryXML struct {
 	ID      string `xml:"id"`
@@ -2,10 +2,17 @@ package adt
 
 import (
 	"context"
+	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
 	"strings"
 	"testing"
+	"time"
 )
 
 // mockTransportClient is a mock for testing the ADT client.
@@ -83,6 +90,90 @@ func TestClient_SearchObject(t *testing.T) {
 	}
 }
 
+func TestClient_SearchObjectPaged(t *testing.T) {
+	// Five matching objects served in rows by startRow/maxResults.
+	names := []string{"ZDEMO_A", "ZDEMO_B", "ZDEMO_C", "ZDEMO_D", "ZDEMO_E"}
+	var starts []string
+	mock := &funcMockClient{doFunc: func(req *http.Request) (*http.Response, error) {
+		if strings.Contains(req.URL.Path, "discovery") {
+			r := newTestResponse("OK")
+			r.Header.Set("X-CSRF-Token", "test-token")
+			return r, nil
+		}
+		q := req.URL.Query()
+		starts = append(starts, q.Get("startRow"))
+		start, _ := strconv.Atoi(q.Get("startRow"))
+		max, _ := strconv.Atoi(q.Get("maxResults"))
+		var b strings.Builder
+		b.WriteString(`<adtcore:objectReferences xmlns:adtcore="http://www.sap.com/adt/core">`)
+		for i := start; i < len(names) && i < start+max; i++ {
+			fmt.Fprintf(&b, `<adtcore:objectReference adtcore:uri="/sap/bc/adt/programs/programs/%s" adtcore:type="PROG/P" adtcore:name="%s" adtcore:packageName="$ZDEMO"/>`,
+				strings.ToLower(names[i]), names[i])
+		}
+		b.WriteString(`</adtcore:objectReferences>`)
+		return newTestResponse(b.String()), nil
+	}}
+	cfg := NewConfig("https://sap.example.com:44300", "user", "pass")
+	client := NewClientWithTransport(cfg, NewTransportWithClient(cfg, mock))
+
+	first, err := client.SearchObjectPaged(context.Background(), "ZDEMO_*", 3, 0)
+	if err != nil {
+		t.Fatalf("SearchObjectPaged failed: %v", err)
+	}
+	if len(first.Results) != 3 || !first.HasMore || first.NextOffset != 3 {
+		t.Fatalf("first page: %d results, hasMore=%v, next=%d", len(first.Results), first.HasMore, first.NextOffset)
+	}
+
+	second, err := client.SearchObjectPaged(context.Background(), "ZDEMO_*", 3, first.NextOffset)
+	if err != nil {
+		t.Fatalf("SearchObjectPaged failed: %v", err)
+	}
+	if len(second.Results) != 2 || second.HasMore || second.NextOffset != 0 {
+		t.Fatalf("second page: %d results, hasMore=%v, next=%d", len(second.Results), second.HasMore, second.NextOffset)
+	}
+	if second.Results[0].Name != "ZDEMO_D" || second.Results[1].Name != "ZDEMO_E" {
+		t.Errorf("second page = %+v", second.Results)
+	}
+	if len(starts) != 2 || starts[0] != "0" || starts[1] != "3" {
+		t.Errorf("startRow params = %v, want [0 3]", starts)
+	}
+}
+
+func TestRankSearchResults(t *testing.T) {
+	candidates := []string{
+		"ZCL_DEMO_FOO_HELPER",
+		"ZCL_FOO",
+		"/DMO/CL_FOO",
+		"CL_FOO_BAR",
+		"CL_FOO",
+		"/DMO/CL_FOO_BAR",
+		"ZIF_DEMO_OTHER",
+	}
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{"CL_FOO", []string{"CL_FOO", "/DMO/CL_FOO", "CL_FOO_BAR", "/DMO/CL_FOO_BAR", "ZCL_FOO", "ZIF_DEMO_OTHER", "ZCL_DEMO_FOO_HELPER"}},
+		{"cl_foo*", []string{"CL_FOO", "/DMO/CL_FOO", "CL_FOO_BAR", "/DMO/CL_FOO_BAR", "ZCL_FOO", "ZIF_DEMO_OTHER", "ZCL_DEMO_FOO_HELPER"}},
+		{"/DMO/CL_FOO", []string{"/DMO/CL_FOO", "/DMO/CL_FOO_BAR", "CL_FOO", "ZCL_FOO", "CL_FOO_BAR", "ZIF_DEMO_OTHER", "ZCL_DEMO_FOO_HELPER"}},
+		{"DEMO", []string{"ZIF_DEMO_OTHER", "ZCL_DEMO_FOO_HELPER", "CL_FOO", "ZCL_FOO", "CL_FOO_BAR", "/DMO/CL_FOO", "/DMO/CL_FOO_BAR"}},
+	}
+	for _, tt := range tests {
+		results := make([]SearchResult, len(candidates))
+		for i, name := range candidates {
+			results[i] = SearchResult{Name: name}
+		}
+		rankSearchResults(tt.query, results)
+		var got []string
+		for _, r := range results {
+			got = append(got, r.Name)
+		}
+		if strings.Join(got, " ") != strings.Join(tt.want, " ") {
+			t.Errorf("rankSearchResults(%q) = %v, want %v", tt.query, got, tt.want)
+		}
+	}
+}
+
 func TestClient_CheckObjectPackageSafety_NormalizesObjectURLs(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -163,6 +254,70 @@ func TestClient_DeleteObject_EnforcesAllowedPackages(t *testing.T) {
 	}
 }
 
+func TestClient_DeleteObjectByName_SafetyGate(t *testing.T) {
+	mock := &methodPathMock{}
+	cfg := NewConfig("https://sap.example.com:44300", "user", "pass", WithReadOnly())
+	client := NewClientWithTransport(cfg, NewTransportWithClient(cfg, mock))
+
+	err := client.DeleteObjectByName(context.Background(), ObjectTypeClass, "ZCL_DEMO_SCRATCH", true)
+	if err == nil || !strings.Contains(err.Error(), "blocked by safety configuration") {
+		t.Fatalf("expected safety rejection, got %v", err)
+	}
+	if len(mock.calls) != 0 {
+		t.Errorf("blocked delete must not reach SAP, got %d requests", len(mock.calls))
+	}
+
+	cfg = NewConfig("https://sap.example.com:44300", "user", "pass", WithSafety(SafetyConfig{DisallowedOps: "D"}))
+	client = NewClientWithTransport(cfg, NewTransportWithClient(cfg, mock))
+	if err := client.DeleteObjectByName(context.Background(), ObjectTypeProgram, "ZDEMO_SCRATCH", true); err == nil {
+		t.Fatal("expected rejection when delete operations are disallowed")
+	}
+	if len(mock.calls) != 0 {
+		t.Errorf("blocked delete must not reach SAP, got %d requests", len(mock.calls))
+	}
+}
+
+func TestClient_DeleteObjectByName_RefusesTransported(t *testing.T) {
+	mock := &mockTransportClient{
+		responses: map[string]*http.Response{
+			"search":    newSearchResponse("/sap/bc/adt/oo/classes/zcl_demo_prod", "CLAS/OC", "ZCL_DEMO_PROD", "ZDEMO_PROD"),
+			"discovery": newTestResponse("OK"),
+		},
+	}
+	cfg := NewConfig("https://sap.example.com:44300", "user", "pass")
+	client := NewClientWithTransport(cfg, NewTransportWithClient(cfg, mock))
+
+	err := client.DeleteObjectByName(context.Background(), ObjectTypeClass, "zcl_demo_prod", false)
+	if err == nil || !strings.Contains(err.Error(), "refusing to delete ZCL_DEMO_PROD in package ZDEMO_PROD") {
+		t.Fatalf("expected refusal outside $TMP, got %v", err)
+	}
+	for _, req := range mock.requests {
+		if req.Method == http.MethodDelete || req.URL.Query().Get("_action") == "LOCK" {
+			t.Errorf("unexpected %s %s", req.Method, req.URL.Path)
+		}
+	}
+}
+
+func TestClient_DeleteObjectByName_TmpObject(t *testing.T) {
+	mock := &methodPathMock{routes: []routedResponse{
+		resp("", "discovery", http.StatusOK, "ok"),
+		resp(http.MethodGet, "/repository/informationsystem/search", http.StatusOK, `<adtcore:objectReferences xmlns:adtcore="http://www.sap.com/adt/core">
+  <adtcore:objectReference adtcore:uri="/sap/bc/adt/oo/interfaces/zif_demo_scratch" adtcore:type="INTF/OI" adtcore:name="ZIF_DEMO_SCRATCH" adtcore:packageName="$TMP"/>
+</adtcore:objectReferences>`),
+		resp(http.MethodPost, "/oo/interfaces/ZIF_DEMO_SCRATCH", http.StatusOK, lockResponseXML),
+		resp(http.MethodDelete, "/oo/interfaces/ZIF_DEMO_SCRATCH", http.StatusOK, ""),
+	}}
+	cfg := NewConfig("https://sap.example.com:44300", "user", "pass")
+	client := NewClientWithTransport(cfg, NewTransportWithClient(cfg, mock))
+
+	if err := client.DeleteObjectByName(context.Background(), ObjectTypeInterface, "ZIF_DEMO_SCRATCH", false); err != nil {
+		t.Fatalf("DeleteObjectByName failed: %v", err)
+	}
+	if n := countCalls(mock, http.MethodDelete); n != 1 {
+		t.Errorf("expected one DELETE, got %d", n)
+	}
+}
+
 func TestClient_CreateTestInclude_EnforcesAllowedPackages(t *testing.T) {
 	mock := &mockTransportClient{
 		responses: map[string]*http.Response{
@@ -328,6 +483,162 @@ WRITE 'Hello World'.`
 	}
 }
 
+func TestClient_GetObjectSource(t *testing.T) {
+	mock := &methodPathMock{routes: []routedResponse{
+		resp(http.MethodGet, "/programs/programs/ZDEMO_REPORT/source/main", http.StatusOK, "REPORT zdemo_report."),
+		resp(http.MethodGet, "/oo/classes/ZCL_DEMO/source/main", http.StatusOK, "CLASS zcl_demo DEFINITION."),
+		resp(http.MethodGet, "/oo/interfaces/ZIF_DEMO/source/main", http.StatusOK, "INTERFACE zif_demo."),
+		resp(http.MethodGet, "/functions/groups/ZDEMO_FG/fmodules/Z_DEMO_FM/source/main", http.StatusOK, "FUNCTION z_demo_fm."),
+		resp(http.MethodGet, "/ddic/ddl/sources/ZDEMO_I_ORDER/source/main", http.StatusOK, "define view entity ZDEMO_I_ORDER"),
+	}}
+	cfg := NewConfig("https://sap.example.com:44300", "user", "pass")
+	client := NewClientWithTransport(cfg, NewTransportWithClient(cfg, mock))
+	ctx := context.Background()
+
+	tests := []struct {
+		objType      CreatableObjectType
+		name, parent string
+		want         string
+	}{
+		{ObjectTypeProgram, "zdemo_report", "", "REPORT zdemo_report."},
+		{ObjectTypeClass, "ZCL_DEMO", "", "CLASS zcl_demo DEFINITION."},
+		{ObjectTypeInterface, "ZIF_DEMO", "", "INTERFACE zif_demo."},
+		{ObjectTypeFunctionMod, "Z_DEMO_FM", "ZDEMO_FG", "FUNCTION z_demo_fm."},
+		{ObjectTypeDDLS, "ZDEMO_I_ORDER", "", "define view entity ZDEMO_I_ORDER"},
+	}
+	for _, tt := range tests {
+		got, err := client.GetObjectSource(ctx, tt.objType, tt.name, tt.parent)
+		if err != nil {
+			t.Errorf("%s %s: %v", tt.objType, tt.name, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("%s %s = %q, want %q", tt.objType, tt.name, got, tt.want)
+		}
+	}
+
+	if _, err := client.GetObjectSource(ctx, ObjectTypeFunctionMod, "Z_DEMO_FM", ""); err == nil || !strings.Contains(err.Error(), "parent function group") {
+		t.Errorf("expected missing-parent error, got %v", err)
+	}
+	if _, err := client.GetObjectSource(ctx, ObjectTypePackage, "$ZDEMO", ""); err == nil {
+		t.Error("expected error for type without source")
+	}
+}
+
+func TestClient_GetObjectSource_AutoMasterLanguage(t *testing.T) {
+	var languages []string
+	mock := &funcMockClient{
+		doFunc: func(req *http.Request) (*http.Response, error) {
+			path := strings.ToUpper(req.URL.Path)
+			lang := req.URL.Query().Get("sap-language")
+			switch {
+			case strings.HasSuffix(path, "/ZDEMO_DE_ONLY/SOURCE/MAIN"):
+				languages = append(languages, lang)
+				if lang == "DE" {
+					return newTestResponse("REPORT zdemo_de_only."), nil
+				}
+				return newTestResponse(""), nil
+			case strings.HasSuffix(path, "/ZDEMO_DE_ONLY"):
+				return newTestResponse(`<program:abapProgram xmlns:program="http://www.sap.com/adt/programs/programs" xmlns:adtcore="http://www.sap.com/adt/core" adtcore:name="ZDEMO_DE_ONLY" adtcore:masterLanguage="DE"/>`), nil
+			}
+			return newTestResponse(""), nil
+		},
+	}
+
+	cfg := NewConfig("https://sap.example.com:44300", "user", "pass", WithAutoMasterLanguage())
+	client := NewClientWithTransport(cfg, NewTransportWithClient(cfg, mock))
+
+	source, err := client.GetObjectSource(context.Background(), ObjectTypeProgram, "ZDEMO_DE_ONLY", "")
+	if err != nil {
+		t.Fatalf("GetObjectSource failed: %v", err)
+	}
+	if source != "REPORT zdemo_de_only." {
+		t.Errorf("source = %q, want master-language source", source)
+	}
+	if want := []string{"EN", "DE"}; strings.Join(languages, ",") != strings.Join(want, ",") {
+		t.Errorf("read languages = %v, want %v", languages, want)
+	}
+
+	// Without the option the empty default-language read is returned as is
+	languages = nil
+	plainCfg := NewConfig("https://sap.example.com:44300", "user", "pass")
+	plain := NewClientWithTransport(plainCfg, NewTransportWithClient(plainCfg, mock))
+	if source, err := plain.GetObjectSource(context.Background(), ObjectTypeProgram, "ZDEMO_DE_ONLY", ""); err != nil || source != "" {
+		t.Errorf("without option: source = %q, err = %v", source, err)
+	}
+	if len(languages) != 1 {
+		t.Errorf("without option: expected a single read, got %v", languages)
+	}
+}
+
+func TestClient_GetTransformation(t *testing.T) {
+	sourceCode := `<?sap.transform simple?>
+<tt:transform xmlns:tt="http://www.sap.com/transformation-templates">
+  <tt:root name="FLIGHT"/>
+</tt:transform>`
+
+	mock := &mockTransportClient{
+		responses: map[string]*http.Response{
+			"/sap/bc/adt/xslt/transformations/": newTestResponse(sourceCode),
+		},
+	}
+
+	cfg := NewConfig("https://sap.example.com:44300", "user", "pass")
+	client := NewClientWithTransport(cfg, NewTransportWithClient(cfg, mock))
+
+	source, err := client.GetTransformation(context.Background(), "/DMO/ST_FLIGHT")
+	if err != nil {
+		t.Fatalf("GetTransformation failed: %v", err)
+	}
+	if source != sourceCode {
+		t.Errorf("unexpected source: %q", source)
+	}
+
+	want := "/sap/bc/adt/xslt/transformations/%2Fdmo%2Fst_flight/source/main"
+	if got := mock.requests[0].URL.EscapedPath(); got != want {
+		t.Errorf("path = %q, want %q", got, want)
+	}
+	if got := GetObjectURL(ObjectTypeTransformation, "ZDEMO_XSLT", ""); got != "/sap/bc/adt/xslt/transformations/zdemo_xslt" {
+		t.Errorf("GetObjectURL = %q", got)
+	}
+}
+
+func TestClient_GetTypeGroup_RoundTrip(t *testing.T) {
+	sourceCode := `TYPE-POOL zdemo.
+TYPES zdemo_flag TYPE c LENGTH 1.
+`
+	mock := &mockTransportClient{
+		responses: map[string]*http.Response{
+			"/sap/bc/adt/ddic/typegroups/zdemo/source/main": newTestResponse(sourceCode),
+		},
+	}
+	cfg := NewConfig("https://sap.example.com:44300", "user", "pass")
+	client := NewClientWithTransport(cfg, NewTransportWithClient(cfg, mock))
+
+	source, err := client.GetTypeGroup(context.Background(), "ZDEMO")
+	if err != nil {
+		t.Fatalf("GetTypeGroup failed: %v", err)
+	}
+	if source != sourceCode {
+		t.Errorf("unexpected source: %q", source)
+	}
+
+	filePath := filepath.Join(t.TempDir(), "zdemo.type.abap")
+	if err := os.WriteFile(filePath, []byte(source), 0644); err != nil {
+		t.Fatal(err)
+	}
+	info, err := ParseABAPFile(filePath)
+	if err != nil {
+		t.Fatalf("ParseABAPFile failed: %v", err)
+	}
+	if info.ObjectType != ObjectTypeTypeGroup || info.ObjectName != "ZDEMO" {
+		t.Errorf("round trip = %s %s, want %s ZDEMO", info.ObjectType, info.ObjectName, ObjectTypeTypeGroup)
+	}
+	if got := GetSourceURL(info.ObjectType, info.ObjectName, ""); got != "/sap/bc/adt/ddic/typegroups/zdemo/source/main" {
+		t.Errorf("GetSourceURL = %q", got)
+	}
+}
+
 func TestClient_GetClass(t *testing.T) {
 	sourceCode := `CLASS zcl_test DEFINITION PUBLIC.
 ENDCLASS.
@@ -451,3 +762,955 @@ func TestParseSRVBMetadata(t *testing.T) {
 		t.Errorf("expected service def name 'Z_RAP_TRAVEL', got '%s'", result.ServiceDefName)
 	}
 }
+
+func newPackageMetadataResponse(name, superPkg, swComp string) *http.Response {
+	return newTestResponse(`<?xml version="1.0" encoding="UTF-8"?>
+<pak:package xmlns:pak="http://www.sap.com/adt/packages" xmlns:adtcore="http://www.sap.com/adt/core" adtcore:name="` + name + `" adtcore:description="Demo package" adtcore:type="DEVC/K">
+  <pak:superPackage adtcore:name="` + superPkg + `" adtcore:type="DEVC/K"/>
+  <pak:transport>
+    <pak:softwareComponent pak:name="` + swComp + `"/>
+    <pak:transportLayer pak:name=""/>
+  </pak:transport>
+</pak:package>`)
+}
+
+func TestClient_ListPackages_SoftwareComponentFilter(t *testing.T) {
+	searchResponse := `<?xml version="1.0" encoding="UTF-8"?>
+<adtcore:objectReferences xmlns:adtcore="http://www.sap.com/adt/core">
+  <adtcore:objectReference adtcore:uri="/sap/bc/adt/packages/zdemo_a" adtcore:type="DEVC/K" adtcore:name="ZDEMO_A" adtcore:packageName="ZDEMO"/>
+  <adtcore:objectReference adtcore:uri="/sap/bc/adt/packages/zdemo_b" adtcore:type="DEVC/K" adtcore:name="ZDEMO_B" adtcore:packageName="ZDEMO"/>
+</adtcore:objectReferences>`
+
+	mock := &mockTransportClient{
+		responses: map[string]*http.Response{
+			"/sap/bc/adt/repository/informationsystem/search": newTestResponse(searchResponse),
+			"/sap/bc/adt/packages/ZDEMO_A":                    newPackageMetadataResponse("ZDEMO_A", "ZDEMO", "HOME"),
+			"/sap/bc/adt/packages/ZDEMO_B":                    newPackageMetadataResponse("ZDEMO_B", "ZDEMO", "LOCAL"),
+		},
+	}
+
+	cfg := NewConfig("https://sap.example.com:44300", "user", "pass")
+	client := NewClientWithTransport(cfg, NewTransportWithClient(cfg, mock))
+
+	packages, err := client.ListPackages(context.Background(), &PackageQueryOptions{
+		Query:             "ZDEMO*",
+		SoftwareComponent: "home",
+	})
+	if err != nil {
+		t.Fatalf("ListPackages failed: %v", err)
+	}
+
+	if len(packages) != 1 {
+		t.Fatalf("expected 1 package, got %+v", packages)
+	}
+	if packages[0].Name != "ZDEMO_A" || packages[0].SoftwareComponent != "HOME" || packages[0].SuperPackage != "ZDEMO" {
+		t.Errorf("unexpected package: %+v", packages[0])
+	}
+
+	q := mock.requests[0].URL.Query()
+	if q.Get("objectType") != "DEVC/K" || q.Get("query") != "ZDEMO*" {
+		t.Errorf("unexpected search parameters: %v", q)
+	}
+}
+
+func TestMethodBlockRange_TrailingBlankLines(t *testing.T) {
+	source := strings.Join([]string{
+		"CLASS zcl_demo IMPLEMENTATION.", // 1
+		"",                               // 2
+		"  METHOD first.",                // 3
+		"    WRITE 'ENDMETHOD.'.",        // 4
+		"  ENDMETHOD.",                   // 5
+		"",                               // 6
+		"",                               // 7
+		"  METHOD zif_demo~second.",      // 8
+		"    \" ENDMETHOD in a comment",  // 9
+		"    DATA lv TYPE i.",            // 10
+		"  ENDMETHOD.",                   // 11
+		"",                               // 12
+		"ENDCLASS.",                      // 13
+	}, "\n")
+
+	tests := []struct {
+		name               string
+		method             string
+		start, end         int
+		wantStart, wantEnd int
+	}{
+		{"range includes trailing blanks", "FIRST", 3, 7, 3, 5},
+		{"range starts in blank gap", "ZIF_DEMO~SECOND", 6, 11, 8, 11},
+		{"range drops ENDMETHOD", "ZIF_DEMO~SECOND", 8, 10, 8, 11},
+		{"unknown method keeps range", "MISSING", 2, 4, 2, 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, end := methodBlockRange(source, tt.method, tt.start, tt.end)
+			if start != tt.wantStart || end != tt.wantEnd {
+				t.Errorf("methodBlockRange = (%d, %d), want (%d, %d)", start, end, tt.wantStart, tt.wantEnd)
+			}
+		})
+	}
+}
+
+func TestClient_GetClassMethodSource_BlankLinesAroundMethod(t *testing.T) {
+	source := "CLASS zcl_demo IMPLEMENTATION.\n\n\n  METHOD run.\n    WRITE 'x'.\n  ENDMETHOD.\n\n\nENDCLASS."
+	structure := `<?xml version="1.0" encoding="UTF-8"?>
+<abapsource:objectStructureElement xmlns:abapsource="http://www.sap.com/adt/abapsource" xmlns:adtcore="http://www.sap.com/adt/core" name="ZCL_DEMO" type="CLAS/OC">
+  <abapsource:objectStructureElement name="RUN" type="CLAS/OM" visibility="public" level="instance">
+    <atom:link xmlns:atom="http://www.w3.org/2005/Atom" href="./source/main#start=2,0;end=8,0" rel="http://www.sap.com/adt/relations/source/implementationBlock"/>
+  </abapsource:objectStructureElement>
+</abapsource:objectStructureElement>`
+
+	mock := &mockTransportClient{
+		responses: map[string]*http.Response{
+			"/sap/bc/adt/oo/classes/ZCL_DEMO/objectstructure": newTestResponse(structure),
+			"/sap/bc/adt/oo/classes/ZCL_DEMO/source/main":     newTestResponse(source),
+		},
+	}
+	cfg := NewConfig("https://sap.example.com:44300", "user", "pass")
+	client := NewClientWithTransport(cfg, NewTransportWithClient(cfg, mock))
+
+	got, err := client.GetClassMethodSource(context.Background(), "ZCL_DEMO", "RUN")
+	if err != nil {
+		t.Fatalf("GetClassMethodSource failed: %v", err)
+	}
+	want := "  METHOD run.\n    WRITE 'x'.\n  ENDMETHOD."
+	if got != want {
+		t.Errorf("GetClassMethodSource = %q, want %q", got, want)
+	}
+}
+
+func TestSnapMethodLines_OffByOne(t *testing.T) {
+	lines := []string{
+		"CLASS zcl_demo IMPLEMENTATION.",  // 1
+		"  METHOD run.",                   // 2
+		"    DEFINE add_line.",            // 3
+		"      APPEND &1 TO lt_lines.",    // 4
+		"    END-OF-DEFINITION.",          // 5
+		"    add_line 'a'. \" macro call", // 6
+		"  ENDMETHOD.",                    // 7
+		"  method Other.",                 // 8
+		"  endmethod.",                    // 9
+		"ENDCLASS.",                       // 10
+	}
+
+	tests := []struct {
+		name               string
+		method             string
+		start, end         int
+		wantStart, wantEnd int
+		wantOK             bool
+	}{
+		{"exact range", "RUN", 2, 7, 2, 7, true},
+		{"both boundaries one early", "RUN", 1, 6, 2, 7, true},
+		{"both boundaries one late", "RUN", 3, 8, 2, 7, true},
+		{"lower case keywords, one late", "OTHER", 9, 10, 8, 9, true},
+		{"end past the source", "OTHER", 8, 11, 8, 9, true},
+		{"method not near the range", "OTHER", 1, 3, 1, 3, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, end, ok := snapMethodLines(lines, tt.method, tt.start, tt.end)
+			if start != tt.wantStart || end != tt.wantEnd || ok != tt.wantOK {
+				t.Errorf("snapMethodLines = (%d, %d, %v), want (%d, %d, %v)", start, end, ok, tt.wantStart, tt.wantEnd, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestClient_GetClassMethodSource_OffByOneRanges(t *testing.T) {
+	source := strings.Join([]string{
+		"CLASS zcl_demo IMPLEMENTATION.",
+		"  METHOD first.",
+		"    DEFINE log.",
+		"      APPEND &1 TO mt_log.",
+		"    END-OF-DEFINITION.",
+		"    log 'first'.",
+		"  ENDMETHOD.",
+		"  METHOD last.",
+		"    \" ENDMETHOD in a comment",
+		"  ENDMETHOD.",
+		"ENDCLASS.",
+	}, "\n")
+	structure := `<?xml version="1.0" encoding="UTF-8"?>
+<abapsource:objectStructureElement xmlns:abapsource="http://www.sap.com/adt/abapsource" xmlns:adtcore="http://www.sap.com/adt/core" name="ZCL_DEMO" type="CLAS/OC">
+  <abapsource:objectStructureElement name="FIRST" type="CLAS/OM" visibility="public" level="instance">
+    <atom:link xmlns:atom="http://www.w3.org/2005/Atom" href="./source/main#start=1,0;end=6,0" rel="http://www.sap.com/adt/relations/source/implementationBlock"/>
+  </abapsource:objectStructureElement>
+  <abapsource:objectStructureElement name="LAST" type="CLAS/OM" visibility="public" level="instance">
+    <atom:link xmlns:atom="http://www.w3.org/2005/Atom" href="./source/main#start=9,0;end=12,0" rel="http://www.sap.com/adt/relations/source/implementationBlock"/>
+  </abapsource:objectStructureElement>
+</abapsource:objectStructureElement>`
+
+	tests := []struct {
+		method string
+		want   string
+	}{
+		{"FIRST", "  METHOD first.\n    DEFINE log.\n      APPEND &1 TO mt_log.\n    END-OF-DEFINITION.\n    log 'first'.\n  ENDMETHOD."},
+		{"LAST", "  METHOD last.\n    \" ENDMETHOD in a comment\n  ENDMETHOD."},
+	}
+	for _, tt := range tests {
+		mock := &mockTransportClient{
+			responses: map[string]*http.Response{
+				"/sap/bc/adt/oo/classes/ZCL_DEMO/objectstructure": newTestResponse(structure),
+				"/sap/bc/adt/oo/classes/ZCL_DEMO/source/main":     newTestResponse(source),
+			},
+		}
+		cfg := NewConfig("https://sap.example.com:44300", "user", "pass")
+		client := NewClientWithTransport(cfg, NewTransportWithClient(cfg, mock))
+
+		got, err := client.GetClassMethodSource(context.Background(), "ZCL_DEMO", tt.method)
+		if err != nil {
+			t.Fatalf("GetClassMethodSource(%s) failed: %v", tt.method, err)
+		}
+		if got != tt.want {
+			t.Errorf("GetClassMethodSource(%s) = %q, want %q", tt.method, got, tt.want)
+		}
+	}
+}
+
+func TestParseStructureNodes_Class(t *testing.T) {
+	structure := `<?xml version="1.0" encoding="UTF-8"?>
+<abapsource:objectStructureElement xmlns:abapsource="http://www.sap.com/adt/abapsource" xmlns:atom="http://www.w3.org/2005/Atom" name="ZCL_DEMO_ORDER" type="CLAS/OC">
+  <atom:link href="./source/main#start=1,0;end=40,8" rel="http://www.sap.com/adt/relations/source/definitionBlock"/>
+  <abapsource:objectStructureElement name="MV_ID" type="CLAS/OA" visibility="private" level="instance">
+    <atom:link href="./source/main#start=12,4;end=12,30" rel="http://www.sap.com/adt/relations/source/definitionIdentifier"/>
+  </abapsource:objectStructureElement>
+  <abapsource:objectStructureElement name="CREATE" type="CLAS/OM" visibility="public" level="static">
+    <atom:link href="./source/main#start=6,4;end=7,40" rel="http://www.sap.com/adt/relations/source/definitionBlock"/>
+    <atom:link href="./source/main#start=20,2;end=25,11" rel="http://www.sap.com/adt/relations/source/implementationBlock"/>
+  </abapsource:objectStructureElement>
+  <abapsource:objectStructureElement name="LCL_HELPER" type="CLAS/OC" visibility="private">
+    <atom:link href="./includes/implementations#start=1,0;end=9,8" rel="http://www.sap.com/adt/relations/source/definitionBlock"/>
+    <abapsource:objectStructureElement name="RUN" type="CLAS/OM" visibility="public">
+      <atom:link href="./includes/implementations#start=5,2;end=7,11" rel="http://www.sap.com/adt/relations/source/implementationBlock"/>
+    </abapsource:objectStructureElement>
+  </abapsource:objectStructureElement>
+</abapsource:objectStructureElement>`
+
+	nodes, err := parseStructureNodes([]byte(structure))
+	if err != nil {
+		t.Fatalf("parseStructureNodes failed: %v", err)
+	}
+	if len(nodes) != 3 {
+		t.Fatalf("expected 3 nodes, got %+v", nodes)
+	}
+	attr, method, local := nodes[0], nodes[1], nodes[2]
+	if attr.Kind != "attribute" || attr.Visibility != "private" || attr.StartLine != 12 || attr.EndLine != 12 {
+		t.Errorf("attribute = %+v", attr)
+	}
+	if method.Name != "CREATE" || method.Kind != "method" || method.StartLine != 20 || method.EndLine != 25 {
+		t.Errorf("method = %+v, want the implementation block 20-25", method)
+	}
+	if local.Kind != "class" || len(local.Children) != 1 || local.Children[0].Name != "RUN" || local.Children[0].StartLine != 5 {
+		t.Errorf("local class = %+v", local)
+	}
+}
+
+func TestClient_GetObjectStructure_FunctionGroup(t *testing.T) {
+	structure := `<?xml version="1.0" encoding="UTF-8"?>
+<abapsource:objectStructureElement xmlns:abapsource="http://www.sap.com/adt/abapsource" xmlns:atom="http://www.w3.org/2005/Atom" name="ZDEMO_FG" type="FUGR/F">
+  <abapsource:objectStructureElement name="LZDEMO_FGTOP" type="FUGR/I">
+    <atom:link href="/sap/bc/adt/functions/groups/zdemo_fg/includes/lzdemo_fgtop/source/main#start=1,0;end=4,0" rel="http://www.sap.com/adt/relations/source/definitionIdentifier"/>
+  </abapsource:objectStructureElement>
+  <abapsource:objectStructureElement name="Z_DEMO_CONVERT" type="FUGR/FF">
+    <atom:link href="/sap/bc/adt/functions/groups/zdemo_fg/fmodules/z_demo_convert/source/main#start=1,0;end=18,11" rel="http://www.sap.com/adt/relations/source/definitionIdentifier"/>
+  </abapsource:objectStructureElement>
+</abapsource:objectStructureElement>`
+
+	mock := &methodPathMock{routes: []routedResponse{
+		resp("GET", "/sap/bc/adt/functions/groups/ZDEMO_FG/objectstructure", 200, structure),
+	}}
+	cfg := NewConfig("https://sap.example.com:44300", "user", "pass")
+	client := NewClientWithTransport(cfg, NewTransportWithClient(cfg, mock))
+
+	nodes, err := client.GetObjectStructure(context.Background(), "/sap/bc/adt/functions/groups/ZDEMO_FG/source/main")
+	if err != nil {
+		t.Fatalf("GetObjectStructure failed: %v", err)
+	}
+	if len(nodes) != 2 {
+		t.Fatalf("expected 2 nodes, got %+v", nodes)
+	}
+	if nodes[0].Kind != "include" || nodes[0].EndLine != 4 {
+		t.Errorf("include = %+v", nodes[0])
+	}
+	if nodes[1].Name != "Z_DEMO_CONVERT" || nodes[1].Kind != "function module" || nodes[1].StartLine != 1 || nodes[1].EndLine != 18 {
+		t.Errorf("function module = %+v", nodes[1])
+	}
+}
+
+const dataPreviewFixture = `<?xml version="1.0" encoding="utf-8"?>
+<dataPreview:tableData xmlns:dataPreview="http://www.sap.com/adt/dataPreview">
+  <dataPreview:totalRows>2</dataPreview:totalRows>
+  <dataPreview:isHanaAnalyticalView>false</dataPreview:isHanaAnalyticalView>
+  <dataPreview:executedQueryString>SELECT * FROM ZDEMO_CONFIG WHERE ACTIVE = 'X'</dataPreview:executedQueryString>
+  <dataPreview:queryExecutionTime>1.25</dataPreview:queryExecutionTime>
+  <dataPreview:columns>
+    <dataPreview:metadata dataPreview:name="PARAM" dataPreview:type="C" dataPreview:description="Parameter" dataPreview:keyAttribute="true" dataPreview:length="30"/>
+    <dataPreview:dataSet>
+      <dataPreview:data>TIMEOUT</dataPreview:data>
+      <dataPreview:data>RETRIES</dataPreview:data>
+    </dataPreview:dataSet>
+  </dataPreview:columns>
+  <dataPreview:columns>
+    <dataPreview:metadata dataPreview:name="VALUE" dataPreview:type="C" dataPreview:description="Value" dataPreview:keyAttribute="false" dataPreview:length="40"/>
+    <dataPreview:dataSet>
+      <dataPreview:data>30</dataPreview:data>
+      <dataPreview:data>3</dataPreview:data>
+    </dataPreview:dataSet>
+  </dataPreview:columns>
+</dataPreview:tableData>`
+
+func TestParseTableContents_DataPreview(t *testing.T) {
+	result, err := parseTableContents([]byte(dataPreviewFixture))
+	if err != nil {
+		t.Fatalf("parseTableContents failed: %v", err)
+	}
+	if len(result.Columns) != 2 || !result.Columns[0].IsKey || result.Columns[1].Length != 40 {
+		t.Errorf("unexpected columns: %+v", result.Columns)
+	}
+
+	data := result.Data()
+	if strings.Join(data.Columns, ",") != "PARAM,VALUE" {
+		t.Errorf("columns = %v", data.Columns)
+	}
+	want := [][]string{{"TIMEOUT", "30"}, {"RETRIES", "3"}}
+	if len(data.Rows) != len(want) {
+		t.Fatalf("expected %d rows, got %v", len(want), data.Rows)
+	}
+	for i := range want {
+		if strings.Join(data.Rows[i], ",") != strings.Join(want[i], ",") {
+			t.Errorf("row %d = %v, want %v", i, data.Rows[i], want[i])
+		}
+	}
+}
+
+func TestClient_GetTableContents_WhereClause(t *testing.T) {
+	var query, body string
+	mock := &funcMockClient{doFunc: func(req *http.Request) (*http.Response, error) {
+		if strings.Contains(req.URL.Path, "discovery") {
+			r := newTestResponse("ok")
+			r.Header.Set("X-CSRF-Token", "test-token")
+			return r, nil
+		}
+		query = req.URL.RawQuery
+		data, _ := io.ReadAll(req.Body)
+		body = string(data)
+		return newTestResponse(dataPreviewFixture), nil
+	}}
+	cfg := NewConfig("https://sap.example.com:44300", "user", "pass")
+	client := NewClientWithTransport(cfg, NewTransportWithClient(cfg, mock))
+
+	if _, err := client.GetTableContents(context.Background(), "zdemo_config", 25, "WHERE active = 'X'"); err != nil {
+		t.Fatalf("GetTableContents failed: %v", err)
+	}
+	if !strings.Contains(query, "rowNumber=25") {
+		t.Errorf("rowNumber not sent, query: %s", query)
+	}
+	if body != "SELECT * FROM ZDEMO_CONFIG WHERE active = 'X'" {
+		t.Errorf("statement = %q", body)
+	}
+
+	if _, err := client.GetTableContents(context.Background(), "ZDEMO_CONFIG; DROP", 25, ""); err == nil {
+		t.Error("expected an invalid table name to be rejected")
+	}
+}
+
+func TestSanitizeWhereClause(t *testing.T) {
+	tests := []struct {
+		clause  string
+		want    string
+		wantErr bool
+	}{
+		{"mandt = '001'", "mandt = '001'", false},
+		{"WHERE param LIKE 'A%' AND value <> 'x; y. \"z'", "param LIKE 'A%' AND value <> 'x; y. \"z'", false},
+		{"amount > 1.5", "amount > 1.5", false},
+		{"description = 'SELECT FROM'", "description = 'SELECT FROM'", false},
+		{"mandt = '001'. DELETE FROM zdemo_config.", "", true},
+		{"mandt = '001'; DROP TABLE x", "", true},
+		{"mandt = '001' \" comment", "", true},
+		{"mandt = '001'\n* comment", "", true},
+		{"mandt IN ( SELECT mandt FROM t000 )", "", true},
+		{"mandt = '001' UNION SELECT * FROM usr02", "", true},
+		{"mandt = '001", "", true},
+		{"  ", "", true},
+	}
+	for _, tt := range tests {
+		got, err := sanitizeWhereClause(tt.clause)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("sanitizeWhereClause(%q) = %q, want error", tt.clause, got)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("sanitizeWhereClause(%q) = %q, %v; want %q", tt.clause, got, err, tt.want)
+		}
+	}
+}
+
+func TestClient_RunSQLQuery(t *testing.T) {
+	var sent []string
+	mock := &funcMockClient{doFunc: func(req *http.Request) (*http.Response, error) {
+		if strings.Contains(req.URL.Path, "discovery") {
+			r := newTestResponse("ok")
+			r.Header.Set("X-CSRF-Token", "test-token")
+			return r, nil
+		}
+		data, _ := io.ReadAll(req.Body)
+		sent = append(sent, req.URL.Path+"?"+req.URL.RawQuery+" "+string(data))
+		return newTestResponse(dataPreviewFixture), nil
+	}}
+	cfg := NewConfig("https://sap.example.com:44300", "user", "pass")
+	client := NewClientWithTransport(cfg, NewTransportWithClient(cfg, mock))
+	ctx := context.Background()
+
+	rejected := []string{
+		"UPDATE zdemo_config SET value = '1'",
+		"DELETE FROM zdemo_config WHERE param = 'TIMEOUT'",
+		"MODIFY zdemo_config FROM ls_row",
+		"SELECT * FROM zdemo_config; DELETE FROM zdemo_config",
+		"  ",
+	}
+	for _, sql := range rejected {
+		if _, err := client.RunSQLQuery(ctx, sql, 10); err == nil {
+			t.Errorf("RunSQLQuery(%q) should be rejected", sql)
+		}
+	}
+	if len(sent) != 0 {
+		t.Fatalf("rejected statements must not be sent, got %v", sent)
+	}
+
+	result, err := client.RunSQLQuery(ctx, "SELECT param, value FROM zdemo_config WHERE note = 'no UPDATE; here'", 2)
+	if err != nil {
+		t.Fatalf("RunSQLQuery failed: %v", err)
+	}
+	if len(sent) != 1 || !strings.Contains(sent[0], "/datapreview/freestyle?rowNumber=2&") {
+		t.Errorf("unexpected request: %v", sent)
+	}
+	data := result.Data()
+	if len(result.Columns) != 2 || result.Columns[0].Type != "C" || len(data.Rows) != 2 || data.Rows[1][0] != "RETRIES" {
+		t.Errorf("unexpected result: %+v", data)
+	}
+
+	blocked := NewConfig("https://sap.example.com:44300", "user", "pass")
+	blocked.Safety.BlockFreeSQL = true
+	blockedClient := NewClientWithTransport(blocked, NewTransportWithClient(blocked, mock))
+	if _, err := blockedClient.RunSQLQuery(ctx, "SELECT * FROM zdemo_config", 5); err == nil {
+		t.Error("expected BlockFreeSQL to block RunSQLQuery")
+	}
+}
+
+func nodeStructureResponse(nodes ...[2]string) string {
+	var sb strings.Builder
+	sb.WriteString(`<?xml version="1.0" encoding="utf-8"?><asx:abap xmlns:asx="http://www.sap.com/abapxml" version="1.0"><asx:values><DATA><TREE_CONTENT>`)
+	for _, n := range nodes {
+		sb.WriteString(`<SEU_ADT_REPOSITORY_OBJ_NODE><OBJECT_TYPE>` + n[0] + `</OBJECT_TYPE><OBJECT_NAME>` + n[1] + `</OBJECT_NAME><OBJECT_URI>/sap/bc/adt/x/` + strings.ToLower(n[1]) + `</OBJECT_URI></SEU_ADT_REPOSITORY_OBJ_NODE>`)
+	}
+	sb.WriteString(`</TREE_CONTENT></DATA></asx:values></asx:abap>`)
+	return sb.String()
+}
+
+func TestClient_GetPackageRecursive(t *testing.T) {
+	tree := map[string]string{
+		"$ZDEMO": nodeStructureResponse(
+			[2]string{"DEVC/K", "$ZDEMO_CORE"},
+			[2]string{"DEVC/K", "$ZDEMO_UI"},
+			[2]string{"PROG/P", "ZDEMO_MAIN"},
+		),
+		"$ZDEMO_CORE": nodeStructureResponse(
+			[2]string{"DEVC/K", "$ZDEMO_CORE_DB"},
+			[2]string{"CLAS/OC", "ZCL_DEMO_ORDER"},
+		),
+		"$ZDEMO_UI": nodeStructureResponse(
+			[2]string{"INTF/OI", "ZIF_DEMO_VIEW"},
+			[2]string{"DEVC/K", "$ZDEMO"}, // cycle back to the root
+		),
+		"$ZDEMO_CORE_DB": nodeStructureResponse(
+			[2]string{"TABL/DT", "ZDEMO_ORDERS"},
+		),
+	}
+	var read []string
+	mock := &funcMockClient{doFunc: func(req *http.Request) (*http.Response, error) {
+		if strings.Contains(req.URL.Path, "discovery") {
+			r := newTestResponse("ok")
+			r.Header.Set("X-CSRF-Token", "test-token")
+			return r, nil
+		}
+		name := req.URL.Query().Get("parent_name")
+		read = append(read, name)
+		return newTestResponse(tree[name]), nil
+	}}
+	cfg := NewConfig("https://sap.example.com:44300", "user", "pass")
+	client := NewClientWithTransport(cfg, NewTransportWithClient(cfg, mock))
+	ctx := context.Background()
+
+	content, err := client.GetPackageRecursive(ctx, "$zdemo", 0)
+	if err != nil {
+		t.Fatalf("GetPackageRecursive failed: %v", err)
+	}
+	if got := strings.Join(read, ","); got != "$ZDEMO,$ZDEMO_CORE,$ZDEMO_UI,$ZDEMO_CORE_DB" {
+		t.Errorf("packages read = %s (expected breadth-first, each once)", got)
+	}
+	var objects []string
+	for _, obj := range content.Objects {
+		objects = append(objects, obj.Package+":"+obj.Name)
+	}
+	want := "$ZDEMO:ZDEMO_MAIN,$ZDEMO_CORE:ZCL_DEMO_ORDER,$ZDEMO_UI:ZIF_DEMO_VIEW,$ZDEMO_CORE_DB:ZDEMO_ORDERS"
+	if got := strings.Join(objects, ","); got != want {
+		t.Errorf("objects = %s, want %s", got, want)
+	}
+	if len(content.SubPackages) != 3 {
+		t.Errorf("expected 3 subpackages, got %v", content.SubPackages)
+	}
+
+	read = nil
+	shallow, err := client.GetPackageRecursive(ctx, "$ZDEMO", 1)
+	if err != nil {
+		t.Fatalf("GetPackageRecursive with depth 1 failed: %v", err)
+	}
+	if len(read) != 3 || len(shallow.Objects) != 3 {
+		t.Errorf("depth 1 should stop above $ZDEMO_CORE_DB, read %v", read)
+	}
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	if _, err := client.GetPackageRecursive(cancelled, "$ZDEMO", 0); err == nil {
+		t.Error("expected a cancelled context to stop the walk")
+	}
+}
+
+const classMetadataXML = `<?xml version="1.0" encoding="utf-8"?>
+<class:abapClass xmlns:class="http://www.sap.com/adt/oo/classes" xmlns:adtcore="http://www.sap.com/adt/core" xmlns:atom="http://www.w3.org/2005/Atom"
+  adtcore:name="ZCL_DEMO_ORDER" adtcore:type="CLAS/OC" adtcore:description="Demo order"
+  adtcore:responsible="TESTUSER" adtcore:masterLanguage="en"
+  adtcore:createdAt="2024-01-15T00:00:00Z" adtcore:changedAt="2024-03-01T10:20:30Z" adtcore:changedBy="TESTUSER">
+  <atom:link href="/sap/bc/adt/oo/classes/zcl_demo_order/source/main" rel="http://www.sap.com/adt/relations/source"/>
+  <atom:link href="/sap/bc/adt/cts/transportrequests/tr-example" rel="http://www.sap.com/adt/relations/transport/request"/>
+  <adtcore:packageRef adtcore:name="$ZDEMO" adtcore:type="DEVC/K" adtcore:uri="/sap/bc/adt/packages/%24zdemo"/>
+</class:abapClass>`
+
+func TestParseObjectMetadata(t *testing.T) {
+	meta, err := parseObjectMetadata([]byte(classMetadataXML))
+	if err != nil {
+		t.Fatalf("parseObjectMetadata failed: %v", err)
+	}
+	want := ObjectMetadata{
+		Name:             "ZCL_DEMO_ORDER",
+		Type:             "CLAS/OC",
+		Description:      "Demo order",
+		Package:          "$ZDEMO",
+		Responsible:      "TESTUSER",
+		MasterLanguage:   "EN",
+		CreatedAt:        "2024-01-15T00:00:00Z",
+		ChangedAt:        "2024-03-01T10:20:30Z",
+		ChangedBy:        "TESTUSER",
+		TransportRequest: "TR-EXAMPLE",
+	}
+	if *meta != want {
+		t.Errorf("metadata = %+v, want %+v", *meta, want)
+	}
+
+	if _, err := parseObjectMetadata([]byte(`<empty/>`)); err == nil {
+		t.Error("expected an error for metadata without an object name")
+	}
+}
+
+func TestClient_GetObjectMetadata(t *testing.T) {
+	mock := &methodPathMock{routes: []routedResponse{
+		resp("GET", "/sap/bc/adt/oo/classes/ZCL_DEMO_ORDER", 200, classMetadataXML),
+	}}
+	cfg := NewConfig("https://sap.example.com:44300", "user", "pass", WithPackageCache(8))
+	client := NewClientWithTransport(cfg, NewTransportWithClient(cfg, mock))
+
+	meta, err := client.GetObjectMetadata(context.Background(), "CLAS/OC", "zcl_demo_order")
+	if err != nil {
+		t.Fatalf("GetObjectMetadata failed: %v", err)
+	}
+	if meta.Package != "$ZDEMO" || meta.Responsible != "TESTUSER" {
+		t.Errorf("unexpected metadata: %+v", meta)
+	}
+	if pkg, ok := client.packages.get("/sap/bc/adt/oo/classes/ZCL_DEMO_ORDER"); !ok || pkg != "$ZDEMO" {
+		t.Errorf("expected the package to be cached, got %q", pkg)
+	}
+
+	if _, err := client.GetObjectMetadata(context.Background(), "FUNC", "Z_DEMO"); err == nil {
+		t.Error("expected function modules to be rejected")
+	}
+}
+
+func TestClient_GetClassIncludes(t *testing.T) {
+	mock := &methodPathMock{routes: []routedResponse{
+		resp("GET", "/ZCL_DEMO_ORDER/source/main", 200, "CLASS zcl_demo_order DEFINITION PUBLIC."),
+		resp("GET", "/ZCL_DEMO_ORDER/includes/definitions", 200, "CLASS lcl_helper DEFINITION."),
+		resp("GET", "/ZCL_DEMO_ORDER/includes/testclasses", 200, "CLASS ltcl_order DEFINITION FOR TESTING."),
+	}}
+	cfg := NewConfig("https://sap.example.com:44300", "user", "pass")
+	client := NewClientWithTransport(cfg, NewTransportWithClient(cfg, mock))
+
+	sources, err := client.GetClassIncludes(context.Background(), "zcl_demo_order")
+	if err != nil {
+		t.Fatalf("GetClassIncludes failed: %v", err)
+	}
+	if len(sources) != 3 {
+		t.Errorf("expected 3 includes, got %v", sources)
+	}
+	for part, prefix := range map[string]string{
+		"main":        "CLASS zcl_demo_order",
+		"definitions": "CLASS lcl_helper",
+		"testclasses": "CLASS ltcl_order",
+	} {
+		if !strings.HasPrefix(sources[part], prefix) {
+			t.Errorf("%s include = %q", part, sources[part])
+		}
+	}
+	if _, ok := sources["macros"]; ok {
+		t.Error("missing includes should be skipped")
+	}
+
+	if _, err := client.GetClassIncludes(context.Background(), "ZCL_DEMO_MISSING"); err == nil {
+		t.Error("expected an error when the main include is missing")
+	}
+}
+
+const systemInformationXML = `<?xml version="1.0" encoding="utf-8"?>
+<atom:feed xmlns:atom="http://www.w3.org/2005/Atom">
+  <atom:title>System Information</atom:title>
+  <atom:entry><atom:id>systemID</atom:id><atom:title>DEV</atom:title></atom:entry>
+  <atom:entry><atom:id>client</atom:id><atom:title>001</atom:title></atom:entry>
+  <atom:entry><atom:id>release</atom:id><atom:title>757</atom:title></atom:entry>
+  <atom:entry><atom:id>supportPackageLevel</atom:id><atom:title>0002</atom:title></atom:entry>
+  <atom:entry><atom:id>kernelRelease</atom:id><atom:title>789</atom:title></atom:entry>
+  <atom:entry><atom:id>dbSystem</atom:id><atom:title>HDB</atom:title></atom:entry>
+  <atom:entry><atom:id>dbRelease</atom:id><atom:title>2.00.070</atom:title></atom:entry>
+  <atom:entry><atom:id>applicationServerName</atom:id><atom:title>dev.example.local</atom:title></atom:entry>
+  <atom:entry><atom:id>abapPlatform</atom:id><atom:title>ON_PREMISE</atom:title></atom:entry>
+  <atom:entry><atom:id>isUnicodeSystem</atom:id><atom:title>true</atom:title></atom:entry>
+</atom:feed>`
+
+func TestParseSystemInformation(t *testing.T) {
+	info, err := parseSystemInformation([]byte(systemInformationXML))
+	if err != nil {
+		t.Fatalf("parseSystemInformation failed: %v", err)
+	}
+	want := SystemInfo{
+		SystemID:            "DEV",
+		Client:              "001",
+		SAPRelease:          "757",
+		ABAPRelease:         "757",
+		SupportPackageLevel: "0002",
+		KernelRelease:       "789",
+		DatabaseSystem:      "HDB",
+		DatabaseRelease:     "2.00.070",
+		HostName:            "dev.example.local",
+		ABAPPlatform:        "ON_PREMISE",
+		UnicodeSystem:       true,
+	}
+	if *info != want {
+		t.Errorf("parseSystemInformation = %+v, want %+v", *info, want)
+	}
+
+	if _, err := parseSystemInformation([]byte(`<atom:feed xmlns:atom="http://www.w3.org/2005/Atom"/>`)); err == nil {
+		t.Error("expected an error for a feed without entries")
+	}
+}
+
+func TestClient_GetSystemInfo_FromSystemInformation(t *testing.T) {
+	// Free-style SQL is not routed (404), so everything must come from the
+	// system information resource.
+	mock := &methodPathMock{routes: []routedResponse{
+		resp("GET", "/sap/bc/adt/system/information", 200, systemInformationXML),
+	}}
+	cfg := NewConfig("https://sap.example.com:44300", "user", "pass")
+	client := NewClientWithTransport(cfg, NewTransportWithClient(cfg, mock))
+
+	info, err := client.GetSystemInfo(context.Background())
+	if err != nil {
+		t.Fatalf("GetSystemInfo failed: %v", err)
+	}
+	if info.SystemID != "DEV" || info.SAPRelease != "757" || info.SupportPackageLevel != "0002" || !info.UnicodeSystem {
+		t.Errorf("unexpected system info: %+v", info)
+	}
+}
+
+func TestFlattenCallGraph_Cycle(t *testing.T) {
+	// A -> B -> A -> B as returned for mutually recursive methods, plus A -> C.
+	root := &CallGraphNode{URI: "/a", Name: "A", Type: "METH", Children: []CallGraphNode{
+		{URI: "/b", Name: "B", Type: "METH", Line: 10, Children: []CallGraphNode{
+			{URI: "/a", Name: "A", Type: "METH", Line: 20, Children: []CallGraphNode{
+				{URI: "/b", Name: "B", Type: "METH", Line: 10},
+			}},
+		}},
+		{URI: "/c", Name: "C", Type: "FUNC", Line: 12},
+	}}
+
+	edges := FlattenCallGraph(root)
+	if len(edges) != 3 {
+		t.Fatalf("expected 3 edges, got %d: %+v", len(edges), edges)
+	}
+	var back []CallGraphEdge
+	for _, e := range edges {
+		if e.IsBackEdge {
+			back = append(back, e)
+		}
+	}
+	if len(back) != 1 || back[0].CallerName != "B" || back[0].CalleeName != "A" {
+		t.Errorf("expected a single B->A back edge, got %+v", back)
+	}
+
+	stats := AnalyzeCallGraph(root)
+	if !stats.HasCycles {
+		t.Error("expected HasCycles")
+	}
+	if stats.TotalEdges != 3 || stats.TotalNodes != 3 || stats.MaxDepth != 1 {
+		t.Errorf("unexpected stats: %+v", stats)
+	}
+
+	acyclic := AnalyzeCallGraph(&CallGraphNode{URI: "/a", Name: "A", Children: []CallGraphNode{{URI: "/c", Name: "C"}}})
+	if acyclic.HasCycles || acyclic.TotalEdges != 1 {
+		t.Errorf("unexpected stats for acyclic graph: %+v", acyclic)
+	}
+}
+
+func TestExtractCallEdgesFromTrace_NestedPerforms(t *testing.T) {
+	entries := []TraceEntry{
+		{Program: "ZDEMO_REPORT", Event: "START-OF-SELECTION", Line: 5},
+		{Program: "ZDEMO_REPORT", Event: "PERFORM LOAD_DATA", Line: 6},
+		{Program: "ZDEMO_REPORT", Event: "PERFORM READ_ORDERS", Line: 20},
+		{Program: "ZDEMO_REPORT", Event: "CALL FUNCTION 'Z_DEMO_GET_ORDERS'", Line: 31},
+		{Program: "ZDEMO_FUGR", Event: "ENDFUNCTION", Line: 40},
+		{Program: "ZDEMO_REPORT", Event: "ENDFORM", Line: 33},
+		{Program: "ZDEMO_REPORT", Event: "ENDFORM", Line: 22},
+		{Program: "ZDEMO_REPORT", Event: "PERFORM SHOW", Line: 7},
+		{Program: "ZDEMO_REPORT", Event: "CALL METHOD ZCL_DEMO_ORDER=>DISPLAY", Line: 50},
+		{Program: "ZCL_DEMO_ORDER=====CP", Event: "ENDMETHOD", Line: 12},
+		{Program: "ZDEMO_REPORT", Event: "ENDFORM", Line: 51},
+		// A second run of LOAD_DATA must not duplicate its edge.
+		{Program: "ZDEMO_REPORT", Event: "PERFORM LOAD_DATA", Line: 8},
+		{Program: "ZDEMO_REPORT", Event: "ENDFORM", Line: 22},
+	}
+
+	edges := ExtractCallEdgesFromTrace(entries)
+
+	want := []string{
+		"ZDEMO_REPORT->LOAD_DATA",
+		"LOAD_DATA->READ_ORDERS",
+		"READ_ORDERS->Z_DEMO_GET_ORDERS",
+		"ZDEMO_REPORT->SHOW",
+		"SHOW->ZCL_DEMO_ORDER=>DISPLAY",
+	}
+	var got []string
+	for _, e := range edges {
+		got = append(got, e.CallerName+"->"+e.CalleeName)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("edges = %v, want %v", got, want)
+	}
+
+	if edges[0].CallerURI != "/sap/bc/adt/programs/programs/zdemo_report" || edges[0].Line != 6 {
+		t.Errorf("unexpected root edge: %+v", edges[0])
+	}
+	if edges[4].CalleeURI != "/sap/bc/adt/oo/classes/zcl_demo_order" {
+		t.Errorf("unexpected method URI: %s", edges[4].CalleeURI)
+	}
+}
+
+func TestExportComparisonMermaid(t *testing.T) {
+	comp := CompareCallGraphs(
+		[]CallGraphEdge{
+			{CallerName: "ZDEMO_REPORT", CalleeName: "LOAD_DATA"},
+			{CallerName: "ZDEMO_REPORT", CalleeName: "SHOW_ERROR"},
+		},
+		[]CallGraphEdge{
+			{CallerName: "ZDEMO_REPORT", CalleeName: "LOAD_DATA"},
+			{CallerName: "LOAD_DATA", CalleeName: "ZCL_DEMO_ORDER=>READ"},
+		},
+	)
+
+	got := ExportComparisonMermaid(comp)
+
+	for _, want := range []string{
+		"graph LR\n",
+		`N0["ZDEMO_REPORT"]`,
+		`N1["LOAD_DATA"]`,
+		"N0 --> N1\n",
+		`N2["SHOW_ERROR"]`,
+		"N0 -.-> N2\n",
+		`N3["ZCL_DEMO_ORDER=>READ"]`,
+		"N1 -. dynamic .-> N3\n",
+		"linkStyle 0 stroke:#16a34a,stroke-width:2px\n",
+		"linkStyle 1 stroke:#9ca3af,stroke-dasharray:6 4\n",
+		"linkStyle 2 stroke:#dc2626,stroke-width:2px,stroke-dasharray:2 2\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("missing %q in:\n%s", want, got)
+		}
+	}
+
+	if ExportComparisonMermaid(nil) != "graph LR\n" {
+		t.Error("expected an empty diagram for a nil comparison")
+	}
+}
+
+func TestFilterTraceEntries(t *testing.T) {
+	entries := []TraceEntry{
+		{Program: "ZDEMO_REPORT", Event: "PERFORM LOAD_DATA", GrossTime: 1200},
+		{Program: "ZCL_DEMO_ORDER=====CP", Event: "CALL METHOD ZCL_DEMO_ORDER=>READ", GrossTime: 9000},
+		{Program: "SAPLSDTX", Event: "CALL FUNCTION 'SDTX_GET'", GrossTime: 300},
+		{Program: "ZCL_DEMO_ITEM=====CP", Event: "CALL METHOD ZCL_DEMO_ITEM=>READ", GrossTime: 4000},
+	}
+	programs := func(es []TraceEntry) []string {
+		var out []string
+		for _, e := range es {
+			out = append(out, e.Program)
+		}
+		return out
+	}
+
+	tests := []struct {
+		name   string
+		filter TraceFilter
+		want   []string
+	}{
+		{"no filter", TraceFilter{}, []string{"ZDEMO_REPORT", "ZCL_DEMO_ORDER=====CP", "SAPLSDTX", "ZCL_DEMO_ITEM=====CP"}},
+		{"min gross time", TraceFilter{MinGrossTimeUs: 1200}, []string{"ZDEMO_REPORT", "ZCL_DEMO_ORDER=====CP", "ZCL_DEMO_ITEM=====CP"}},
+		{"prefix pattern", TraceFilter{ProgramPattern: "zcl_demo*"}, []string{"ZCL_DEMO_ORDER=====CP", "ZCL_DEMO_ITEM=====CP"}},
+		{"exact pattern", TraceFilter{ProgramPattern: "ZDEMO_REPORT"}, []string{"ZDEMO_REPORT"}},
+		{"top n", TraceFilter{TopN: 2}, []string{"ZCL_DEMO_ORDER=====CP", "ZCL_DEMO_ITEM=====CP"}},
+		{"combined", TraceFilter{MinGrossTimeUs: 1000, ProgramPattern: "Z*", TopN: 1}, []string{"ZCL_DEMO_ORDER=====CP"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := programs(FilterTraceEntries(entries, tt.filter))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAggregateTrace(t *testing.T) {
+	entries := []TraceEntry{
+		{Program: "ZCL_DEMO_ORDER=====CP", Event: "READ", GrossTime: 500, NetTime: 200, Calls: 2},
+		{Program: "ZDEMO_REPORT", Event: "LOAD_DATA", GrossTime: 900, NetTime: 100},
+		{Program: "ZCL_DEMO_ORDER=====CP", Event: "READ", GrossTime: 700, NetTime: 300, Calls: 3},
+		{Program: "ZCL_DEMO_ORDER=====CP", Event: "SAVE", GrossTime: 900, NetTime: 900, Calls: 1},
+		{Program: "ZDEMO_REPORT", Event: "LOAD_DATA", GrossTime: 100, NetTime: 50},
+	}
+
+	got := AggregateTrace(entries)
+	want := []TraceAggregate{
+		{Program: "ZCL_DEMO_ORDER=====CP", Event: "READ", GrossTime: 1200, NetTime: 500, Calls: 5},
+		{Program: "ZDEMO_REPORT", Event: "LOAD_DATA", GrossTime: 1000, NetTime: 150, Calls: 2},
+		{Program: "ZCL_DEMO_ORDER=====CP", Event: "SAVE", GrossTime: 900, NetTime: 900, Calls: 1},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("AggregateTrace =\n%+v\nwant\n%+v", got, want)
+	}
+
+	if AggregateTrace(nil) != nil {
+		t.Error("expected nil for no entries")
+	}
+}
+
+func TestClient_StartStopTrace(t *testing.T) {
+	type call struct {
+		method string
+		path   string
+		query  url.Values
+		body   string
+	}
+	var calls []call
+	mock := &funcMockClient{doFunc: func(req *http.Request) (*http.Response, error) {
+		if strings.Contains(req.URL.Path, "discovery") {
+			r := newTestResponse("ok")
+			r.Header.Set("X-CSRF-Token", "test-token")
+			return r, nil
+		}
+		var body string
+		if req.Body != nil {
+			data, _ := io.ReadAll(req.Body)
+			body = string(data)
+		}
+		calls = append(calls, call{req.Method, req.URL.Path, req.URL.Query(), body})
+		switch {
+		case strings.HasSuffix(req.URL.Path, "/abaptraces/parameters"):
+			r := newTestResponse("")
+			r.Header.Set("Location", "/sap/bc/adt/runtime/traces/abaptraces/parameters/PARAMS1")
+			return r, nil
+		case req.Method == http.MethodPost && strings.HasSuffix(req.URL.Path, "/abaptraces/requests"):
+			return newTestResponse(`<atom:feed xmlns:atom="http://www.w3.org/2005/Atom">
+  <atom:entry>
+    <atom:id>REQ0001</atom:id>
+    <atom:title>unit test trace</atom:title>
+    <atom:link href="/sap/bc/adt/runtime/traces/abaptraces/requests/REQ0001"/>
+  </atom:entry>
+</atom:feed>`), nil
+		}
+		return newTestResponse(""), nil
+	}}
+	cfg := NewConfig("https://sap.example.com:44300", "testuser", "pass", WithClient("001"))
+	client := NewClientWithTransport(cfg, NewTransportWithClient(cfg, mock))
+	ctx := context.Background()
+
+	req, err := client.StartTrace(ctx, StartTraceOptions{Description: "unit test trace", ProcessType: "HTTP"})
+	if err != nil {
+		t.Fatalf("StartTrace failed: %v", err)
+	}
+	if req.ID != "REQ0001" || req.User != "TESTUSER" {
+		t.Errorf("unexpected trace request: %+v", req)
+	}
+	if len(calls) != 2 {
+		t.Fatalf("expected parameters and request calls, got %+v", calls)
+	}
+
+	params := calls[0]
+	if params.method != http.MethodPost {
+		t.Errorf("parameters method = %s", params.method)
+	}
+	for _, want := range []string{
+		`<trc:allProceduralUnits value="true"/>`,
+		`<trc:aggregate value="false"/>`,
+		`<trc:description value="unit test trace"/>`,
+	} {
+		if !strings.Contains(params.body, want) {
+			t.Errorf("parameters body missing %s:\n%s", want, params.body)
+		}
+	}
+
+	create := calls[1].query
+	if create.Get("traceUser") != "TESTUSER" || create.Get("traceClient") != "001" ||
+		create.Get("processType") != "HTTP" || create.Get("objectType") != "ANY" ||
+		create.Get("maximalExecutions") != "1" || create.Get("description") != "unit test trace" ||
+		create.Get("parametersId") != "/sap/bc/adt/runtime/traces/abaptraces/parameters/PARAMS1" {
+		t.Errorf("unexpected request query: %v", create)
+	}
+	if _, err := time.Parse(time.RFC3339, create.Get("expires")); err != nil {
+		t.Errorf("expires is not RFC3339: %q", create.Get("expires"))
+	}
+
+	if err := client.StopTrace(ctx, req.ID); err != nil {
+		t.Fatalf("StopTrace failed: %v", err)
+	}
+	stop := calls[len(calls)-1]
+	if stop.method != http.MethodDelete || stop.path != "/sap/bc/adt/runtime/traces/abaptraces/requests/REQ0001" {
+		t.Errorf("unexpected stop call: %s %s", stop.method, stop.path)
+	}
+}
+
+func TestClient_StartStopTrace_ReadOnly(t *testing.T) {
+	mock := &funcMockClient{doFunc: func(req *http.Request) (*http.Response, error) {
+		t.Errorf("unexpected request in read-only mode: %s %s", req.Method, req.URL.Path)
+		return newTestResponse(""), nil
+	}}
+	cfg := NewConfig("https://sap.example.com:44300", "testuser", "pass", WithReadOnly())
+	client := NewClientWithTransport(cfg, NewTransportWithClient(cfg, mock))
+	ctx := context.Background()
+
+	if _, err := client.StartTrace(ctx, StartTraceOptions{}); err == nil {
+		t.Error("StartTrace should be blocked in read-only mode")
+	}
+	if err := client.StopTrace(ctx, "REQ0001"); err == nil {
+		t.Error("StopTrace should be blocked in read-only mode")
+	}
+}
@@ -102,6 +102,7 @@ type UsageReference struct {
 	Description      string `json:"description"`
 	PackageURI       string `json:"packageUri"`
 	PackageName      string `json:"packageName"`
+	Line             int    `json:"line,omitempty"` // From a #start= fragment in URI, if any
 }
 
 // FindReferences finds all references to a symbol.
@@ -189,6 +190,9 @@ func parseUsageReferences(data []byte) ([]UsageReference, error) {
 			PackageURI:       obj.AdtObject.PackageRef.URI,
 			PackageName:      obj.AdtObject.PackageRef.Name,
 		}
+		if m := usagePositionRegex.FindStringSubmatch(ref.URI); m != nil {
+			ref.Line, _ = strconv.Atoi(m[1])
+		}
 
 		// If type is not in the adtObject, try to extract from URI
 		if ref.Type == "" && ref.URI != "" {
@@ -201,6 +205,53 @@ func parseUsageReferences(data []byte) ([]UsageReference, error) {
 	return results, nil
 }
 
+// usagePositionRegex extracts the line of a reference URI fragment.
+var usagePositionRegex = regexp.MustCompile(`#start=(\d+)`)
+
+// WhereUsedOptions filters GetWhereUsed results.
+type WhereUsedOptions struct {
+	// UsageKinds keeps only references whose usage information contains
+	// one of these kinds (case-insensitive), e.g. "gradeDirect" for direct
+	// usages. Empty keeps all references.
+	UsageKinds []string
+}
+
+// GetWhereUsed lists the objects that use the given object.
+// Unlike FindReferences, only actual usages are returned; the package and
+// parent nodes of the result tree are dropped.
+func (c *Client) GetWhereUsed(ctx context.Context, objectURI string, opts *WhereUsedOptions) ([]UsageReference, error) {
+	refs, err := c.FindReferences(ctx, objectURI, 0, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	results := []UsageReference{}
+	for _, ref := range refs {
+		if !ref.IsResult {
+			continue
+		}
+		if opts != nil && len(opts.UsageKinds) > 0 && !hasUsageKind(ref.UsageInformation, opts.UsageKinds) {
+			continue
+		}
+		results = append(results, ref)
+	}
+	return results, nil
+}
+
+// hasUsageKind reports whether the comma-separated usage information
+// contains one of kinds.
+func hasUsageKind(usage string, kinds []string) bool {
+	for _, part := range strings.Split(usage, ",") {
+		part = strings.TrimSpace(part)
+		for _, kind := range kinds {
+			if strings.EqualFold(part, kind) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // extractTypeFromURI tries to extract the object type from ADT URI patterns
 func extractTypeFromURI(uri string) string {
 	// Common patterns: /sap/bc/adt/oo/classes/..., /sap/bc/adt/programs/programs/...
@@ -633,3 +684,121 @@ func parseTypeHierarchy(data []byte) ([]HierarchyNode, error) {
 
 	return results, nil
 }
+
+// --- Type Hierarchy Tree ---
+
+// TypeHierarchyNode is a class or interface in a type hierarchy tree.
+// For direction "sub" the children of a node are its subtypes; for "super"
+// they are its supertypes (superclass and implemented interfaces).
+type TypeHierarchyNode struct {
+	URI         string              `json:"uri"`
+	Name        string              `json:"name"`
+	Type        string              `json:"type"`
+	Description string              `json:"description,omitempty"`
+	Children    []TypeHierarchyNode `json:"children,omitempty"`
+}
+
+// GetTypeHierarchyTree retrieves the super- or subtype tree of a class or
+// interface. direction is "super" or "sub". Unlike GetTypeHierarchy, which
+// resolves the symbol at a source position and returns a flat list, this
+// works on the object URI and keeps the tree shape.
+func (c *Client) GetTypeHierarchyTree(ctx context.Context, objectURI string, direction string) (*TypeHierarchyNode, error) {
+	direction = strings.ToLower(direction)
+	if direction != "super" && direction != "sub" {
+		return nil, fmt.Errorf("invalid type hierarchy direction %q: use super or sub", direction)
+	}
+
+	params := url.Values{}
+	params.Set("direction", direction)
+
+	body := fmt.Sprintf(`<?xml version="1.0" encoding="UTF-8"?>
+<typehierarchy:typeHierarchyRequest xmlns:typehierarchy="http://www.sap.com/adt/oo/typehierarchy">
+  <typehierarchy:objectUri>%s</typehierarchy:objectUri>
+</typehierarchy:typeHierarchyRequest>`, xmlEscape(objectURI))
+
+	resp, err := c.transport.Request(ctx, "/sap/bc/adt/oo/typehierarchy", &RequestOptions{
+		Method:      http.MethodPost,
+		Query:       params,
+		Accept:      "application/xml",
+		ContentType: "application/xml",
+		Body:        []byte(body),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("getting type hierarchy: %w", err)
+	}
+
+	return parseTypeHierarchyTree(resp.Body)
+}
+
+// typeHierarchyNodeXML is used for parsing type hierarchy XML responses.
+type typeHierarchyNodeXML struct {
+	URI         string                 `xml:"uri,attr"`
+	Name        string                 `xml:"name,attr"`
+	Type        string                 `xml:"type,attr"`
+	Description string                 `xml:"description,attr"`
+	Children    []typeHierarchyNodeXML `xml:"node"`
+}
+
+// parseTypeHierarchyTree parses the type hierarchy XML response.
+func parseTypeHierarchyTree(data []byte) (*TypeHierarchyNode, error) {
+	type typeHierarchyXML struct {
+		XMLName xml.Name             `xml:"typeHierarchy"`
+		Root    typeHierarchyNodeXML `xml:"node"`
+	}
+
+	var th typeHierarchyXML
+	if err := xml.Unmarshal(data, &th); err != nil {
+		return nil, fmt.Errorf("parsing type hierarchy: %w", err)
+	}
+
+	return convertTypeHierarchyNode(&th.Root), nil
+}
+
+func convertTypeHierarchyNode(n *typeHierarchyNodeXML) *TypeHierarchyNode {
+	node := &TypeHierarchyNode{
+		URI:         n.URI,
+		Name:        n.Name,
+		Type:        n.Type,
+		Description: n.Description,
+	}
+	for i := range n.Children {
+		node.Children = append(node.Children, *convertTypeHierarchyNode(&n.Children[i]))
+	}
+	return node
+}
+
+// TypeHierarchyEdge is a single parent → child link of a type hierarchy
+// tree. Whether the child is a sub- or supertype depends on the direction
+// the tree was requested in.
+type TypeHierarchyEdge struct {
+	ParentURI  string `json:"parent_uri"`
+	ParentName string `json:"parent_name"`
+	ChildURI   string `json:"child_uri"`
+	ChildName  string `json:"child_name"`
+	ChildType  string `json:"child_type"`
+}
+
+// FlattenTypeHierarchy converts a type hierarchy tree to a flat list of edges.
+func FlattenTypeHierarchy(root *TypeHierarchyNode) []TypeHierarchyEdge {
+	var edges []TypeHierarchyEdge
+	if root == nil {
+		return edges
+	}
+
+	var traverse func(parent *TypeHierarchyNode)
+	traverse = func(parent *TypeHierarchyNode) {
+		for i := range parent.Children {
+			child := &parent.Children[i]
+			edges = append(edges, TypeHierarchyEdge{
+				ParentURI:  parent.URI,
+				ParentName: parent.Name,
+				ChildURI:   child.URI,
+				ChildName:  child.Name,
+				ChildType:  child.Type,
+			})
+			traverse(child)
+		}
+	}
+	traverse(root)
+	return edges
+}
@@ -1,6 +1,9 @@
 package adt
 
 import (
+	"context"
+	"net/http"
+	"strings"
 	"testing"
 )
 
@@ -156,3 +159,146 @@ func TestParseClassComponentsNested(t *testing.T) {
 		t.Errorf("expected local type name 'LT_LOCAL', got '%s'", localType.Name)
 	}
 }
+
+const whereUsedFixture = `<?xml version="1.0" encoding="utf-8"?>
+<usageReferences:usageReferenceResult xmlns:usageReferences="http://www.sap.com/adt/ris/usageReferences" xmlns:adtcore="http://www.sap.com/adt/core" numberOfResults="2" resultDescription="Where-used list for ZCL_DEMO_ORDER">
+  <usageReferences:referencedObjects>
+    <usageReferences:referencedObject uri="/sap/bc/adt/packages/%24zdemo" isResult="false" canHaveChildren="true">
+      <usageReferences:adtObject adtcore:name="$ZDEMO" adtcore:type="DEVC/K"/>
+    </usageReferences:referencedObject>
+    <usageReferences:referencedObject uri="/sap/bc/adt/programs/programs/zdemo_report/source/main#start=42,8" parentUri="/sap/bc/adt/packages/%24zdemo" isResult="true" canHaveChildren="false" usageInformation="gradeDirect,includeProductive">
+      <usageReferences:adtObject adtcore:name="ZDEMO_REPORT" adtcore:type="PROG/P" adtcore:responsible="TESTUSER" adtcore:description="Demo report">
+        <adtcore:packageRef adtcore:uri="/sap/bc/adt/packages/%24zdemo" adtcore:name="$ZDEMO"/>
+      </usageReferences:adtObject>
+    </usageReferences:referencedObject>
+    <usageReferences:referencedObject uri="/sap/bc/adt/oo/classes/zcl_demo_invoice/source/main#start=17,4" parentUri="/sap/bc/adt/packages/%24zdemo" isResult="true" canHaveChildren="false" usageInformation="gradeIndirect,includeTest">
+      <usageReferences:adtObject adtcore:name="ZCL_DEMO_INVOICE" adtcore:type="CLAS/OC" adtcore:responsible="TESTUSER">
+        <adtcore:packageRef adtcore:uri="/sap/bc/adt/packages/%24zdemo" adtcore:name="$ZDEMO"/>
+      </usageReferences:adtObject>
+    </usageReferences:referencedObject>
+  </usageReferences:referencedObjects>
+</usageReferences:usageReferenceResult>`
+
+func TestGetWhereUsed(t *testing.T) {
+	mock := &methodPathMock{routes: []routedResponse{
+		resp("", "discovery", http.StatusOK, "ok"),
+		resp(http.MethodPost, "/informationsystem/usageReferences", http.StatusOK, whereUsedFixture),
+	}}
+	cfg := NewConfig("https://sap.example.com:44300", "user", "pass")
+	client := NewClientWithTransport(cfg, NewTransportWithClient(cfg, mock))
+	ctx := context.Background()
+
+	refs, err := client.GetWhereUsed(ctx, "/sap/bc/adt/oo/classes/zcl_demo_order", nil)
+	if err != nil {
+		t.Fatalf("GetWhereUsed failed: %v", err)
+	}
+	if len(refs) != 2 {
+		t.Fatalf("expected 2 usages (package node dropped), got %d: %+v", len(refs), refs)
+	}
+
+	prog, class := refs[0], refs[1]
+	if prog.Name != "ZDEMO_REPORT" || prog.Type != "PROG/P" || prog.Line != 42 || prog.PackageName != "$ZDEMO" {
+		t.Errorf("unexpected program reference: %+v", prog)
+	}
+	if class.Name != "ZCL_DEMO_INVOICE" || class.Type != "CLAS/OC" || class.Line != 17 {
+		t.Errorf("unexpected class reference: %+v", class)
+	}
+	if prog.UsageInformation != "gradeDirect,includeProductive" {
+		t.Errorf("usage = %q", prog.UsageInformation)
+	}
+
+	direct, err := client.GetWhereUsed(ctx, "/sap/bc/adt/oo/classes/zcl_demo_order", &WhereUsedOptions{UsageKinds: []string{"GRADEDIRECT"}})
+	if err != nil {
+		t.Fatalf("GetWhereUsed with filter failed: %v", err)
+	}
+	if len(direct) != 1 || direct[0].Name != "ZDEMO_REPORT" {
+		t.Errorf("expected only the direct usage, got %+v", direct)
+	}
+}
+
+func TestGetTypeHierarchyTree(t *testing.T) {
+	subTypes := `<?xml version="1.0" encoding="UTF-8"?>
+<typeHierarchy>
+  <node uri="/sap/bc/adt/oo/classes/zcl_demo_base" name="ZCL_DEMO_BASE" type="CLAS/OC" description="Base document">
+    <node uri="/sap/bc/adt/oo/classes/zcl_demo_order" name="ZCL_DEMO_ORDER" type="CLAS/OC">
+      <node uri="/sap/bc/adt/oo/classes/zcl_demo_rush_order" name="ZCL_DEMO_RUSH_ORDER" type="CLAS/OC"/>
+    </node>
+    <node uri="/sap/bc/adt/oo/classes/zcl_demo_invoice" name="ZCL_DEMO_INVOICE" type="CLAS/OC"/>
+  </node>
+</typeHierarchy>`
+	superTypes := `<?xml version="1.0" encoding="UTF-8"?>
+<typeHierarchy>
+  <node uri="/sap/bc/adt/oo/classes/zcl_demo_order" name="ZCL_DEMO_ORDER" type="CLAS/OC">
+    <node uri="/sap/bc/adt/oo/classes/zcl_demo_base" name="ZCL_DEMO_BASE" type="CLAS/OC">
+      <node uri="/sap/bc/adt/oo/interfaces/zif_demo_document" name="ZIF_DEMO_DOCUMENT" type="INTF/OI"/>
+    </node>
+    <node uri="/sap/bc/adt/oo/interfaces/zif_demo_printable" name="ZIF_DEMO_PRINTABLE" type="INTF/OI"/>
+  </node>
+</typeHierarchy>`
+
+	var directions []string
+	mock := &funcMockClient{doFunc: func(req *http.Request) (*http.Response, error) {
+		if strings.Contains(req.URL.Path, "discovery") {
+			r := newTestResponse("ok")
+			r.Header.Set("X-CSRF-Token", "test-token")
+			return r, nil
+		}
+		direction := req.URL.Query().Get("direction")
+		directions = append(directions, direction)
+		if direction == "super" {
+			return newTestResponse(superTypes), nil
+		}
+		return newTestResponse(subTypes), nil
+	}}
+	cfg := NewConfig("https://sap.example.com:44300", "user", "pass")
+	client := NewClientWithTransport(cfg, NewTransportWithClient(cfg, mock))
+	ctx := context.Background()
+
+	flatten := func(root *TypeHierarchyNode) []string {
+		var edges []string
+		for _, e := range FlattenTypeHierarchy(root) {
+			edges = append(edges, e.ParentName+">"+e.ChildName)
+		}
+		return edges
+	}
+
+	t.Run("sub", func(t *testing.T) {
+		root, err := client.GetTypeHierarchyTree(ctx, "/sap/bc/adt/oo/classes/zcl_demo_base", "sub")
+		if err != nil {
+			t.Fatalf("GetTypeHierarchyTree failed: %v", err)
+		}
+		if root.Name != "ZCL_DEMO_BASE" || root.Description != "Base document" || len(root.Children) != 2 {
+			t.Fatalf("unexpected root: %+v", root)
+		}
+		got := strings.Join(flatten(root), ",")
+		want := "ZCL_DEMO_BASE>ZCL_DEMO_ORDER,ZCL_DEMO_ORDER>ZCL_DEMO_RUSH_ORDER,ZCL_DEMO_BASE>ZCL_DEMO_INVOICE"
+		if got != want {
+			t.Errorf("edges = %s, want %s", got, want)
+		}
+	})
+
+	t.Run("super", func(t *testing.T) {
+		root, err := client.GetTypeHierarchyTree(ctx, "/sap/bc/adt/oo/classes/zcl_demo_order", "SUPER")
+		if err != nil {
+			t.Fatalf("GetTypeHierarchyTree failed: %v", err)
+		}
+		edges := FlattenTypeHierarchy(root)
+		if len(edges) != 3 {
+			t.Fatalf("expected 3 edges, got %+v", edges)
+		}
+		if edges[1].ChildName != "ZIF_DEMO_DOCUMENT" || edges[1].ChildType != "INTF/OI" || edges[1].ParentName != "ZCL_DEMO_BASE" {
+			t.Errorf("unexpected interface edge: %+v", edges[1])
+		}
+	})
+
+	if strings.Join(directions, ",") != "sub,super" {
+		t.Errorf("directions sent = %v", directions)
+	}
+
+	if _, err := client.GetTypeHierarchyTree(ctx, "/sap/bc/adt/oo/classes/zcl_demo_order", "sideways"); err == nil {
+		t.Error("expected an invalid direction to be rejected")
+	}
+	if edges := FlattenTypeHierarchy(nil); len(edges) != 0 {
+		t.Errorf("expected no edges for a nil tree, got %+v", edges)
+	}
+}
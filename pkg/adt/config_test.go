@@ -1,7 +1,19 @@
 package adt
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
 	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 )
@@ -191,6 +203,138 @@ func TestNewHTTPClient(t *testing.T) {
 	}
 }
 
+func TestNewHTTPClient_Proxy(t *testing.T) {
+	var proxied []*http.Request
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = append(proxied, r)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer proxy.Close()
+
+	proxyURL := strings.Replace(proxy.URL, "http://", "http://proxyuser:proxypass@", 1)
+	cfg := NewConfig("http://dev.example.local:8000", "user", "pass", WithProxy(proxyURL))
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Validate failed: %v", err)
+	}
+	resp, err := cfg.NewHTTPClient().Get("http://dev.example.local:8000/sap/bc/adt/discovery")
+	if err != nil {
+		t.Fatalf("request through proxy failed: %v", err)
+	}
+	resp.Body.Close()
+
+	if len(proxied) != 1 {
+		t.Fatalf("expected the request to reach the proxy, got %d requests", len(proxied))
+	}
+	r := proxied[0]
+	if r.URL.Host != "dev.example.local:8000" || r.URL.Path != "/sap/bc/adt/discovery" {
+		t.Errorf("proxy saw %s", r.URL)
+	}
+	want := "Basic " + base64.StdEncoding.EncodeToString([]byte("proxyuser:proxypass"))
+	if got := r.Header.Get("Proxy-Authorization"); got != want {
+		t.Errorf("Proxy-Authorization = %q, want %q", got, want)
+	}
+}
+
+func TestNewHTTPClient_MalformedProxy(t *testing.T) {
+	var direct int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		direct++
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	cfg := NewConfig(server.URL, "user", "pass", WithProxy("proxy.example.local:8080"))
+	resp, err := cfg.NewHTTPClient().Get(server.URL + "/sap/bc/adt/discovery")
+	if err == nil {
+		resp.Body.Close()
+		t.Fatal("expected the malformed proxy URL to fail the request")
+	}
+	if !strings.Contains(err.Error(), "proxy URL") {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if direct != 0 {
+		t.Errorf("request bypassed the configured proxy %d times", direct)
+	}
+}
+
+// testKeyPairPEM generates a self-signed certificate for client auth tests.
+func testKeyPairPEM(t *testing.T) (certPEM, keyPEM []byte) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "TESTUSER"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
+
+func TestNewHTTPClient_ClientCertificate(t *testing.T) {
+	certPEM, keyPEM := testKeyPairPEM(t)
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "client.crt")
+	keyFile := filepath.Join(dir, "client.key")
+	if err := os.WriteFile(certFile, certPEM, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(keyFile, keyPEM, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		cfg  *Config
+	}{
+		{"files", NewConfig("https://sap.example.com:44300", "user", "pass",
+			WithClientCertificate(certFile, keyFile), WithInsecureSkipVerify())},
+		{"PEM with cookie auth", NewConfig("https://sap.example.com:44300", "", "",
+			WithClientCertificatePEM(certPEM, keyPEM), WithInsecureSkipVerify(),
+			WithCookies(map[string]string{"MYSAPSSO2": "x"}))},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.cfg.Validate(); err != nil {
+				t.Fatalf("Validate failed: %v", err)
+			}
+			tlsCfg := tt.cfg.NewHTTPClient().Transport.(*http.Transport).TLSClientConfig
+			if len(tlsCfg.Certificates) != 1 {
+				t.Fatalf("expected one client certificate, got %d", len(tlsCfg.Certificates))
+			}
+			leaf, err := x509.ParseCertificate(tlsCfg.Certificates[0].Certificate[0])
+			if err != nil || leaf.Subject.CommonName != "TESTUSER" {
+				t.Errorf("unexpected certificate: %v %v", leaf, err)
+			}
+			if !tlsCfg.InsecureSkipVerify {
+				t.Error("InsecureSkipVerify was lost")
+			}
+		})
+	}
+
+	bad := NewConfig("https://sap.example.com:44300", "user", "pass",
+		WithClientCertificate(certFile, filepath.Join(dir, "missing.key")))
+	if err := bad.Validate(); err == nil || !strings.Contains(err.Error(), "client certificate") {
+		t.Errorf("Validate() = %v, want client certificate error", err)
+	}
+	// The HTTP client must not fall back to connecting without the certificate.
+	if _, err := bad.NewHTTPClient().Get("https://sap.example.com:44300/sap/bc/adt/discovery"); err == nil || !strings.Contains(err.Error(), "client certificate") {
+		t.Errorf("request with unloadable certificate = %v, want client certificate error", err)
+	}
+}
+
 func TestSessionTypes(t *testing.T) {
 	if SessionStateful != "stateful" {
 		t.Errorf("SessionStateful = %v, want stateful", SessionStateful)
@@ -202,3 +346,49 @@ func TestSessionTypes(t *testing.T) {
 		t.Errorf("SessionKeep = %v, want keep", SessionKeep)
 	}
 }
+
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     *Config
+		wantErr string
+	}{
+		{"valid basic auth", NewConfig("https://sap.example.com:44300", "user", "pass"), ""},
+		{"valid cookie auth", NewConfig("https://sap.example.com:44300", "", "", WithCookies(map[string]string{"MYSAPSSO2": "x"})), ""},
+		{"empty client and language", NewConfig("https://sap.example.com:44300", "user", "pass", WithClient(""), WithLanguage("")), ""},
+		{"missing base URL", NewConfig("", "user", "pass"), "base URL is required"},
+		{"base URL without scheme", NewConfig("sap.example.com:44300", "user", "pass"), "http:// or https://"},
+		{"base URL without host", NewConfig("https://", "user", "pass"), "no host"},
+		{"password without username", NewConfig("https://sap.example.com:44300", "", "pass"), "username is empty"},
+		{"basic and cookie auth", NewConfig("https://sap.example.com:44300", "user", "pass", WithCookies(map[string]string{"MYSAPSSO2": "x"})), "mutually exclusive"},
+		{"non-numeric client", NewConfig("https://sap.example.com:44300", "user", "pass", WithClient("ABC")), "three-digit"},
+		{"short client", NewConfig("https://sap.example.com:44300", "user", "pass", WithClient("1")), "three-digit"},
+		{"long language", NewConfig("https://sap.example.com:44300", "user", "pass", WithLanguage("ENG")), "language"},
+		{"valid proxy", NewConfig("https://sap.example.com:44300", "user", "pass", WithProxy("http://proxy.example.local:8080")), ""},
+		{"proxy without scheme", NewConfig("https://sap.example.com:44300", "user", "pass", WithProxy("proxy.example.local:8080")), "proxy URL"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("Validate() = %v, want error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNewClient_ConfigError(t *testing.T) {
+	if err := NewClient("https://sap.example.com:44300", "user", "pass").ConfigError(); err != nil {
+		t.Errorf("valid config reported error: %v", err)
+	}
+	if err := NewClient("", "user", "pass").ConfigError(); err == nil {
+		t.Error("expected ConfigError for missing base URL")
+	}
+}
@@ -0,0 +1,160 @@
+package adt
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultConfirmationTTL is how long a confirmation token issued by a
+// destructive batch operation stays valid.
+const DefaultConfirmationTTL = 2 * time.Minute
+
+// ConfirmationRequired is returned by destructive batch operations (for
+// example DeletePackageObjects) on their first invocation. Nothing has been
+// modified yet: the caller must review Objects and re-invoke the same
+// operation with Token before ExpiresAt to proceed.
+type ConfirmationRequired struct {
+	Operation string    `json:"operation"`
+	Scope     string    `json:"scope"`
+	Token     string    `json:"token"`
+	Objects   []string  `json:"objects"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+func (e *ConfirmationRequired) Error() string {
+	return fmt.Sprintf("operation '%s' on %s would destroy %d object(s): confirmation required (re-run with token %s before %s)",
+		e.Operation, e.Scope, len(e.Objects), e.Token, e.ExpiresAt.Format(time.RFC3339))
+}
+
+// IsConfirmationRequired reports whether err is a ConfirmationRequired error
+// and returns it.
+func IsConfirmationRequired(err error) (*ConfirmationRequired, bool) {
+	var confirm *ConfirmationRequired
+	if errors.As(err, &confirm) {
+		return confirm, true
+	}
+	return nil, false
+}
+
+// pendingConfirmation is a token issued but not yet redeemed.
+type pendingConfirmation struct {
+	operation   string
+	scope       string
+	fingerprint string
+	expiresAt   time.Time
+}
+
+// confirmationStore keeps the tokens issued by destructive batch operations.
+// Tokens are single-use and bound to the operation, its scope, and the exact
+// set of objects shown to the caller.
+type confirmationStore struct {
+	mu      sync.Mutex
+	pending map[string]pendingConfirmation
+	ttl     time.Duration
+	now     func() time.Time
+}
+
+func newConfirmationStore() *confirmationStore {
+	return &confirmationStore{
+		pending: make(map[string]pendingConfirmation),
+		ttl:     DefaultConfirmationTTL,
+		now:     time.Now,
+	}
+}
+
+// issue records a new token for the operation and returns the error the
+// caller should surface.
+func (s *confirmationStore) issue(operation, scope string, objects []string) (*ConfirmationRequired, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return nil, fmt.Errorf("generating confirmation token: %w", err)
+	}
+	token := hex.EncodeToString(buf)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.evictExpiredLocked(now)
+
+	expiresAt := now.Add(s.ttl)
+	s.pending[token] = pendingConfirmation{
+		operation:   operation,
+		scope:       scope,
+		fingerprint: objectSetFingerprint(objects),
+		expiresAt:   expiresAt,
+	}
+
+	return &ConfirmationRequired{
+		Operation: operation,
+		Scope:     scope,
+		Token:     token,
+		Objects:   objects,
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+// redeem validates and consumes a token. The object set must match the one
+// the token was issued for, so a package that changed in between requires a
+// fresh confirmation.
+func (s *confirmationStore) redeem(token, operation, scope string, objects []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	p, ok := s.pending[token]
+	if !ok {
+		return fmt.Errorf("confirmation token for '%s' is unknown or already used", operation)
+	}
+	delete(s.pending, token)
+
+	if now.After(p.expiresAt) {
+		return fmt.Errorf("confirmation token for '%s' expired at %s", operation, p.expiresAt.Format(time.RFC3339))
+	}
+	if p.operation != operation || p.scope != scope {
+		return fmt.Errorf("confirmation token was issued for '%s' on %s, not '%s' on %s", p.operation, p.scope, operation, scope)
+	}
+	if p.fingerprint != objectSetFingerprint(objects) {
+		return fmt.Errorf("objects affected by '%s' on %s changed since confirmation was requested; request a new token", operation, scope)
+	}
+
+	return nil
+}
+
+func (s *confirmationStore) evictExpiredLocked(now time.Time) {
+	for token, p := range s.pending {
+		if now.After(p.expiresAt) {
+			delete(s.pending, token)
+		}
+	}
+}
+
+// objectSetFingerprint returns an order-independent key for a list of objects.
+func objectSetFingerprint(objects []string) string {
+	sorted := make([]string, len(objects))
+	for i, o := range objects {
+		sorted[i] = strings.ToUpper(o)
+	}
+	sort.Strings(sorted)
+	return strings.Join(sorted, "\n")
+}
+
+// confirmDestructive implements the two-step confirmation protocol for
+// destructive batch operations. With an empty token it issues a new one and
+// returns a ConfirmationRequired error; otherwise it redeems the token.
+func (c *Client) confirmDestructive(operation, scope string, objects []string, token string) error {
+	if token == "" {
+		confirm, err := c.confirmations.issue(operation, scope, objects)
+		if err != nil {
+			return err
+		}
+		return confirm
+	}
+	return c.confirmations.redeem(token, operation, scope, objects)
+}
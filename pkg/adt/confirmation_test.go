@@ -0,0 +1,130 @@
+package adt
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+const packageWithTwoObjectsXML = `<?xml version="1.0" encoding="UTF-8"?>
+<asx:abap xmlns:asx="http://www.sap.com/abapxml" version="1.0">
+  <asx:values>
+    <DATA>
+      <TREE_CONTENT>
+        <SEU_ADT_REPOSITORY_OBJ_NODE>
+          <OBJECT_TYPE>PROG/P</OBJECT_TYPE>
+          <OBJECT_NAME>ZDEMO_A</OBJECT_NAME>
+          <OBJECT_URI>/sap/bc/adt/programs/programs/zdemo_a</OBJECT_URI>
+        </SEU_ADT_REPOSITORY_OBJ_NODE>
+        <SEU_ADT_REPOSITORY_OBJ_NODE>
+          <OBJECT_TYPE>CLAS/OC</OBJECT_TYPE>
+          <OBJECT_NAME>ZCL_DEMO_B</OBJECT_NAME>
+          <OBJECT_URI>/sap/bc/adt/oo/classes/zcl_demo_b</OBJECT_URI>
+        </SEU_ADT_REPOSITORY_OBJ_NODE>
+      </TREE_CONTENT>
+    </DATA>
+  </asx:values>
+</asx:abap>`
+
+func newBatchDeleteClient() (*Client, *methodPathMock) {
+	mock := &methodPathMock{
+		routes: []routedResponse{
+			resp("", "discovery", 200, "ok"),
+			resp(http.MethodPost, "nodestructure", 200, packageWithTwoObjectsXML),
+			resp(http.MethodPost, "/sap/bc/adt/", 200, lockResponseXML),
+			resp(http.MethodDelete, "/sap/bc/adt/", 200, ""),
+		},
+	}
+	cfg := NewConfig("https://sap.example.com:44300", "user", "pass")
+	return NewClientWithTransport(cfg, NewTransportWithClient(cfg, mock)), mock
+}
+
+func countCalls(mock *methodPathMock, method string) int {
+	n := 0
+	for _, c := range mock.calls {
+		if c.method == method {
+			n++
+		}
+	}
+	return n
+}
+
+func TestDeletePackageObjects_RequiresConfirmation(t *testing.T) {
+	client, mock := newBatchDeleteClient()
+
+	_, err := client.DeletePackageObjects(context.Background(), "$ZDEMO", "", "")
+	confirm, ok := IsConfirmationRequired(err)
+	if !ok {
+		t.Fatalf("expected ConfirmationRequired, got %v", err)
+	}
+	if confirm.Token == "" {
+		t.Fatal("expected non-empty token")
+	}
+	if len(confirm.Objects) != 2 {
+		t.Fatalf("expected 2 objects listed, got %v", confirm.Objects)
+	}
+	if n := countCalls(mock, http.MethodDelete); n != 0 {
+		t.Fatalf("first call must not delete anything, got %d DELETE calls", n)
+	}
+
+	result, err := client.DeletePackageObjects(context.Background(), "$ZDEMO", "", confirm.Token)
+	if err != nil {
+		t.Fatalf("confirmed call failed: %v", err)
+	}
+	if !result.Success || len(result.Deleted) != 2 {
+		t.Fatalf("expected 2 deletions, got %+v", result)
+	}
+	if n := countCalls(mock, http.MethodDelete); n != 2 {
+		t.Fatalf("expected 2 DELETE calls, got %d", n)
+	}
+}
+
+func TestDeletePackageObjects_TokenSingleUse(t *testing.T) {
+	client, _ := newBatchDeleteClient()
+
+	_, err := client.DeletePackageObjects(context.Background(), "$ZDEMO", "", "")
+	confirm, _ := IsConfirmationRequired(err)
+
+	if _, err := client.DeletePackageObjects(context.Background(), "$ZDEMO", "", confirm.Token); err != nil {
+		t.Fatalf("confirmed call failed: %v", err)
+	}
+	if _, err := client.DeletePackageObjects(context.Background(), "$ZDEMO", "", confirm.Token); err == nil {
+		t.Fatal("expected reused token to be rejected")
+	}
+}
+
+func TestConfirmationStore_Expired(t *testing.T) {
+	store := newConfirmationStore()
+	now := time.Now()
+	store.now = func() time.Time { return now }
+
+	confirm, err := store.issue("Op", "package X", []string{"A"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now = now.Add(DefaultConfirmationTTL + time.Second)
+	if err := store.redeem(confirm.Token, "Op", "package X", []string{"A"}); err == nil {
+		t.Fatal("expected expired token to be rejected")
+	}
+}
+
+func TestConfirmationStore_ScopeAndObjectsMustMatch(t *testing.T) {
+	store := newConfirmationStore()
+
+	confirm, _ := store.issue("Op", "package X", []string{"A", "B"})
+	if err := store.redeem(confirm.Token, "Op", "package Y", []string{"A", "B"}); err == nil {
+		t.Fatal("expected scope mismatch to be rejected")
+	}
+
+	confirm, _ = store.issue("Op", "package X", []string{"A", "B"})
+	if err := store.redeem(confirm.Token, "Op", "package X", []string{"A", "B", "C"}); err == nil {
+		t.Fatal("expected changed object set to be rejected")
+	}
+
+	confirm, _ = store.issue("Op", "package X", []string{"A", "B"})
+	if err := store.redeem(confirm.Token, "Op", "package X", []string{"B", "A"}); err != nil {
+		t.Fatalf("expected order-independent match, got %v", err)
+	}
+}
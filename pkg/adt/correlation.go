@@ -0,0 +1,23 @@
+package adt
+
+import "context"
+
+// CorrelationIDHeader carries the ID attached with WithCorrelationID so that
+// requests can be matched with SAP gateway and ICM logs.
+const CorrelationIDHeader = "X-Request-ID"
+
+type correlationIDKey struct{}
+
+// WithCorrelationID returns a copy of ctx carrying id. Every request sent
+// with the returned context includes id in the X-Request-ID header, and
+// errors returned for those requests mention it.
+func WithCorrelationID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, correlationIDKey{}, id)
+}
+
+// CorrelationID returns the ID attached to ctx by WithCorrelationID, or ""
+// if there is none.
+func CorrelationID(ctx context.Context) string {
+	id, _ := ctx.Value(correlationIDKey{}).(string)
+	return id
+}
@@ -0,0 +1,52 @@
+package adt
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestTransport_CorrelationID(t *testing.T) {
+	var got []string
+	mock := &funcMockClient{doFunc: func(req *http.Request) (*http.Response, error) {
+		got = append(got, req.Header.Get(CorrelationIDHeader))
+		if strings.Contains(req.URL.Path, "zdemo_missing") {
+			return newMockResponse(http.StatusNotFound, "not found", nil), nil
+		}
+		return newTestResponse("ok"), nil
+	}}
+	cfg := NewConfig("https://sap.example.com:44300", "user", "pass")
+	transport := NewTransportWithClient(cfg, mock)
+
+	ctx := WithCorrelationID(context.Background(), "batch-7f3a")
+	if CorrelationID(ctx) != "batch-7f3a" {
+		t.Fatalf("CorrelationID = %q", CorrelationID(ctx))
+	}
+	if _, err := transport.Request(ctx, "/sap/bc/adt/programs/programs/zdemo_report", nil); err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	_, err := transport.Request(ctx, "/sap/bc/adt/programs/programs/zdemo_missing", nil)
+	if err == nil {
+		t.Fatal("expected an error for the missing program")
+	}
+	if !strings.Contains(err.Error(), "batch-7f3a") {
+		t.Errorf("error does not mention the correlation ID: %v", err)
+	}
+	if !IsNotFoundError(err) {
+		t.Errorf("wrapped error lost its status: %v", err)
+	}
+	for i, h := range got {
+		if h != "batch-7f3a" {
+			t.Errorf("request %d: %s = %q", i, CorrelationIDHeader, h)
+		}
+	}
+
+	got = nil
+	if _, err := transport.Request(context.Background(), "/sap/bc/adt/programs/programs/zdemo_report", nil); err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	if got[0] != "" {
+		t.Errorf("header sent without a correlation ID: %q", got[0])
+	}
+}
@@ -7,7 +7,9 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"regexp"
 	"strings"
+	"time"
 )
 
 // --- Lock/Unlock Operations ---
@@ -23,6 +25,12 @@ type LockResult struct {
 	ModificationSupport string `json:"modificationSupport,omitempty"`
 }
 
+// Retry policy for transient lock-acquisition failures (see IsLockBusy).
+var (
+	lockRetryAttempts = 3
+	lockRetryBackoff  = 200 * time.Millisecond
+)
+
 // LockObject acquires an edit lock on an ABAP object.
 // objectURL is the ADT URL of the object (e.g., "/sap/bc/adt/programs/programs/ZTEST")
 // accessMode is typically "MODIFY" for editing
@@ -42,13 +50,32 @@ func (c *Client) LockObject(ctx context.Context, objectURL string, accessMode st
 	params.Set("_action", "LOCK")
 	params.Set("accessMode", accessMode)
 
-	resp, err := c.transport.Request(ctx, objectURL, &RequestOptions{
-		Method:   http.MethodPost,
-		Query:    params,
-		Accept:   "application/vnd.sap.as+xml;charset=UTF-8;dataname=com.sap.adt.lock.result",
-		Stateful: true, // Lock handles are session-specific — force stateful (issue #88)
-	})
+	// Transient "resource busy" failures are retried with a short backoff;
+	// a lock held by another user fails immediately.
+	var resp *Response
+	var err error
+	backoff := lockRetryBackoff
+	for attempt := 1; ; attempt++ {
+		resp, err = c.transport.Request(ctx, objectURL, &RequestOptions{
+			Method:   http.MethodPost,
+			Query:    params,
+			Accept:   "application/vnd.sap.as+xml;charset=UTF-8;dataname=com.sap.adt.lock.result",
+			Stateful: true, // Lock handles are session-specific — force stateful (issue #88)
+		})
+		if err == nil || !IsLockBusyError(err) || attempt >= lockRetryAttempts {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("locking object: %w", ctx.Err())
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
 	if err != nil {
+		if lockErr := newObjectLockedError(objectURL, err); lockErr != nil {
+			return nil, lockErr
+		}
 		return nil, fmt.Errorf("locking object: %w", err)
 	}
 
@@ -165,6 +192,7 @@ func (c *Client) UpdateSource(ctx context.Context, objectSourceURL string, sourc
 		ContentType: contentType,
 		Stateful:    true, // Must match lock session (issue #88)
 	})
+	c.structures.invalidateURL(objectSourceURL)
 	if err != nil {
 		return fmt.Errorf("updating source: %w", err)
 	}
@@ -178,14 +206,18 @@ func (c *Client) UpdateSource(ctx context.Context, objectSourceURL string, sourc
 type CreatableObjectType string
 
 const (
-	ObjectTypeProgram       CreatableObjectType = "PROG/P"
-	ObjectTypeInclude       CreatableObjectType = "PROG/I"
-	ObjectTypeClass         CreatableObjectType = "CLAS/OC"
-	ObjectTypeInterface     CreatableObjectType = "INTF/OI"
-	ObjectTypeFunctionGroup CreatableObjectType = "FUGR/F"
-	ObjectTypeFunctionMod   CreatableObjectType = "FUGR/FF"
-	ObjectTypeTable         CreatableObjectType = "TABL/DT"
-	ObjectTypePackage       CreatableObjectType = "DEVC/K"
+	ObjectTypeProgram        CreatableObjectType = "PROG/P"
+	ObjectTypeInclude        CreatableObjectType = "PROG/I"
+	ObjectTypeClass          CreatableObjectType = "CLAS/OC"
+	ObjectTypeInterface      CreatableObjectType = "INTF/OI"
+	ObjectTypeFunctionGroup  CreatableObjectType = "FUGR/F"
+	ObjectTypeFunctionMod    CreatableObjectType = "FUGR/FF"
+	ObjectTypeTable          CreatableObjectType = "TABL/DT"
+	ObjectTypeStructure      CreatableObjectType = "TABL/DS" // DDIC structure (read-only)
+	ObjectTypeView           CreatableObjectType = "VIEW/DV" // Classic DDIC view (read-only)
+	ObjectTypePackage        CreatableObjectType = "DEVC/K"
+	ObjectTypeTransformation CreatableObjectType = "XSLT/VT" // XSLT or Simple Transformation
+	ObjectTypeTypeGroup      CreatableObjectType = "TYPE/DG" // Type group (TYPE-POOL)
 	// RAP object types (read-only via ADT, created via RAP generators)
 	ObjectTypeDDLS CreatableObjectType = "DDLS/DF"  // CDS DDL Source
 	ObjectTypeBDEF CreatableObjectType = "BDEF/BDO" // Behavior Definition
@@ -313,6 +345,95 @@ func isLockConflictError(err error) bool {
 	return strings.Contains(errStr, "403") && strings.Contains(errStr, "currently editing")
 }
 
+// ObjectLockedError is returned by LockObject when the object is locked by
+// another user. LockedBy is the lock owner when SAP names it.
+type ObjectLockedError struct {
+	ObjectURL string
+	LockedBy  string
+	Err       error
+}
+
+func (e *ObjectLockedError) Error() string {
+	return fmt.Sprintf("locking object: %v", e.Err)
+}
+
+func (e *ObjectLockedError) Unwrap() error {
+	return e.Err
+}
+
+var lockOwnerPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`(?i)user\s+(\S+)\s+is currently editing`),
+	regexp.MustCompile(`(?i)currently editing by\s+([^\s.,]+)`),
+	regexp.MustCompile(`(?i)locked by (?:user\s+)?([^\s.,]+)`),
+}
+
+// newObjectLockedError classifies a lock failure as a lock held by another
+// user ("... is currently editing ..."). It returns nil for other failures.
+func newObjectLockedError(objectURL string, err error) *ObjectLockedError {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || !strings.Contains(strings.ToLower(apiErr.Message), "currently editing") {
+		return nil
+	}
+	lockErr := &ObjectLockedError{ObjectURL: objectURL, Err: err}
+	for _, re := range lockOwnerPatterns {
+		if m := re.FindStringSubmatch(apiErr.Message); m != nil {
+			lockErr.LockedBy = m[1]
+			break
+		}
+	}
+	return lockErr
+}
+
+// LockStatus reports whether an object can be locked for editing.
+type LockStatus struct {
+	Locked   bool   `json:"locked"`
+	LockedBy string `json:"lockedBy,omitempty"` // Lock owner, when SAP names it
+	// LockHandle is the handle of the probe lock. The lock is released before
+	// GetLockStatus returns, so it cannot be used for writes.
+	LockHandle string `json:"lockHandle,omitempty"`
+}
+
+// GetLockStatus tells whether an object is locked by someone else, and by
+// whom, before a write runs into the lock. It takes a lock and releases it
+// at once; the object itself is not changed. objType is a short type such
+// as "CLAS" or "PROG/P".
+func (c *Client) GetLockStatus(ctx context.Context, objType, name string) (*LockStatus, error) {
+	t, ok := objectTypeFromShort(objType)
+	if !ok || t == ObjectTypeFunctionMod {
+		return nil, fmt.Errorf("unsupported object type for lock status: %s", objType)
+	}
+	objectURL := GetObjectURL(t, name, "")
+	if objectURL == "" {
+		return nil, fmt.Errorf("unsupported object type for lock status: %s", objType)
+	}
+
+	lock, err := c.LockObject(ctx, objectURL, "MODIFY")
+	if err != nil {
+		var lockErr *ObjectLockedError
+		if errors.As(err, &lockErr) {
+			return &LockStatus{Locked: true, LockedBy: lockErr.LockedBy}, nil
+		}
+		// Some releases answer a held lock with 409/423 instead of 403.
+		var apiErr *APIError
+		if errors.As(err, &apiErr) && (apiErr.StatusCode == http.StatusConflict || apiErr.StatusCode == http.StatusLocked) {
+			status := &LockStatus{Locked: true}
+			for _, re := range lockOwnerPatterns {
+				if m := re.FindStringSubmatch(apiErr.Message); m != nil {
+					status.LockedBy = m[1]
+					break
+				}
+			}
+			return status, nil
+		}
+		return nil, err
+	}
+
+	if err := c.UnlockObject(ctx, objectURL, lock.LockHandle); err != nil {
+		return nil, fmt.Errorf("releasing probe lock: %w", err)
+	}
+	return &LockStatus{LockHandle: lock.LockHandle}, nil
+}
+
 // PartialCreateError is returned by CreateObject when the SAP backend
 // failed mid-flight but had already persisted the new object before the
 // HTTP failure surfaced. CleanupOK is true when the best-effort
@@ -666,6 +787,32 @@ func (c *Client) CreateObject(ctx context.Context, opts CreateObjectOptions) err
 	return nil
 }
 
+// CreateObjectInPackage creates an empty class, interface or program in
+// packageName ($TMP when empty) and returns the URI of the new object. It
+// is a shorthand for CreateObject and goes through the same safety checks;
+// use CreateObject for other object types and for transports.
+func (c *Client) CreateObjectInPackage(ctx context.Context, objType CreatableObjectType, name, packageName, description string) (string, error) {
+	switch objType {
+	case ObjectTypeClass, ObjectTypeInterface, ObjectTypeProgram:
+	default:
+		return "", fmt.Errorf("CreateObjectInPackage supports classes, interfaces and programs, not %s; use CreateObject", objType)
+	}
+	if packageName == "" {
+		packageName = "$TMP"
+	}
+
+	err := c.CreateObject(ctx, CreateObjectOptions{
+		ObjectType:  objType,
+		Name:        name,
+		Description: description,
+		PackageName: packageName,
+	})
+	if err != nil {
+		return "", err
+	}
+	return GetObjectURL(objType, name, ""), nil
+}
+
 func buildCreateObjectBody(opts CreateObjectOptions, typeInfo objectTypeInfo, defaultResponsible string) string {
 	responsible := opts.Responsible
 	if responsible == "" {
@@ -875,6 +1022,52 @@ func (c *Client) DeleteObject(ctx context.Context, objectURL string, lockHandle
 		return fmt.Errorf("deleting object: %w", err)
 	}
 
+	c.packages.invalidate(objectURL)
+	return nil
+}
+
+// DeleteObjectByName deletes a class, program or interface: Lock -> Delete.
+// Objects outside $TMP are refused unless allowTransported is set, so a
+// cleanup tool cannot remove transported development by accident. The
+// delete is gated by the safety configuration like DeleteObject.
+func (c *Client) DeleteObjectByName(ctx context.Context, objType CreatableObjectType, name string, allowTransported bool) error {
+	switch objType {
+	case ObjectTypeClass, ObjectTypeProgram, ObjectTypeInterface:
+	default:
+		return fmt.Errorf("DeleteObjectByName supports classes, programs and interfaces, not %s", objType)
+	}
+
+	name = strings.ToUpper(name)
+	objectURL := GetObjectURL(objType, name, "")
+
+	// Fail fast before any request when deletes are blocked by policy
+	if err := c.checkSafety(OpDelete, "DeleteObjectByName"); err != nil {
+		return err
+	}
+
+	if !allowTransported {
+		pkg, err := c.getObjectPackage(ctx, objectURL)
+		if err != nil {
+			return fmt.Errorf("deleting %s: cannot determine package (object may not exist): %w", name, err)
+		}
+		if !strings.EqualFold(pkg, "$TMP") {
+			return fmt.Errorf("refusing to delete %s in package %s: only $TMP objects are deleted unless allowTransported is set", name, pkg)
+		}
+	}
+
+	lock, err := c.LockObject(ctx, objectURL, "MODIFY")
+	if err != nil {
+		if IsNotFoundError(err) {
+			return fmt.Errorf("deleting %s: object does not exist: %w", name, err)
+		}
+		return err
+	}
+
+	if err := c.DeleteObject(ctx, objectURL, lock.LockHandle, ""); err != nil {
+		_ = c.UnlockObject(ctx, objectURL, lock.LockHandle)
+		return err
+	}
+
 	return nil
 }
 
@@ -903,6 +1096,10 @@ func GetObjectURL(objectType CreatableObjectType, name string, parentName string
 		return fmt.Sprintf("/sap/bc/adt/functions/groups/%s/fmodules/%s", encodedParent, encodedName)
 	case ObjectTypePackage:
 		return fmt.Sprintf("/sap/bc/adt/packages/%s", encodedName)
+	case ObjectTypeTransformation:
+		return fmt.Sprintf("/sap/bc/adt/xslt/transformations/%s", url.PathEscape(strings.ToLower(name)))
+	case ObjectTypeTypeGroup:
+		return fmt.Sprintf("/sap/bc/adt/ddic/typegroups/%s", url.PathEscape(strings.ToLower(name)))
 	// RAP object types - use lowercase for CDS objects
 	case ObjectTypeDDLS:
 		return fmt.Sprintf("/sap/bc/adt/ddic/ddl/sources/%s", url.PathEscape(strings.ToLower(name)))
@@ -917,6 +1114,74 @@ func GetObjectURL(objectType CreatableObjectType, name string, parentName string
 	}
 }
 
+// ResolveObjectURI returns the canonical ADT URI of an object given its short
+// type ("CLAS", "PROG", "FUNC", ...) and name. parent is the function group
+// and is required for FUNC. The URI is built like GetObjectURL and then
+// checked with a HEAD request, so a misspelled name fails here with a 404
+// instead of deep inside the analysis that consumes the URI.
+func (c *Client) ResolveObjectURI(ctx context.Context, objType, name, parent string) (string, error) {
+	t, ok := objectTypeFromShort(objType)
+	if !ok {
+		return "", fmt.Errorf("unsupported object type %q", objType)
+	}
+	if name == "" {
+		return "", fmt.Errorf("object name is required")
+	}
+	if t == ObjectTypeFunctionMod && parent == "" {
+		return "", fmt.Errorf("function group (parent) is required for FUNC %s", strings.ToUpper(name))
+	}
+
+	objectURI := GetObjectURL(t, name, parent)
+	if objectURI == "" {
+		return "", fmt.Errorf("cannot build URI for %s %s", objType, name)
+	}
+
+	_, err := c.transport.Request(ctx, objectURI, &RequestOptions{Method: http.MethodHead})
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusMethodNotAllowed {
+		// Some object endpoints only answer GET.
+		_, err = c.transport.Request(ctx, objectURI, &RequestOptions{Method: http.MethodGet})
+	}
+	if err != nil {
+		if IsNotFoundError(err) {
+			return "", fmt.Errorf("%s %s does not exist: %w", strings.ToUpper(objType), strings.ToUpper(name), err)
+		}
+		return "", fmt.Errorf("resolving %s %s: %w", strings.ToUpper(objType), strings.ToUpper(name), err)
+	}
+
+	return objectURI, nil
+}
+
+// shortObjectTypes maps the short object type used by GetSource and the
+// MCP tools ("PROG", "CLAS", ...) to its creatable ADT object type.
+var shortObjectTypes = map[string]CreatableObjectType{
+	"PROG": ObjectTypeProgram,
+	"INCL": ObjectTypeInclude,
+	"CLAS": ObjectTypeClass,
+	"INTF": ObjectTypeInterface,
+	"FUGR": ObjectTypeFunctionGroup,
+	"FUNC": ObjectTypeFunctionMod,
+	"TABL": ObjectTypeTable,
+	"DEVC": ObjectTypePackage,
+	"XSLT": ObjectTypeTransformation,
+	"TYPE": ObjectTypeTypeGroup,
+	"DDLS": ObjectTypeDDLS,
+	"BDEF": ObjectTypeBDEF,
+	"SRVD": ObjectTypeSRVD,
+	"SRVB": ObjectTypeSRVB,
+}
+
+// objectTypeFromShort resolves a short object type ("PROG", "CLAS/OC", ...)
+// to its creatable ADT object type.
+func objectTypeFromShort(objectType string) (CreatableObjectType, bool) {
+	short := strings.ToUpper(objectType)
+	if i := strings.Index(short, "/"); i >= 0 {
+		short = short[:i]
+	}
+	t, ok := shortObjectTypes[short]
+	return t, ok
+}
+
 // GetSourceURL returns the source URL for an object.
 func GetSourceURL(objectType CreatableObjectType, name string, parentName string) string {
 	objectURL := GetObjectURL(objectType, name, parentName)
@@ -1014,6 +1279,7 @@ func (c *Client) GetClassInclude(ctx context.Context, className string, includeT
 
 	resp, err := c.transport.Request(ctx, sourceURL, &RequestOptions{
 		Method: http.MethodGet,
+		Accept: c.sourceAcceptFor(ObjectTypeClass),
 	})
 	if err != nil {
 		return "", fmt.Errorf("getting class include: %w", err)
@@ -1022,6 +1288,43 @@ func (c *Client) GetClassInclude(ctx context.Context, className string, includeT
 	return string(resp.Body), nil
 }
 
+// classIncludeParts lists the include parts read by GetClassIncludes.
+var classIncludeParts = []ClassIncludeType{
+	ClassIncludeMain,
+	ClassIncludeDefinitions,
+	ClassIncludeImplementations,
+	ClassIncludeMacros,
+	ClassIncludeTestClasses,
+}
+
+// classIncludePart resolves a case-insensitive include part name.
+func classIncludePart(part string) (ClassIncludeType, bool) {
+	for _, p := range classIncludeParts {
+		if strings.EqualFold(part, string(p)) {
+			return p, true
+		}
+	}
+	return "", false
+}
+
+// GetClassIncludes retrieves every include part of a class that exists,
+// keyed by part name ("main", "definitions", "testclasses", ...). Parts the
+// class does not have are left out; a missing main include is an error.
+func (c *Client) GetClassIncludes(ctx context.Context, className string) (map[string]string, error) {
+	sources := make(map[string]string, len(classIncludeParts))
+	for _, part := range classIncludeParts {
+		source, err := c.GetClassInclude(ctx, className, part)
+		if err != nil {
+			if part != ClassIncludeMain && IsNotFoundError(err) {
+				continue
+			}
+			return nil, fmt.Errorf("reading %s include of %s: %w", part, strings.ToUpper(className), err)
+		}
+		sources[string(part)] = source
+	}
+	return sources, nil
+}
+
 // UpdateClassInclude updates the source code of a class include.
 // Requires a lock on the parent class.
 func (c *Client) UpdateClassInclude(ctx context.Context, className string, includeType ClassIncludeType, source string, lockHandle string, transport string) error {
@@ -1050,6 +1353,7 @@ func (c *Client) UpdateClassInclude(ctx context.Context, className string, inclu
 		ContentType: "text/plain; charset=utf-8",
 		Stateful:    true, // Must match lock session — the lock was acquired statefully (issues #88/#92/#98)
 	})
+	c.structures.invalidateURL(sourceURL)
 	if err != nil {
 		return fmt.Errorf("updating class include: %w", err)
 	}
@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"strings"
 	"testing"
+	"time"
 )
 
 // methodPathMock is a richer test transport than the path-only
@@ -639,3 +640,74 @@ func TestLockObject_AllowsNoModificationOnReadLock(t *testing.T) {
 		t.Errorf("LockHandle = %q, want HANDLE-X", result.LockHandle)
 	}
 }
+
+// busyLockMock answers the first `busy` lock POSTs with a transient
+// enqueue failure (or `failure` when set) before delegating to the routes.
+type busyLockMock struct {
+	methodPathMock
+	busy    int
+	failure string
+}
+
+func (m *busyLockMock) Do(req *http.Request) (*http.Response, error) {
+	if req.Method == http.MethodPost && req.URL.Query().Get("_action") == "LOCK" && m.busy > 0 {
+		m.busy--
+		m.calls = append(m.calls, recordedCall{method: req.Method, path: req.URL.Path})
+		status, body := http.StatusServiceUnavailable, "Enqueue: resource busy, try again"
+		if m.failure != "" {
+			status, body = http.StatusConflict, m.failure
+		}
+		return &http.Response{
+			StatusCode: status,
+			Body:       io.NopCloser(strings.NewReader(body)),
+			Header:     http.Header{},
+		}, nil
+	}
+	return m.methodPathMock.Do(req)
+}
+
+func withFastLockRetry(t *testing.T) {
+	t.Helper()
+	saved := lockRetryBackoff
+	lockRetryBackoff = time.Millisecond
+	t.Cleanup(func() { lockRetryBackoff = saved })
+}
+
+func TestLockObject_RetriesTransientBusy(t *testing.T) {
+	withFastLockRetry(t)
+	mock := &busyLockMock{busy: 2, methodPathMock: methodPathMock{routes: []routedResponse{
+		resp("", "discovery", 200, "ok"),
+		resp(http.MethodPost, "/oo/classes/ZCL_DEMO_BUSY", 200, lockResponseXML),
+	}}}
+	cfg := NewConfig("https://sap.example.com:44300", "user", "pass")
+	client := NewClientWithTransport(cfg, NewTransportWithClient(cfg, mock))
+
+	result, err := client.LockObject(context.Background(), "/sap/bc/adt/oo/classes/ZCL_DEMO_BUSY", "MODIFY")
+	if err != nil {
+		t.Fatalf("LockObject should succeed after transient busy: %v", err)
+	}
+	if result.LockHandle != "TESTHANDLE" {
+		t.Errorf("LockHandle = %q, want TESTHANDLE", result.LockHandle)
+	}
+	if n := countCalls(&mock.methodPathMock, http.MethodPost); n != 3 {
+		t.Errorf("expected 3 lock attempts, got %d", n)
+	}
+}
+
+func TestLockObject_NoRetryOnForeignLock(t *testing.T) {
+	withFastLockRetry(t)
+	mock := &busyLockMock{busy: 1, failure: "Object ZCL_DEMO_BUSY is currently editing by TESTUSER",
+		methodPathMock: methodPathMock{routes: []routedResponse{
+			resp("", "discovery", 200, "ok"),
+			resp(http.MethodPost, "/oo/classes/ZCL_DEMO_BUSY", 200, lockResponseXML),
+		}}}
+	cfg := NewConfig("https://sap.example.com:44300", "user", "pass")
+	client := NewClientWithTransport(cfg, NewTransportWithClient(cfg, mock))
+
+	if _, err := client.LockObject(context.Background(), "/sap/bc/adt/oo/classes/ZCL_DEMO_BUSY", "MODIFY"); err == nil {
+		t.Fatal("LockObject must fail immediately on a lock held by another user")
+	}
+	if n := countCalls(&mock.methodPathMock, http.MethodPost); n != 1 {
+		t.Errorf("expected a single lock attempt, got %d", n)
+	}
+}
@@ -0,0 +1,197 @@
+package adt
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// createRecorder answers package lookups and records creation POSTs.
+type createRecorder struct {
+	posts map[string]string // creation path -> body
+}
+
+func (r *createRecorder) Do(req *http.Request) (*http.Response, error) {
+	resp := newTestResponse("")
+	resp.Header.Set("X-CSRF-Token", "test-token")
+	switch {
+	case strings.Contains(req.URL.Path, "nodestructure"):
+		resp = newTestResponse(nodeStructureResponse())
+	case req.Method == http.MethodPost && req.Body != nil:
+		body, _ := io.ReadAll(req.Body)
+		r.posts[req.URL.Path] = string(body)
+	}
+	return resp, nil
+}
+
+func TestClient_CreateObjectInPackage(t *testing.T) {
+	tests := []struct {
+		objType  CreatableObjectType
+		name     string
+		pkg      string
+		wantPath string
+		wantURI  string
+		wantBody []string
+	}{
+		{
+			objType:  ObjectTypeClass,
+			name:     "zcl_demo_created",
+			pkg:      "$ZDEMO",
+			wantPath: "/sap/bc/adt/oo/classes",
+			wantURI:  "/sap/bc/adt/oo/classes/ZCL_DEMO_CREATED",
+			wantBody: []string{`<class:abapClass`, `adtcore:name="ZCL_DEMO_CREATED"`, `adtcore:type="CLAS/OC"`, `adtcore:description="Demo class"`, `adtcore:name="$ZDEMO"`},
+		},
+		{
+			objType:  ObjectTypeProgram,
+			name:     "ZDEMO_REPORT",
+			wantPath: "/sap/bc/adt/programs/programs",
+			wantURI:  "/sap/bc/adt/programs/programs/ZDEMO_REPORT",
+			wantBody: []string{`<program:abapProgram`, `adtcore:name="ZDEMO_REPORT"`, `adtcore:type="PROG/P"`, `adtcore:name="$TMP"`},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.objType), func(t *testing.T) {
+			rec := &createRecorder{posts: map[string]string{}}
+			cfg := NewConfig("https://sap.example.com:44300", "user", "pass")
+			client := NewClientWithTransport(cfg, NewTransportWithClient(cfg, rec))
+
+			desc := "Demo class"
+			if tt.objType == ObjectTypeProgram {
+				desc = "Demo report"
+			}
+			uri, err := client.CreateObjectInPackage(context.Background(), tt.objType, tt.name, tt.pkg, desc)
+			if err != nil {
+				t.Fatalf("CreateObjectInPackage failed: %v", err)
+			}
+			if uri != tt.wantURI {
+				t.Errorf("uri = %q, want %q", uri, tt.wantURI)
+			}
+			body, ok := rec.posts[tt.wantPath]
+			if !ok {
+				t.Fatalf("no POST to %s; got %v", tt.wantPath, rec.posts)
+			}
+			for _, want := range tt.wantBody {
+				if !strings.Contains(body, want) {
+					t.Errorf("creation body lacks %s:\n%s", want, body)
+				}
+			}
+		})
+	}
+}
+
+func TestClient_CreateObjectInPackage_BlockedPackage(t *testing.T) {
+	rec := &createRecorder{posts: map[string]string{}}
+	cfg := NewConfig("https://sap.example.com:44300", "user", "pass", WithAllowedPackages("$TMP"))
+	client := NewClientWithTransport(cfg, NewTransportWithClient(cfg, rec))
+
+	_, err := client.CreateObjectInPackage(context.Background(), ObjectTypeInterface, "ZIF_DEMO_BLOCKED", "$ZDEMO", "Blocked")
+	if err == nil {
+		t.Fatal("expected creation in a package outside the allowed list to fail")
+	}
+	if len(rec.posts) != 0 {
+		t.Errorf("no creation request expected, got %v", rec.posts)
+	}
+
+	if _, err := client.CreateObjectInPackage(context.Background(), ObjectTypeTable, "ZDEMO_TAB", "$TMP", "Table"); err == nil {
+		t.Error("expected an error for an object type that needs CreateObject")
+	}
+}
+
+func TestClient_GetLockStatus(t *testing.T) {
+	tests := []struct {
+		name       string
+		lockStatus int
+		lockBody   string
+		want       LockStatus
+		wantUnlock bool
+	}{
+		{
+			name:       "locked by other user",
+			lockStatus: http.StatusForbidden,
+			lockBody:   "User TESTUSER is currently editing ZCL_DEMO_ORDER",
+			want:       LockStatus{Locked: true, LockedBy: "TESTUSER"},
+		},
+		{
+			name:       "locked, reported as 409",
+			lockStatus: http.StatusConflict,
+			lockBody:   "Object ZCL_DEMO_ORDER is locked by user TESTUSER.",
+			want:       LockStatus{Locked: true, LockedBy: "TESTUSER"},
+		},
+		{
+			name:       "free",
+			lockStatus: http.StatusOK,
+			lockBody:   lockResponseXML,
+			wantUnlock: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock, seq := lifecycleMock(tt.lockStatus, tt.lockBody)
+			cfg := NewConfig("https://sap.example.com:44300", "user", "pass")
+			client := NewClientWithTransport(cfg, NewTransportWithClient(cfg, mock))
+
+			status, err := client.GetLockStatus(context.Background(), "CLAS", "zcl_demo_order")
+			if err != nil {
+				t.Fatalf("GetLockStatus failed: %v", err)
+			}
+			if status.Locked != tt.want.Locked || status.LockedBy != tt.want.LockedBy {
+				t.Errorf("status = %+v, want %+v", status, tt.want)
+			}
+			unlocked := false
+			for _, entry := range *seq {
+				if strings.HasSuffix(entry, " UNLOCK") {
+					unlocked = true
+				}
+			}
+			if unlocked != tt.wantUnlock {
+				t.Errorf("probe lock released = %v, want %v (requests %v)", unlocked, tt.wantUnlock, *seq)
+			}
+			if tt.wantUnlock && status.LockHandle == "" {
+				t.Error("expected the probe lock handle")
+			}
+		})
+	}
+}
+
+func TestClient_ResolveObjectURI(t *testing.T) {
+	mock := &methodPathMock{routes: []routedResponse{
+		resp(http.MethodHead, "/core/discovery", http.StatusOK, ""),
+		resp(http.MethodHead, "/oo/classes/ZCL_DEMO_ORDER", http.StatusOK, ""),
+		resp(http.MethodHead, "/programs/programs/ZDEMO_REPORT", http.StatusMethodNotAllowed, ""),
+		resp(http.MethodGet, "/programs/programs/ZDEMO_REPORT", http.StatusOK, "<program/>"),
+		resp(http.MethodHead, "/fmodules/", http.StatusOK, ""),
+		resp(http.MethodHead, "", http.StatusNotFound, "not found"),
+	}}
+	cfg := NewConfig("https://sap.example.com:44300", "user", "pass")
+	client := NewClientWithTransport(cfg, NewTransportWithClient(cfg, mock))
+	ctx := context.Background()
+
+	tests := []struct {
+		objType, name, parent string
+		want                  string
+	}{
+		{"CLAS", "zcl_demo_order", "", "/sap/bc/adt/oo/classes/ZCL_DEMO_ORDER"},
+		{"PROG", "ZDEMO_REPORT", "", "/sap/bc/adt/programs/programs/ZDEMO_REPORT"},
+		{"FUNC", "/DEMO/GET_ORDER", "/DEMO/ORDERS", "/sap/bc/adt/functions/groups/%2FDEMO%2FORDERS/fmodules/%2FDEMO%2FGET_ORDER"},
+	}
+	for _, tt := range tests {
+		got, err := client.ResolveObjectURI(ctx, tt.objType, tt.name, tt.parent)
+		if err != nil {
+			t.Errorf("ResolveObjectURI(%s %s) failed: %v", tt.objType, tt.name, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ResolveObjectURI(%s %s) = %q, want %q", tt.objType, tt.name, got, tt.want)
+		}
+	}
+
+	_, err := client.ResolveObjectURI(ctx, "CLAS", "ZCL_DEMO_TYPO", "")
+	if !IsNotFoundError(err) || !strings.Contains(err.Error(), "CLAS ZCL_DEMO_TYPO does not exist") {
+		t.Errorf("expected a not-found error for a typo, got %v", err)
+	}
+	if _, err := client.ResolveObjectURI(ctx, "FUNC", "/DEMO/GET_ORDER", ""); err == nil {
+		t.Error("expected an error for FUNC without a function group")
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/hex"
 	"encoding/xml"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -116,6 +117,10 @@ type Breakpoint struct {
 	ClassName  string `json:"className,omitempty"`  // Class name for method breakpoint
 	MethodName string `json:"methodName,omitempty"` // Method name for method breakpoint
 
+	// Hit count: break only from the HitCount-th pass on (0 or 1 = every
+	// pass).
+	HitCount int `json:"hitCount,omitempty"`
+
 	// Read-only fields returned by SAP
 	ActualLine int    `json:"actualLine,omitempty"` // Actual line after adjustment
 	IsActive   bool   `json:"isActive,omitempty"`   // Whether BP is currently active
@@ -325,6 +330,9 @@ func buildBreakpointRequestXML(req *BreakpointRequest) (string, error) {
 			if bp.Condition != "" {
 				attrs += fmt.Sprintf(` condition="%s"`, xmlEscape(bp.Condition))
 			}
+			if bp.HitCount > 1 {
+				attrs += fmt.Sprintf(` hitCount="%d"`, bp.HitCount)
+			}
 			bpElements = append(bpElements, fmt.Sprintf(`<breakpoint %s/>`, attrs))
 
 		case BreakpointKindException:
@@ -379,6 +387,7 @@ func parseBreakpointResponse(data []byte) (*BreakpointResponse, error) {
 		IsActive       bool   `xml:"isActive,attr"`
 		URI            string `xml:"uri,attr"`          // adtcore:uri attribute
 		Condition      string `xml:"condition,attr"`    // condition attribute
+		HitCount       int    `xml:"hitCount,attr"`
 		ExceptionClass string `xml:"exceptionClass,attr"`
 		Statement      string `xml:"statement,attr"`
 		MsgID          string `xml:"msgId,attr"`
@@ -412,6 +421,7 @@ func parseBreakpointResponse(data []byte) (*BreakpointResponse, error) {
 			IsActive:    bp.IsActive,
 			URI:         bp.URI,
 			Condition:   bp.Condition,
+			HitCount:    bp.HitCount,
 			Exception:   bp.ExceptionClass,
 			Statement:   bp.Statement,
 			MessageID:   bp.MsgID,
@@ -609,10 +619,11 @@ func (c *Client) DebuggerListen(ctx context.Context, opts *ListenOptions) (*List
 	})
 	if err != nil {
 		// Check for conflict error
-		if strings.Contains(err.Error(), "conflict") {
+		var apiErr *APIError
+		if errors.As(err, &apiErr) && apiErr.IsConflict() {
 			return &ListenResult{
 				Conflict: &ListenerConflict{
-					ConflictText: err.Error(),
+					ConflictText: listenerConflictText(apiErr),
 				},
 			}, nil
 		}
@@ -633,6 +644,15 @@ func (c *Client) DebuggerListen(ctx context.Context, opts *ListenOptions) (*List
 	return &ListenResult{Debuggee: debuggee}, nil
 }
 
+// listenerConflictText returns the server's description of a listener
+// conflict, falling back to the full error.
+func listenerConflictText(apiErr *APIError) string {
+	if apiErr.Text != "" {
+		return apiErr.Text
+	}
+	return apiErr.Error()
+}
+
 // DebuggerCheckListener checks if there are active debug listeners.
 // Returns nil if no listeners are active.
 func (c *Client) DebuggerCheckListener(ctx context.Context, opts *ListenOptions) (*ListenerConflict, error) {
@@ -665,13 +685,15 @@ func (c *Client) DebuggerCheckListener(ctx context.Context, opts *ListenOptions)
 		Query:  query,
 	})
 	if err != nil {
-		// 404 = no listeners active
-		if strings.Contains(err.Error(), "404") {
-			return nil, nil
-		}
-		// Conflict detected
-		if strings.Contains(err.Error(), "conflict") || strings.Contains(err.Error(), "409") {
-			return &ListenerConflict{ConflictText: err.Error()}, nil
+		var apiErr *APIError
+		if errors.As(err, &apiErr) {
+			// 404 = no listeners active
+			if apiErr.IsNotFound() {
+				return nil, nil
+			}
+			if apiErr.IsConflict() {
+				return &ListenerConflict{ConflictText: listenerConflictText(apiErr)}, nil
+			}
 		}
 		return nil, fmt.Errorf("check listener failed: %w", err)
 	}
@@ -906,6 +928,9 @@ type DebugStackEntry struct {
 	SystemProgram bool   `json:"systemProgram"`
 	IsVit         bool   `json:"isVit"`
 	URI           string `json:"uri"`
+	// Variables holds the frame's locals; only filled by
+	// DebuggerGetStackWithVariables.
+	Variables []DebugVariable `json:"variables,omitempty"`
 }
 
 // DebugStackInfo contains the call stack information.
@@ -957,6 +982,10 @@ type DebugVariable struct {
 	IsException      bool          `json:"isException"`
 	InheritanceLevel int           `json:"inheritanceLevel,omitempty"`
 	InheritanceClass string        `json:"inheritanceClass,omitempty"`
+	// Children is only filled by DebuggerGetVariablesDeep. Complex variables
+	// below the requested depth keep their ID so they can be expanded later.
+	Children          []DebugVariable `json:"children,omitempty"`
+	ChildrenTruncated bool            `json:"childrenTruncated,omitempty"`
 }
 
 // DebugVariableHierarchy represents a parent-child relationship between variables.
@@ -996,13 +1025,19 @@ func (c *Client) DebuggerAttach(ctx context.Context, debuggeeID string, user str
 		return nil, fmt.Errorf("debugger attach failed: %w", err)
 	}
 
-	return parseAttachResponse(resp.Body)
+	result, err := parseAttachResponse(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	c.debugAttached.Store(true)
+	return result, nil
 }
 
 // DebuggerDetach terminates the current debug session.
 // This releases the debuggee and ends the debugging session.
 func (c *Client) DebuggerDetach(ctx context.Context) error {
 	_, err := c.DebuggerStep(ctx, DebugTerminate, "")
+	c.debugAttached.Store(false)
 	return err
 }
 
@@ -1028,6 +1063,15 @@ func (c *Client) DebuggerStep(ctx context.Context, stepType DebugStepType, uri s
 	return parseStepResponse(resp.Body)
 }
 
+// DebugLineURI builds the target URI for stepRunToLine and stepJumpToLine
+// from a source URI and a line number, replacing any existing fragment.
+func DebugLineURI(uri string, line int) string {
+	if i := strings.Index(uri, "#"); i >= 0 {
+		uri = uri[:i]
+	}
+	return fmt.Sprintf("%s#start=%d", uri, line)
+}
+
 // DebuggerGetStack retrieves the current call stack.
 // semanticURIs: If true, returns semantic URIs that can be used for navigation
 func (c *Client) DebuggerGetStack(ctx context.Context, semanticURIs bool) (*DebugStackInfo, error) {
@@ -1050,6 +1094,65 @@ func (c *Client) DebuggerGetStack(ctx context.Context, semanticURIs bool) (*Debu
 	return parseStackResponse(resp.Body)
 }
 
+// maxDebugStackFramesWithVariables caps how many frames
+// DebuggerGetStackWithVariables hydrates, starting from the top of the stack.
+const maxDebugStackFramesWithVariables = 10
+
+// DebuggerGetStackWithVariables retrieves the call stack and attaches the
+// top-level variables of each frame. The debugger only exposes the variables
+// of the selected frame, so every frame is selected in turn and the original
+// cursor position is restored afterwards. Frames without a stack URI and
+// frames beyond maxDebugStackFramesWithVariables are returned without
+// variables.
+func (c *Client) DebuggerGetStackWithVariables(ctx context.Context, semanticURIs bool) (result *DebugStackInfo, err error) {
+	stack, err := c.DebuggerGetStack(ctx, semanticURIs)
+	if err != nil {
+		return nil, err
+	}
+
+	cursorURI := ""
+	for _, entry := range stack.Stack {
+		if entry.StackPosition == stack.DebugCursorStackIndex {
+			cursorURI = entry.StackURI
+		}
+	}
+
+	// Restore the cursor even when hydrating a frame fails, so the debuggee
+	// is not left on another frame. A restore failure is only reported when
+	// nothing else failed.
+	moved := false
+	defer func() {
+		if !moved || cursorURI == "" {
+			return
+		}
+		if restoreErr := c.DebuggerGoToStack(ctx, cursorURI); restoreErr != nil && err == nil {
+			result, err = nil, restoreErr
+		}
+	}()
+
+	hydrated := 0
+	for i := range stack.Stack {
+		entry := &stack.Stack[i]
+		if entry.StackURI == "" || hydrated >= maxDebugStackFramesWithVariables {
+			continue
+		}
+		moved = true
+		if err := c.DebuggerGoToStack(ctx, entry.StackURI); err != nil {
+			return nil, err
+		}
+		info, err := c.DebuggerGetChildVariables(ctx, nil)
+		if err != nil {
+			return nil, err
+		}
+		if info != nil {
+			entry.Variables = info.Variables
+		}
+		hydrated++
+	}
+
+	return stack, nil
+}
+
 // DebuggerGetVariables retrieves the values of specific variables.
 // variableIDs: List of variable IDs to retrieve (e.g., ["@ROOT", "@DATAAGING", "LV_COUNT"])
 func (c *Client) DebuggerGetVariables(ctx context.Context, variableIDs []string) ([]DebugVariable, error) {
@@ -1110,6 +1213,88 @@ func (c *Client) DebuggerGetChildVariables(ctx context.Context, parentIDs []stri
 	return parseChildVariablesResponse(resp.Body)
 }
 
+// maxDebugChildrenPerNode caps how many children DebuggerGetVariablesDeep
+// attaches to a single variable, so large internal tables stay manageable.
+const maxDebugChildrenPerNode = 100
+
+// DebuggerGetVariablesDeep retrieves variables and expands their children up
+// to depth levels (0 behaves like DebuggerGetVariables). Each level costs one
+// getChildVariables round-trip for all complex variables found on it.
+// Children beyond maxDebugChildrenPerNode are dropped and the parent is
+// marked with ChildrenTruncated.
+func (c *Client) DebuggerGetVariablesDeep(ctx context.Context, variableIDs []string, depth int) ([]DebugVariable, error) {
+	vars, err := c.DebuggerGetVariables(ctx, variableIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	level := make([]*DebugVariable, 0, len(vars))
+	for i := range vars {
+		level = append(level, &vars[i])
+	}
+
+	for d := 0; d < depth && len(level) > 0; d++ {
+		parents := make(map[string]*DebugVariable)
+		var parentIDs []string
+		for _, v := range level {
+			if v.ID == "" || !v.IsComplexType() {
+				continue
+			}
+			if _, seen := parents[v.ID]; !seen {
+				parentIDs = append(parentIDs, v.ID)
+			}
+			parents[v.ID] = v
+		}
+		if len(parentIDs) == 0 {
+			break
+		}
+
+		info, err := c.DebuggerGetChildVariables(ctx, parentIDs)
+		if err != nil {
+			return nil, err
+		}
+		if info == nil {
+			break
+		}
+
+		byID := make(map[string]DebugVariable, len(info.Variables))
+		for _, v := range info.Variables {
+			byID[v.ID] = v
+		}
+		for _, h := range info.Hierarchies {
+			parent, ok := parents[h.ParentID]
+			if !ok {
+				continue
+			}
+			child, ok := byID[h.ChildID]
+			if !ok {
+				continue
+			}
+			if len(parent.Children) >= maxDebugChildrenPerNode {
+				parent.ChildrenTruncated = true
+				continue
+			}
+			if child.Name == "" {
+				child.Name = h.ChildName
+			}
+			parent.Children = append(parent.Children, child)
+		}
+
+		// Collect the next level only after all appends, so the pointers
+		// into the children slices stay valid.
+		var next []*DebugVariable
+		for _, id := range parentIDs {
+			p := parents[id]
+			for i := range p.Children {
+				next = append(next, &p.Children[i])
+			}
+		}
+		level = next
+	}
+
+	return vars, nil
+}
+
 // DebuggerSetVariableValue modifies the value of a variable during debugging.
 // variableName: The name of the variable to modify
 // value: The new value as a string
@@ -1130,6 +1315,101 @@ func (c *Client) DebuggerSetVariableValue(ctx context.Context, variableName, val
 	return string(resp.Body), nil
 }
 
+// ErrDebuggerNotAttached is returned by debugger writes when the client is
+// not attached to a debuggee.
+var ErrDebuggerNotAttached = errors.New("debugger is not attached to a debuggee")
+
+// DebuggerSetVariable changes a variable of the attached debuggee and
+// returns the variable as re-read after the change.
+// variableID: The variable ID as returned by DebuggerGetVariables/DebuggerGetChildVariables
+// newValue: The new value as a string
+func (c *Client) DebuggerSetVariable(ctx context.Context, variableID, newValue string) (*DebugVariable, error) {
+	if !c.debugAttached.Load() {
+		return nil, ErrDebuggerNotAttached
+	}
+	if variableID == "" {
+		return nil, fmt.Errorf("variable ID is required")
+	}
+
+	if _, err := c.DebuggerSetVariableValue(ctx, variableID, newValue); err != nil {
+		return nil, err
+	}
+
+	vars, err := c.DebuggerGetVariables(ctx, []string{variableID})
+	if err != nil {
+		return nil, fmt.Errorf("re-reading variable %s: %w", variableID, err)
+	}
+	for i := range vars {
+		if strings.EqualFold(vars[i].ID, variableID) {
+			return &vars[i], nil
+		}
+	}
+	return nil, fmt.Errorf("variable %s not returned after setting it", variableID)
+}
+
+// DebugEvaluation is the result of DebuggerEvaluate.
+type DebugEvaluation struct {
+	Expression string        `json:"expression"`
+	Value      string        `json:"value"`
+	Type       string        `json:"type"`               // Declared type of the result, e.g. "I" or "TY_ORDER"
+	MetaType   DebugMetaType `json:"metaType,omitempty"` // simple, structure, table, ...
+	ID         string        `json:"id,omitempty"`       // Variable ID, for DebuggerGetChildVariables on complex results
+}
+
+// DebuggerEvaluate evaluates an ABAP expression (e.g. "lv_total * 2" or
+// "it_tab[ 1 ]-field") in the current stack frame of the attached
+// debuggee. An invalid expression returns an error carrying the debugger's
+// message.
+func (c *Client) DebuggerEvaluate(ctx context.Context, expression string) (*DebugEvaluation, error) {
+	if !c.debugAttached.Load() {
+		return nil, ErrDebuggerNotAttached
+	}
+	if strings.TrimSpace(expression) == "" {
+		return nil, fmt.Errorf("expression is required")
+	}
+
+	resp, err := c.transport.Request(ctx, "/sap/bc/adt/debugger", &RequestOptions{
+		Method:      http.MethodPost,
+		ContentType: "text/plain",
+		Accept:      "application/vnd.sap.as+xml;charset=UTF-8;dataname=com.sap.adt.debugger.Variables",
+		Query:       url.Values{"method": []string{"evaluate"}},
+		Body:        []byte(expression),
+	})
+	if err != nil {
+		var apiErr *APIError
+		if errors.As(err, &apiErr) && apiErr.Text != "" {
+			return nil, fmt.Errorf("evaluating %q: %s", expression, apiErr.Text)
+		}
+		if errors.As(err, &apiErr) && apiErr.Message != "" {
+			return nil, fmt.Errorf("evaluating %q: %s", expression, strings.TrimSpace(apiErr.Message))
+		}
+		return nil, fmt.Errorf("debugger evaluate failed: %w", err)
+	}
+
+	vars, err := parseVariablesResponse(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if len(vars) == 0 {
+		return nil, fmt.Errorf("evaluating %q: no result returned", expression)
+	}
+	v := vars[0]
+	if v.IsException {
+		return nil, fmt.Errorf("evaluating %q: %s", expression, v.Value)
+	}
+	typeName := v.DeclaredTypeName
+	if typeName == "" {
+		typeName = v.ActualTypeName
+	}
+	return &DebugEvaluation{
+		Expression: expression,
+		Value:      v.Value,
+		Type:       typeName,
+		MetaType:   v.MetaType,
+		ID:         v.ID,
+	}, nil
+}
+
 // DebuggerGoToStack navigates to a specific stack entry.
 // stackURI: The stack URI (e.g., "/sap/bc/adt/debugger/stack/type/ABAP/position/3")
 func (c *Client) DebuggerGoToStack(ctx context.Context, stackURI string) error {
@@ -2,8 +2,11 @@ package adt
 
 import (
 	"context"
+	"errors"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"strings"
 	"testing"
 )
@@ -1268,3 +1271,299 @@ func TestDebugStepTypes(t *testing.T) {
 		}
 	}
 }
+
+func TestBuildBreakpointRequestXML_WithHitCount(t *testing.T) {
+	bp := NewLineBreakpoint("/sap/bc/adt/programs/programs/ZDEMO_REPORT/source/main", 12)
+	bp.HitCount = 3
+	req := &BreakpointRequest{User: "TESTUSER", Breakpoints: []Breakpoint{bp}}
+
+	xml, err := buildBreakpointRequestXML(req)
+	if err != nil {
+		t.Fatalf("buildBreakpointRequestXML failed: %v", err)
+	}
+	if !strings.Contains(xml, `hitCount="3"`) {
+		t.Errorf("missing hitCount attribute:\n%s", xml)
+	}
+
+	resp, err := parseBreakpointResponse([]byte(`<dbg:breakpoints xmlns:dbg="http://www.sap.com/adt/debugger" xmlns:adtcore="http://www.sap.com/adt/core">
+  <breakpoint kind="line" id="KIND=0.SOURCETYPE=ABAP.MAIN_PROGRAM=ZDEMO_REPORT.LINE_NR=12" adtcore:uri="/sap/bc/adt/programs/programs/zdemo_report/source/main#start=12" hitCount="3"/>
+</dbg:breakpoints>`))
+	if err != nil {
+		t.Fatalf("parseBreakpointResponse failed: %v", err)
+	}
+	if len(resp.Breakpoints) != 1 || resp.Breakpoints[0].HitCount != 3 {
+		t.Errorf("hit count not parsed: %+v", resp.Breakpoints)
+	}
+}
+
+func TestDebuggerSetVariable(t *testing.T) {
+	type call struct {
+		method, query, body string
+	}
+	var calls []call
+	value := "41"
+	mock := &funcMockClient{doFunc: func(req *http.Request) (*http.Response, error) {
+		if strings.Contains(req.URL.Path, "discovery") {
+			r := newTestResponse("ok")
+			r.Header.Set("X-CSRF-Token", "test-token")
+			return r, nil
+		}
+		var body string
+		if req.Body != nil {
+			data, _ := io.ReadAll(req.Body)
+			body = string(data)
+		}
+		calls = append(calls, call{req.Method, req.URL.Query().Get("method"), body})
+
+		switch req.URL.Query().Get("method") {
+		case "attach":
+			return newTestResponse(`<dbg:attach xmlns:dbg="http://www.sap.com/adt/debugger" debugSessionId="DBG1" isSteppingPossible="true"/>`), nil
+		case "setVariableValue":
+			value = body
+			return newTestResponse(body), nil
+		case "getVariables":
+			return newTestResponse(`<asx:abap xmlns:asx="http://www.sap.com/abapxml" version="1.0"><asx:values><DATA>
+<STPDA_ADT_VARIABLE><ID>LV_COUNT</ID><NAME>LV_COUNT</NAME><META_TYPE>simple</META_TYPE><VALUE>` + value + `</VALUE></STPDA_ADT_VARIABLE>
+</DATA></asx:values></asx:abap>`), nil
+		}
+		return newTestResponse(""), nil
+	}}
+	cfg := NewConfig("https://sap.example.com:44300", "user", "pass")
+	client := NewClientWithTransport(cfg, NewTransportWithClient(cfg, mock))
+	ctx := context.Background()
+
+	if _, err := client.DebuggerSetVariable(ctx, "LV_COUNT", "42"); !errors.Is(err, ErrDebuggerNotAttached) {
+		t.Fatalf("expected ErrDebuggerNotAttached before attach, got %v", err)
+	}
+	if len(calls) != 0 {
+		t.Fatalf("an unattached write must not reach the server, got %+v", calls)
+	}
+
+	if _, err := client.DebuggerAttach(ctx, "DEBUGGEE1", "TESTUSER"); err != nil {
+		t.Fatalf("DebuggerAttach failed: %v", err)
+	}
+	variable, err := client.DebuggerSetVariable(ctx, "LV_COUNT", "42")
+	if err != nil {
+		t.Fatalf("DebuggerSetVariable failed: %v", err)
+	}
+	if variable.Value != "42" {
+		t.Errorf("refreshed value = %q, want 42", variable.Value)
+	}
+
+	set := calls[1]
+	if set.method != http.MethodPost || set.query != "setVariableValue" || set.body != "42" {
+		t.Errorf("unexpected set request: %+v", set)
+	}
+
+	// Detaching ends the attachment even if the terminate step fails
+	_ = client.DebuggerDetach(ctx)
+	if _, err := client.DebuggerSetVariable(ctx, "LV_COUNT", "43"); !errors.Is(err, ErrDebuggerNotAttached) {
+		t.Errorf("expected ErrDebuggerNotAttached after detach, got %v", err)
+	}
+}
+
+func TestDebuggerEvaluate(t *testing.T) {
+	var expressions []string
+	mock := &funcMockClient{doFunc: func(req *http.Request) (*http.Response, error) {
+		if strings.Contains(req.URL.Path, "discovery") {
+			r := newTestResponse("ok")
+			r.Header.Set("X-CSRF-Token", "test-token")
+			return r, nil
+		}
+		switch req.URL.Query().Get("method") {
+		case "attach":
+			return newTestResponse(`<dbg:attach xmlns:dbg="http://www.sap.com/adt/debugger" debugSessionId="DBG1" isSteppingPossible="true"/>`), nil
+		case "evaluate":
+			data, _ := io.ReadAll(req.Body)
+			expressions = append(expressions, string(data))
+			if string(data) == "lv_unknown + 1" {
+				return newMockResponse(http.StatusBadRequest, `<?xml version="1.0" encoding="utf-8"?>
+<exc:exception xmlns:exc="http://www.sap.com/abapxml/types/communicationframework">
+  <namespace id="com.sap.adt"/>
+  <type id="ExceptionEvaluation"/>
+  <message lang="EN">Field &quot;LV_UNKNOWN&quot; is unknown</message>
+</exc:exception>`, nil), nil
+			}
+			return newTestResponse(`<asx:abap xmlns:asx="http://www.sap.com/abapxml" version="1.0"><asx:values><DATA>
+<STPDA_ADT_VARIABLE><ID>@EVAL1</ID><NAME>lv_total * 2</NAME><DECLARED_TYPE_NAME>P</DECLARED_TYPE_NAME><META_TYPE>simple</META_TYPE><VALUE>84.00</VALUE></STPDA_ADT_VARIABLE>
+</DATA></asx:values></asx:abap>`), nil
+		}
+		return newTestResponse(""), nil
+	}}
+	cfg := NewConfig("https://sap.example.com:44300", "user", "pass")
+	client := NewClientWithTransport(cfg, NewTransportWithClient(cfg, mock))
+	ctx := context.Background()
+
+	if _, err := client.DebuggerEvaluate(ctx, "lv_total * 2"); !errors.Is(err, ErrDebuggerNotAttached) {
+		t.Fatalf("expected ErrDebuggerNotAttached before attach, got %v", err)
+	}
+	if _, err := client.DebuggerAttach(ctx, "DEBUGGEE1", "TESTUSER"); err != nil {
+		t.Fatalf("DebuggerAttach failed: %v", err)
+	}
+
+	result, err := client.DebuggerEvaluate(ctx, "lv_total * 2")
+	if err != nil {
+		t.Fatalf("DebuggerEvaluate failed: %v", err)
+	}
+	if result.Value != "84.00" || result.Type != "P" || result.MetaType != "simple" || result.ID != "@EVAL1" {
+		t.Errorf("unexpected evaluation: %+v", result)
+	}
+
+	_, err = client.DebuggerEvaluate(ctx, "lv_unknown + 1")
+	if err == nil || !strings.Contains(err.Error(), `Field "LV_UNKNOWN" is unknown`) {
+		t.Errorf("expected the debugger's message, got %v", err)
+	}
+	if len(expressions) != 2 || expressions[0] != "lv_total * 2" {
+		t.Errorf("evaluated expressions = %v", expressions)
+	}
+}
+
+func TestDebuggerGetVariablesDeep(t *testing.T) {
+	var childRequests []string
+	mock := &funcMockClient{doFunc: func(req *http.Request) (*http.Response, error) {
+		if strings.Contains(req.URL.Path, "discovery") {
+			r := newTestResponse("ok")
+			r.Header.Set("X-CSRF-Token", "test-token")
+			return r, nil
+		}
+		switch req.URL.Query().Get("method") {
+		case "getVariables":
+			return newTestResponse(`<asx:abap xmlns:asx="http://www.sap.com/abapxml" version="1.0"><asx:values><DATA>
+<STPDA_ADT_VARIABLE><ID>LS_ORDER</ID><NAME>LS_ORDER</NAME><META_TYPE>structure</META_TYPE></STPDA_ADT_VARIABLE>
+<STPDA_ADT_VARIABLE><ID>LV_COUNT</ID><NAME>LV_COUNT</NAME><META_TYPE>simple</META_TYPE><VALUE>3</VALUE></STPDA_ADT_VARIABLE>
+</DATA></asx:values></asx:abap>`), nil
+		case "getChildVariables":
+			data, _ := io.ReadAll(req.Body)
+			childRequests = append(childRequests, string(data))
+			if strings.Contains(string(data), "<PARENT_ID>LS_ORDER</PARENT_ID>") {
+				return newTestResponse(`<asx:abap xmlns:asx="http://www.sap.com/abapxml" version="1.0"><asx:values><DATA>
+<HIERARCHIES>
+<STPDA_ADT_VARIABLE_HIERARCHY><PARENT_ID>LS_ORDER</PARENT_ID><CHILD_ID>LS_ORDER-ID</CHILD_ID><CHILD_NAME>ID</CHILD_NAME></STPDA_ADT_VARIABLE_HIERARCHY>
+<STPDA_ADT_VARIABLE_HIERARCHY><PARENT_ID>LS_ORDER</PARENT_ID><CHILD_ID>LS_ORDER-HEADER</CHILD_ID><CHILD_NAME>HEADER</CHILD_NAME></STPDA_ADT_VARIABLE_HIERARCHY>
+</HIERARCHIES>
+<VARIABLES>
+<STPDA_ADT_VARIABLE><ID>LS_ORDER-ID</ID><NAME>ID</NAME><META_TYPE>simple</META_TYPE><VALUE>42</VALUE></STPDA_ADT_VARIABLE>
+<STPDA_ADT_VARIABLE><ID>LS_ORDER-HEADER</ID><NAME>HEADER</NAME><META_TYPE>structure</META_TYPE></STPDA_ADT_VARIABLE>
+</VARIABLES>
+</DATA></asx:values></asx:abap>`), nil
+			}
+			return newTestResponse(`<asx:abap xmlns:asx="http://www.sap.com/abapxml" version="1.0"><asx:values><DATA>
+<HIERARCHIES>
+<STPDA_ADT_VARIABLE_HIERARCHY><PARENT_ID>LS_ORDER-HEADER</PARENT_ID><CHILD_ID>LS_ORDER-HEADER-CREATED_BY</CHILD_ID><CHILD_NAME>CREATED_BY</CHILD_NAME></STPDA_ADT_VARIABLE_HIERARCHY>
+</HIERARCHIES>
+<VARIABLES>
+<STPDA_ADT_VARIABLE><ID>LS_ORDER-HEADER-CREATED_BY</ID><NAME>CREATED_BY</NAME><META_TYPE>simple</META_TYPE><VALUE>TESTUSER</VALUE></STPDA_ADT_VARIABLE>
+</VARIABLES>
+</DATA></asx:values></asx:abap>`), nil
+		}
+		return newTestResponse(""), nil
+	}}
+	cfg := NewConfig("https://sap.example.com:44300", "user", "pass")
+	client := NewClientWithTransport(cfg, NewTransportWithClient(cfg, mock))
+
+	vars, err := client.DebuggerGetVariablesDeep(context.Background(), []string{"LS_ORDER", "LV_COUNT"}, 2)
+	if err != nil {
+		t.Fatalf("DebuggerGetVariablesDeep failed: %v", err)
+	}
+	if len(childRequests) != 2 {
+		t.Fatalf("expected one getChildVariables call per level, got %d", len(childRequests))
+	}
+	if strings.Contains(childRequests[0], "LV_COUNT") {
+		t.Error("simple variables should not be expanded")
+	}
+	if len(vars) != 2 || len(vars[1].Children) != 0 {
+		t.Fatalf("unexpected top level: %+v", vars)
+	}
+	order := vars[0]
+	if len(order.Children) != 2 || order.Children[0].Value != "42" {
+		t.Fatalf("unexpected LS_ORDER children: %+v", order.Children)
+	}
+	header := order.Children[1]
+	if header.Name != "HEADER" || len(header.Children) != 1 {
+		t.Fatalf("unexpected HEADER: %+v", header)
+	}
+	if got := header.Children[0]; got.Name != "CREATED_BY" || got.Value != "TESTUSER" {
+		t.Errorf("unexpected leaf: %+v", got)
+	}
+}
+
+func TestDebuggerGetStackWithVariables(t *testing.T) {
+	position := "2"
+	var moves []string
+	mock := &funcMockClient{doFunc: func(req *http.Request) (*http.Response, error) {
+		if strings.Contains(req.URL.Path, "discovery") {
+			r := newTestResponse("ok")
+			r.Header.Set("X-CSRF-Token", "test-token")
+			return r, nil
+		}
+		if req.Method == http.MethodGet && req.URL.Path == "/sap/bc/adt/debugger/stack" {
+			return newTestResponse(`<dbg:stack xmlns:dbg="http://www.sap.com/adt/debugger" isSameSystem="true" debugCursorStackIndex="2">
+  <dbg:stackEntry stackPosition="1" stackUri="/sap/bc/adt/debugger/stack/type/ABAP/position/1" programName="ZDEMO_REPORT" eventName="START-OF-SELECTION" line="12"/>
+  <dbg:stackEntry stackPosition="2" stackUri="/sap/bc/adt/debugger/stack/type/ABAP/position/2" programName="ZCL_DEMO_ORDER=====CP" eventName="PROCESS" line="40"/>
+</dbg:stack>`), nil
+		}
+		if req.Method == http.MethodPut && strings.HasPrefix(req.URL.Path, "/sap/bc/adt/debugger/stack/type/ABAP/position/") {
+			position = strings.TrimPrefix(req.URL.Path, "/sap/bc/adt/debugger/stack/type/ABAP/position/")
+			moves = append(moves, position)
+			return newTestResponse(""), nil
+		}
+		if req.URL.Query().Get("method") == "getChildVariables" {
+			name := map[string]string{"1": "LV_REPORT_COUNT", "2": "LS_ORDER"}[position]
+			return newTestResponse(`<asx:abap xmlns:asx="http://www.sap.com/abapxml" version="1.0"><asx:values><DATA>
+<HIERARCHIES><STPDA_ADT_VARIABLE_HIERARCHY><PARENT_ID>@ROOT</PARENT_ID><CHILD_ID>` + name + `</CHILD_ID><CHILD_NAME>` + name + `</CHILD_NAME></STPDA_ADT_VARIABLE_HIERARCHY></HIERARCHIES>
+<VARIABLES><STPDA_ADT_VARIABLE><ID>` + name + `</ID><NAME>` + name + `</NAME><VALUE>frame` + position + `</VALUE></STPDA_ADT_VARIABLE></VARIABLES>
+</DATA></asx:values></asx:abap>`), nil
+		}
+		return newMockResponse(http.StatusNotFound, "", nil), nil
+	}}
+	cfg := NewConfig("https://sap.example.com:44300", "user", "pass")
+	client := NewClientWithTransport(cfg, NewTransportWithClient(cfg, mock))
+
+	stack, err := client.DebuggerGetStackWithVariables(context.Background(), true)
+	if err != nil {
+		t.Fatalf("DebuggerGetStackWithVariables failed: %v", err)
+	}
+	if len(stack.Stack) != 2 {
+		t.Fatalf("expected 2 frames, got %d", len(stack.Stack))
+	}
+	for i, want := range []string{"LV_REPORT_COUNT", "LS_ORDER"} {
+		vars := stack.Stack[i].Variables
+		if len(vars) != 1 || vars[0].Name != want || vars[0].Value != "frame"+strconv.Itoa(i+1) {
+			t.Errorf("frame %d variables = %+v", i+1, vars)
+		}
+	}
+	if position != "2" || len(moves) != 3 {
+		t.Errorf("expected the cursor restored to frame 2, moves = %v", moves)
+	}
+}
+
+func TestDebuggerGetStackWithVariables_RestoresCursorOnError(t *testing.T) {
+	position := "2"
+	mock := &funcMockClient{doFunc: func(req *http.Request) (*http.Response, error) {
+		if strings.Contains(req.URL.Path, "discovery") {
+			r := newTestResponse("ok")
+			r.Header.Set("X-CSRF-Token", "test-token")
+			return r, nil
+		}
+		if req.Method == http.MethodGet && req.URL.Path == "/sap/bc/adt/debugger/stack" {
+			return newTestResponse(`<dbg:stack xmlns:dbg="http://www.sap.com/adt/debugger" isSameSystem="true" debugCursorStackIndex="2">
+  <dbg:stackEntry stackPosition="1" stackUri="/sap/bc/adt/debugger/stack/type/ABAP/position/1" programName="ZDEMO_REPORT" eventName="START-OF-SELECTION" line="12"/>
+  <dbg:stackEntry stackPosition="2" stackUri="/sap/bc/adt/debugger/stack/type/ABAP/position/2" programName="ZCL_DEMO_ORDER=====CP" eventName="PROCESS" line="40"/>
+</dbg:stack>`), nil
+		}
+		if req.Method == http.MethodPut && strings.HasPrefix(req.URL.Path, "/sap/bc/adt/debugger/stack/type/ABAP/position/") {
+			position = strings.TrimPrefix(req.URL.Path, "/sap/bc/adt/debugger/stack/type/ABAP/position/")
+			return newTestResponse(""), nil
+		}
+		return newMockResponse(http.StatusInternalServerError, "variables not available", nil), nil
+	}}
+	cfg := NewConfig("https://sap.example.com:44300", "user", "pass")
+	client := NewClientWithTransport(cfg, NewTransportWithClient(cfg, mock))
+
+	if _, err := client.DebuggerGetStackWithVariables(context.Background(), true); err == nil {
+		t.Fatal("expected the variables error")
+	}
+	if position != "2" {
+		t.Errorf("cursor left on frame %s, want it restored to frame 2", position)
+	}
+}
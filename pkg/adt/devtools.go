@@ -1,10 +1,13 @@
 package adt
 
 import (
+	"bytes"
 	"context"
 	"encoding/base64"
 	"encoding/xml"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"os"
 	"regexp"
@@ -19,15 +22,18 @@ import (
 type SyntaxCheckResult struct {
 	URI      string `json:"uri"`
 	Line     int    `json:"line"`
-	Offset   int    `json:"offset"`
+	Offset   int    `json:"offset"`   // Column within the line
 	Severity string `json:"severity"` // E=Error, W=Warning, I=Info
 	Text     string `json:"text"`
+	CheckID  string `json:"checkId,omitempty"` // Message code reported by the check
 }
 
 // SyntaxCheck performs syntax check on ABAP source code.
 // objectURL is the ADT URL of the object (e.g., "/sap/bc/adt/programs/programs/ZTEST")
 // For class includes (e.g., "/sap/bc/adt/oo/classes/ZCL_FOO/includes/testclasses"),
 // pass the include URL directly - no /source/main suffix will be added.
+// A URL that already ends in /source/main is accepted and reduced to the
+// bare object URL.
 // content is the source code to check
 func (c *Client) SyntaxCheck(ctx context.Context, objectURL string, content string) ([]SyntaxCheckResult, error) {
 	// Build the request body
@@ -37,6 +43,7 @@ func (c *Client) SyntaxCheck(ctx context.Context, objectURL string, content stri
 	//   - For other objects, append /source/main to point to the source
 	// Using objectURL (without /source/main) for checkObject avoids exceeding
 	// SAP's URI length limit for long namespaced classes.
+	objectURL = strings.TrimSuffix(objectURL, "/source/main")
 	checkObjectURI := objectURL
 	artifactURI := objectURL
 	if !strings.Contains(objectURL, "/includes/") {
@@ -68,6 +75,11 @@ func (c *Client) SyntaxCheck(ctx context.Context, objectURL string, content stri
 }
 
 func parseSyntaxCheckResults(data []byte) ([]SyntaxCheckResult, error) {
+	results := []SyntaxCheckResult{}
+	if len(bytes.TrimSpace(data)) == 0 {
+		return results, nil
+	}
+
 	// The response uses namespace prefixes like chkrun:uri, chkrun:type, etc.
 	// Go's xml package doesn't handle namespaced attributes well, so we strip the prefix
 	xmlStr := string(data)
@@ -77,6 +89,7 @@ func parseSyntaxCheckResults(data []byte) ([]SyntaxCheckResult, error) {
 		URI       string `xml:"uri,attr"`
 		Type      string `xml:"type,attr"`
 		ShortText string `xml:"shortText,attr"`
+		Code      string `xml:"code,attr"`
 	}
 	type checkMessageList struct {
 		Messages []checkMessage `xml:"checkMessage"`
@@ -93,7 +106,6 @@ func parseSyntaxCheckResults(data []byte) ([]SyntaxCheckResult, error) {
 		return nil, fmt.Errorf("parsing syntax check response: %w", err)
 	}
 
-	var results []SyntaxCheckResult
 	lineOffsetRegex := regexp.MustCompile(`([^#]+)#start=(\d+),(\d+)`)
 
 	for _, report := range resp.Reports {
@@ -102,6 +114,7 @@ func parseSyntaxCheckResults(data []byte) ([]SyntaxCheckResult, error) {
 				URI:      msg.URI,
 				Severity: msg.Type,
 				Text:     msg.ShortText,
+				CheckID:  msg.Code,
 			}
 
 			// Parse line and offset from URI fragment
@@ -122,9 +135,10 @@ func parseSyntaxCheckResults(data []byte) ([]SyntaxCheckResult, error) {
 
 // ActivationResult represents the result of an activation.
 type ActivationResult struct {
-	Success  bool                       `json:"success"`
-	Messages []ActivationResultMessage  `json:"messages"`
-	Inactive []InactiveObject           `json:"inactive,omitempty"`
+	Success  bool                      `json:"success"`
+	Messages []ActivationResultMessage `json:"messages"`
+	Inactive []InactiveObject          `json:"inactive,omitempty"`
+	Warnings []string                  `json:"warnings,omitempty"` // Client-side notes, e.g. an unsortable batch
 }
 
 // ActivationResultMessage represents a message from activation.
@@ -137,6 +151,11 @@ type ActivationResultMessage struct {
 	ShortText      string `json:"shortText"`
 }
 
+// IsError reports whether the message has error severity.
+func (m ActivationResultMessage) IsError() bool {
+	return strings.ContainsAny(m.Type, "EAX")
+}
+
 // InactiveObject represents an inactive object.
 type InactiveObject struct {
 	URI       string `json:"uri"`
@@ -162,14 +181,24 @@ func (c *Client) Activate(ctx context.Context, objectURL string, objectName stri
 		return nil, err
 	}
 
-	body := fmt.Sprintf(`<?xml version="1.0" encoding="UTF-8"?>
+	return c.activate(ctx, []ActivationRef{{URI: objectURL, Name: objectName}})
+}
+
+// activate sends one activation request for refs.
+func (c *Client) activate(ctx context.Context, refs []ActivationRef) (*ActivationResult, error) {
+	var sb strings.Builder
+	sb.WriteString(`<?xml version="1.0" encoding="UTF-8"?>
 <adtcore:objectReferences xmlns:adtcore="http://www.sap.com/adt/core">
-  <adtcore:objectReference adtcore:uri="%s" adtcore:name="%s"/>
-</adtcore:objectReferences>`, objectURL, objectName)
+`)
+	for _, ref := range refs {
+		fmt.Fprintf(&sb, "  <adtcore:objectReference adtcore:uri=\"%s\" adtcore:name=\"%s\"/>\n",
+			xmlEscape(ref.URI), xmlEscape(strings.ToUpper(ref.Name)))
+	}
+	sb.WriteString("</adtcore:objectReferences>")
 
 	resp, err := c.transport.Request(ctx, "/sap/bc/adt/activation?method=activate&preauditRequested=true", &RequestOptions{
 		Method:      http.MethodPost,
-		Body:        []byte(body),
+		Body:        []byte(sb.String()),
 		ContentType: "application/xml",
 	})
 	if err != nil {
@@ -179,6 +208,14 @@ func (c *Client) Activate(ctx context.Context, objectURL string, objectName stri
 	return parseActivationResult(resp.Body)
 }
 
+// activationStartPattern extracts the line from a "#start=line,column"
+// message href fragment.
+var activationStartPattern = regexp.MustCompile(`#start=(\d+)`)
+
+// parseActivationResult collects the msg elements and inactive object
+// entries of an activation response, wherever they are nested. An empty
+// response means the activation succeeded without messages. A response that
+// is not XML is reported as a single error message.
 func parseActivationResult(data []byte) (*ActivationResult, error) {
 	result := &ActivationResult{
 		Success:  true,
@@ -187,7 +224,7 @@ func parseActivationResult(data []byte) (*ActivationResult, error) {
 	}
 
 	// If response is empty, activation was successful
-	if len(data) == 0 {
+	if len(bytes.TrimSpace(data)) == 0 {
 		return result, nil
 	}
 
@@ -201,67 +238,235 @@ func parseActivationResult(data []byte) (*ActivationResult, error) {
 			Text string `xml:"txt"`
 		} `xml:"shortText"`
 	}
-	type messages struct {
-		Msgs []msg `xml:"msg"`
-	}
-	type inactiveRef struct {
-		URI       string `xml:"uri,attr"`
-		Type      string `xml:"type,attr"`
-		Name      string `xml:"name,attr"`
-		ParentURI string `xml:"parentUri,attr"`
-	}
-	type inactiveEntry struct {
+	type entry struct {
 		Object *struct {
-			Ref inactiveRef `xml:"ref"`
+			User    string `xml:"user,attr"`
+			Deleted bool   `xml:"deleted,attr"`
+			Ref     struct {
+				URI       string `xml:"uri,attr"`
+				Type      string `xml:"type,attr"`
+				Name      string `xml:"name,attr"`
+				ParentURI string `xml:"parentUri,attr"`
+			} `xml:"ref"`
 		} `xml:"object"`
 	}
-	type inactiveObjects struct {
-		Entries []inactiveEntry `xml:"entry"`
+
+	dec := xml.NewDecoder(bytes.NewReader(data))
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			// Not an activation document; pass the raw response on
+			return &ActivationResult{
+				Messages: []ActivationResultMessage{{Type: "E", ShortText: string(data)}},
+				Inactive: []InactiveObject{},
+			}, nil
+		}
+		start, ok := tok.(xml.StartElement)
+		if !ok {
+			continue
+		}
+
+		switch start.Name.Local {
+		case "msg":
+			var m msg
+			if err := dec.DecodeElement(&m, &start); err != nil {
+				return nil, fmt.Errorf("parsing activation message: %w", err)
+			}
+			line := m.Line
+			if match := activationStartPattern.FindStringSubmatch(m.Href); match != nil && line == 0 {
+				line, _ = strconv.Atoi(match[1])
+			}
+			message := ActivationResultMessage{
+				ObjDescr:       m.ObjDescr,
+				Type:           m.Type,
+				Line:           line,
+				Href:           m.Href,
+				ForceSupported: m.ForceSupported,
+				ShortText:      m.ShortText.Text,
+			}
+			if message.IsError() {
+				result.Success = false
+			}
+			result.Messages = append(result.Messages, message)
+		case "entry":
+			var e entry
+			if err := dec.DecodeElement(&e, &start); err != nil {
+				return nil, fmt.Errorf("parsing inactive dependent: %w", err)
+			}
+			if e.Object == nil {
+				continue
+			}
+			result.Success = false
+			result.Inactive = append(result.Inactive, InactiveObject{
+				URI:       e.Object.Ref.URI,
+				Type:      e.Object.Ref.Type,
+				Name:      e.Object.Ref.Name,
+				ParentURI: e.Object.Ref.ParentURI,
+				User:      e.Object.User,
+				Deleted:   e.Object.Deleted,
+			})
+		}
+	}
+
+	return result, nil
+}
+
+// ErrActivationFailed is wrapped by ActivateObjects when the system reports
+// error-severity messages.
+var ErrActivationFailed = errors.New("activation failed")
+
+// ErrInactiveDependents is wrapped by ActivateObjects when the objects could
+// not be activated alone because inactive objects still depend on them.
+var ErrInactiveDependents = errors.New("inactive dependents must be activated together")
+
+// ActivationRef identifies an object to activate.
+type ActivationRef struct {
+	URI  string `json:"uri"`  // e.g. "/sap/bc/adt/oo/classes/zcl_demo"
+	Name string `json:"name"` // e.g. "ZCL_DEMO"
+}
+
+// ActivateObjectsOptions configures ActivateObjectsWithOptions.
+type ActivateObjectsOptions struct {
+	// SortByDependencies reorders CDS DDL sources so that base views are
+	// activated before the views selecting from them. Other objects keep
+	// their position relative to each other.
+	SortByDependencies bool
+}
+
+// ActivateObjects activates several objects in one request.
+// Warnings are returned in the result without failing. The error wraps
+// ErrActivationFailed when error messages were reported, or
+// ErrInactiveDependents when the system asks for inactive dependents to be
+// activated too; in both cases the result is still returned so callers can
+// inspect the messages or re-run with result.Inactive added.
+func (c *Client) ActivateObjects(ctx context.Context, refs []ActivationRef) (*ActivationResult, error) {
+	return c.ActivateObjectsWithOptions(ctx, refs, nil)
+}
+
+// ActivateObjectsWithOptions is ActivateObjects with options. When the
+// dependency order cannot be determined (a lookup fails or the DDL sources
+// depend on each other in a cycle), the objects are activated in the given
+// order and the reason is added to result.Warnings.
+func (c *Client) ActivateObjectsWithOptions(ctx context.Context, refs []ActivationRef, opts *ActivateObjectsOptions) (*ActivationResult, error) {
+	if err := c.checkSafety(OpActivate, "ActivateObjects"); err != nil {
+		return nil, err
 	}
-	type response struct {
-		Messages messages        `xml:"messages"`
-		Inactive inactiveObjects `xml:"inactiveObjects"`
+	if len(refs) == 0 {
+		return &ActivationResult{Success: true, Messages: []ActivationResultMessage{}, Inactive: []InactiveObject{}}, nil
 	}
 
-	var resp response
-	if err := xml.Unmarshal(data, &resp); err != nil {
-		// If parsing fails, try to extract any error message
-		result.Success = false
-		result.Messages = append(result.Messages, ActivationResultMessage{
-			Type:      "E",
-			ShortText: string(data),
-		})
-		return result, nil
+	var warnings []string
+	if opts != nil && opts.SortByDependencies {
+		sorted, err := c.sortDDLSByDependencies(ctx, refs)
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("activating in the given order: %v", err))
+		} else {
+			refs = sorted
+		}
 	}
 
-	for _, m := range resp.Messages.Msgs {
-		result.Messages = append(result.Messages, ActivationResultMessage{
-			ObjDescr:       m.ObjDescr,
-			Type:           m.Type,
-			Line:           m.Line,
-			Href:           m.Href,
-			ForceSupported: m.ForceSupported,
-			ShortText:      m.ShortText.Text,
-		})
-		// Check for errors
-		if strings.ContainsAny(m.Type, "EAX") {
-			result.Success = false
+	result, err := c.activate(ctx, refs)
+	if err != nil {
+		return nil, err
+	}
+	result.Warnings = warnings
+
+	var errs []string
+	for _, m := range result.Messages {
+		if m.IsError() {
+			errs = append(errs, m.ShortText)
+		}
+	}
+	if len(errs) > 0 {
+		return result, fmt.Errorf("%w: %s", ErrActivationFailed, strings.Join(errs, "; "))
+	}
+	if len(result.Inactive) > 0 {
+		names := make([]string, len(result.Inactive))
+		for i, d := range result.Inactive {
+			names[i] = d.Name
 		}
+		return result, fmt.Errorf("%w: %s", ErrInactiveDependents, strings.Join(names, ", "))
 	}
+	return result, nil
+}
 
-	for _, entry := range resp.Inactive.Entries {
-		if entry.Object != nil {
-			result.Success = false
-			result.Inactive = append(result.Inactive, InactiveObject{
-				URI:       entry.Object.Ref.URI,
-				Type:      entry.Object.Ref.Type,
-				Name:      entry.Object.Ref.Name,
-				ParentURI: entry.Object.Ref.ParentURI,
-			})
+// sortDDLSByDependencies orders the DDL sources among refs so that each
+// comes after the DDL sources it selects from. Only dependencies within the
+// batch count. The DDL sources fill the slots they held in refs, so other
+// objects are not moved.
+func (c *Client) sortDDLSByDependencies(ctx context.Context, refs []ActivationRef) ([]ActivationRef, error) {
+	var slots []int
+	index := map[string]int{} // DDLS name -> position in slots
+	for i, ref := range refs {
+		if strings.Contains(strings.ToLower(ref.URI), "/ddic/ddl/sources/") {
+			index[strings.ToUpper(ref.Name)] = len(slots)
+			slots = append(slots, i)
+		}
+	}
+	if len(slots) < 2 {
+		return refs, nil
+	}
+
+	// deps[i] lists the batch DDLS that slots[i] depends on.
+	deps := make([][]int, len(slots))
+	for i, slot := range slots {
+		root, err := c.GetCDSDependencies(ctx, refs[slot].Name, CDSDependencyOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("resolving dependencies of %s: %w", strings.ToUpper(refs[slot].Name), err)
+		}
+		for _, dep := range root.FlattenDependencies()[1:] {
+			j, ok := index[strings.ToUpper(dep.Name)]
+			if ok && j != i {
+				deps[i] = append(deps[i], j)
+			}
 		}
 	}
 
-	return result, nil
+	// Kahn's algorithm, always picking the earliest ready DDLS so the given
+	// order is kept where dependencies allow.
+	pending := make([]int, len(slots))
+	dependents := make([][]int, len(slots))
+	for i, ds := range deps {
+		pending[i] = len(ds)
+		for _, j := range ds {
+			dependents[j] = append(dependents[j], i)
+		}
+	}
+	done := make([]bool, len(slots))
+	order := make([]int, 0, len(slots))
+	for len(order) < len(slots) {
+		next := -1
+		for i := range slots {
+			if !done[i] && pending[i] == 0 {
+				next = i
+				break
+			}
+		}
+		if next < 0 {
+			var cyclic []string
+			for i, slot := range slots {
+				if !done[i] {
+					cyclic = append(cyclic, strings.ToUpper(refs[slot].Name))
+				}
+			}
+			return nil, fmt.Errorf("dependency cycle between %s", strings.Join(cyclic, ", "))
+		}
+		done[next] = true
+		order = append(order, next)
+		for _, d := range dependents[next] {
+			pending[d]--
+		}
+	}
+
+	sorted := make([]ActivationRef, len(refs))
+	copy(sorted, refs)
+	for k, i := range order {
+		sorted[slots[k]] = refs[slots[i]]
+	}
+	return sorted, nil
 }
 
 // GetInactiveObjects retrieves all inactive objects for the current user.
@@ -279,7 +484,8 @@ func (c *Client) GetInactiveObjects(ctx context.Context) ([]InactiveObjectRecord
 }
 
 func parseInactiveObjects(data []byte) ([]InactiveObjectRecord, error) {
-	if len(data) == 0 {
+	// Some systems answer an empty list with an empty (or blank) body
+	if len(bytes.TrimSpace(data)) == 0 {
 		return []InactiveObjectRecord{}, nil
 	}
 
@@ -312,7 +518,7 @@ func parseInactiveObjects(data []byte) ([]InactiveObjectRecord, error) {
 		return nil, fmt.Errorf("parsing inactive objects: %w", err)
 	}
 
-	var results []InactiveObjectRecord
+	results := []InactiveObjectRecord{}
 	for _, e := range resp.Entries {
 		record := InactiveObjectRecord{}
 		if e.Object != nil {
@@ -341,6 +547,23 @@ func parseInactiveObjects(data []byte) ([]InactiveObjectRecord, error) {
 	return results, nil
 }
 
+// ActivationRef returns the reference ActivateObjects needs for the object.
+func (o InactiveObject) ActivationRef() ActivationRef {
+	return ActivationRef{URI: o.URI, Name: o.Name}
+}
+
+// InactiveActivationRefs turns GetInactiveObjects records into activation
+// references, skipping entries that only describe a transport.
+func InactiveActivationRefs(records []InactiveObjectRecord) []ActivationRef {
+	refs := []ActivationRef{}
+	for _, rec := range records {
+		if rec.Object != nil && rec.Object.URI != "" {
+			refs = append(refs, rec.Object.ActivationRef())
+		}
+	}
+	return refs
+}
+
 // --- Batch Activation ---
 
 // ActivatePackageResult represents the result of batch activation.
@@ -1,6 +1,13 @@
 package adt
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
 	"testing"
 )
 
@@ -104,3 +111,359 @@ func TestParseInactiveObjectsEmptyResponse(t *testing.T) {
 		t.Errorf("expected 0 entries, got %d", len(result))
 	}
 }
+
+const mixedActivationResponse = `<?xml version="1.0" encoding="utf-8"?>
+<chkl:messages xmlns:chkl="http://www.sap.com/abapxml/checklist">
+  <msg objDescr="Class ZCL_DEMO_ORDER" type="W" line="0" href="/sap/bc/adt/oo/classes/zcl_demo_order/source/main#start=14,4" forceSupported="true">
+    <shortText><txt>Variable LV_UNUSED is not used</txt></shortText>
+  </msg>
+  <msg objDescr="Program ZDEMO_REPORT" type="E" line="7" href="/sap/bc/adt/programs/programs/zdemo_report/source/main#start=7,2" forceSupported="false">
+    <shortText><txt>Field "LV_TOTL" is unknown.</txt></shortText>
+  </msg>
+  <msg objDescr="Program ZDEMO_REPORT" type="I" href="/sap/bc/adt/programs/programs/zdemo_report">
+    <shortText><txt>Check the program</txt></shortText>
+  </msg>
+</chkl:messages>`
+
+func TestParseActivationResult_MixedSeverities(t *testing.T) {
+	result, err := parseActivationResult([]byte(mixedActivationResponse))
+	if err != nil {
+		t.Fatalf("parseActivationResult failed: %v", err)
+	}
+
+	want := []ActivationResultMessage{
+		{ObjDescr: "Class ZCL_DEMO_ORDER", Type: "W", Line: 14, Href: "/sap/bc/adt/oo/classes/zcl_demo_order/source/main#start=14,4", ForceSupported: true, ShortText: "Variable LV_UNUSED is not used"},
+		{ObjDescr: "Program ZDEMO_REPORT", Type: "E", Line: 7, Href: "/sap/bc/adt/programs/programs/zdemo_report/source/main#start=7,2", ShortText: `Field "LV_TOTL" is unknown.`},
+		{ObjDescr: "Program ZDEMO_REPORT", Type: "I", Href: "/sap/bc/adt/programs/programs/zdemo_report", ShortText: "Check the program"},
+	}
+	if len(result.Messages) != len(want) {
+		t.Fatalf("expected %d messages, got %d: %+v", len(want), len(result.Messages), result.Messages)
+	}
+	for i := range want {
+		if result.Messages[i] != want[i] {
+			t.Errorf("message %d = %+v, want %+v", i, result.Messages[i], want[i])
+		}
+	}
+	if result.Messages[0].IsError() || !result.Messages[1].IsError() {
+		t.Error("IsError should be true for E and false for W")
+	}
+	if result.Success {
+		t.Error("Success should be false with an error message")
+	}
+	if len(result.Inactive) != 0 {
+		t.Errorf("expected no inactive objects, got %+v", result.Inactive)
+	}
+}
+
+func TestParseActivationResult_InactiveDependents(t *testing.T) {
+	data := `<?xml version="1.0" encoding="utf-8"?>
+<ioc:inactiveObjects xmlns:ioc="http://www.sap.com/abapxml/inactiveCtsObjects" xmlns:adtcore="http://www.sap.com/adt/core">
+  <ioc:entry>
+    <ioc:object ioc:user="TESTUSER">
+      <ioc:ref adtcore:uri="/sap/bc/adt/oo/interfaces/zif_demo_order" adtcore:type="INTF/OI" adtcore:name="ZIF_DEMO_ORDER"/>
+    </ioc:object>
+  </ioc:entry>
+</ioc:inactiveObjects>`
+
+	result, err := parseActivationResult([]byte(data))
+	if err != nil {
+		t.Fatalf("parseActivationResult failed: %v", err)
+	}
+	if len(result.Inactive) != 1 || result.Inactive[0].Name != "ZIF_DEMO_ORDER" || result.Inactive[0].User != "TESTUSER" {
+		t.Errorf("unexpected inactive objects: %+v", result.Inactive)
+	}
+	if result.Success {
+		t.Error("Success should be false with inactive dependents")
+	}
+}
+
+func TestParseActivationResult_NotXML(t *testing.T) {
+	result, err := parseActivationResult([]byte("Activation aborted: <unexpected"))
+	if err != nil {
+		t.Fatalf("parseActivationResult failed: %v", err)
+	}
+	if result.Success || len(result.Messages) != 1 || !result.Messages[0].IsError() {
+		t.Errorf("expected the raw response as an error message, got %+v", result)
+	}
+}
+
+func TestActivateObjects(t *testing.T) {
+	warningOnly := `<chkl:messages xmlns:chkl="http://www.sap.com/abapxml/checklist"><msg type="W"><shortText><txt>Unused variable</txt></shortText></msg></chkl:messages>`
+	dependents := `<ioc:inactiveObjects xmlns:ioc="http://www.sap.com/abapxml/inactiveCtsObjects" xmlns:adtcore="http://www.sap.com/adt/core"><ioc:entry><ioc:object><ioc:ref adtcore:uri="/sap/bc/adt/oo/interfaces/zif_demo_order" adtcore:name="ZIF_DEMO_ORDER"/></ioc:object></ioc:entry></ioc:inactiveObjects>`
+	refs := []ActivationRef{
+		{URI: "/sap/bc/adt/oo/classes/zcl_demo_order", Name: "zcl_demo_order"},
+		{URI: "/sap/bc/adt/programs/programs/zdemo_report", Name: "ZDEMO_REPORT"},
+	}
+
+	tests := []struct {
+		name    string
+		body    string
+		wantErr error
+	}{
+		{"warnings only", warningOnly, nil},
+		{"errors", mixedActivationResponse, ErrActivationFailed},
+		{"inactive dependents", dependents, ErrInactiveDependents},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var sent string
+			mock := &funcMockClient{doFunc: func(req *http.Request) (*http.Response, error) {
+				if strings.Contains(req.URL.Path, "discovery") {
+					r := newTestResponse("ok")
+					r.Header.Set("X-CSRF-Token", "test-token")
+					return r, nil
+				}
+				if req.Body != nil {
+					data, _ := io.ReadAll(req.Body)
+					sent = string(data)
+				}
+				return newTestResponse(tt.body), nil
+			}}
+			cfg := NewConfig("https://sap.example.com:44300", "user", "pass")
+			client := NewClientWithTransport(cfg, NewTransportWithClient(cfg, mock))
+
+			result, err := client.ActivateObjects(context.Background(), refs)
+			if tt.wantErr == nil && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected %v, got %v", tt.wantErr, err)
+			}
+			if result == nil || len(result.Messages)+len(result.Inactive) == 0 {
+				t.Fatalf("expected the parsed result to be returned, got %+v", result)
+			}
+			if !strings.Contains(sent, `adtcore:name="ZCL_DEMO_ORDER"`) || !strings.Contains(sent, `adtcore:uri="/sap/bc/adt/programs/programs/zdemo_report"`) {
+				t.Errorf("request body missing object references:\n%s", sent)
+			}
+		})
+	}
+}
+
+func TestGetInactiveObjects_FeedsActivateObjects(t *testing.T) {
+	data := `<?xml version="1.0" encoding="utf-8"?>
+<ioc:inactiveObjects xmlns:ioc="http://www.sap.com/abapxml/inactiveCtsObjects" xmlns:adtcore="http://www.sap.com/adt/core">
+  <ioc:entry>
+    <ioc:object ioc:user="TESTUSER" ioc:deleted="false">
+      <ioc:ref adtcore:uri="/sap/bc/adt/oo/classes/zcl_demo_order" adtcore:type="CLAS/OC" adtcore:name="ZCL_DEMO_ORDER" adtcore:parentUri="/sap/bc/adt/packages/%24zdemo"/>
+    </ioc:object>
+    <ioc:transport ioc:user="TESTUSER">
+      <ioc:ref adtcore:uri="/sap/bc/adt/cts/transportrequests/TR-EXAMPLE" adtcore:type="/RQ" adtcore:name="TR-EXAMPLE"/>
+    </ioc:transport>
+  </ioc:entry>
+  <ioc:entry>
+    <ioc:object ioc:user="TESTUSER" ioc:deleted="false">
+      <ioc:ref adtcore:uri="/sap/bc/adt/programs/programs/zdemo_report" adtcore:type="PROG/P" adtcore:name="ZDEMO_REPORT"/>
+    </ioc:object>
+  </ioc:entry>
+  <ioc:entry>
+    <ioc:transport ioc:user="TESTUSER">
+      <ioc:ref adtcore:uri="/sap/bc/adt/cts/transportrequests/TR-EXAMPLE" adtcore:type="/RQ" adtcore:name="TR-EXAMPLE"/>
+    </ioc:transport>
+  </ioc:entry>
+  <ioc:entry>
+    <ioc:object ioc:user="TESTUSER" ioc:deleted="true">
+      <ioc:ref adtcore:uri="/sap/bc/adt/oo/interfaces/zif_demo_order" adtcore:type="INTF/OI" adtcore:name="ZIF_DEMO_ORDER"/>
+    </ioc:object>
+  </ioc:entry>
+</ioc:inactiveObjects>`
+
+	mock := &methodPathMock{routes: []routedResponse{
+		resp(http.MethodGet, "/activation/inactiveobjects", http.StatusOK, data),
+	}}
+	cfg := NewConfig("https://sap.example.com:44300", "user", "pass")
+	client := NewClientWithTransport(cfg, NewTransportWithClient(cfg, mock))
+
+	records, err := client.GetInactiveObjects(context.Background())
+	if err != nil {
+		t.Fatalf("GetInactiveObjects failed: %v", err)
+	}
+	if len(records) != 4 {
+		t.Fatalf("expected 4 entries, got %d", len(records))
+	}
+	if obj := records[0].Object; obj == nil || obj.User != "TESTUSER" || obj.Type != "CLAS/OC" {
+		t.Errorf("unexpected first object: %+v", obj)
+	}
+	if !records[3].Object.Deleted {
+		t.Error("expected the interface entry to be marked deleted")
+	}
+
+	refs := InactiveActivationRefs(records)
+	want := []ActivationRef{
+		{URI: "/sap/bc/adt/oo/classes/zcl_demo_order", Name: "ZCL_DEMO_ORDER"},
+		{URI: "/sap/bc/adt/programs/programs/zdemo_report", Name: "ZDEMO_REPORT"},
+		{URI: "/sap/bc/adt/oo/interfaces/zif_demo_order", Name: "ZIF_DEMO_ORDER"},
+	}
+	if len(refs) != len(want) {
+		t.Fatalf("expected %d refs, got %+v", len(want), refs)
+	}
+	for i := range want {
+		if refs[i] != want[i] {
+			t.Errorf("ref %d = %+v, want %+v", i, refs[i], want[i])
+		}
+	}
+}
+
+func TestParseInactiveObjectsBlankResponse(t *testing.T) {
+	result, err := parseInactiveObjects([]byte("\r\n  "))
+	if err != nil {
+		t.Fatalf("parseInactiveObjects failed: %v", err)
+	}
+	if result == nil || len(result) != 0 {
+		t.Errorf("expected an empty, non-nil list, got %#v", result)
+	}
+}
+
+func TestSyntaxCheck(t *testing.T) {
+	const className = "/DEMO/CL_DEMO_VERY_LONG_NAMESPACED_CLASS"
+	objectURL := "/sap/bc/adt/oo/classes/%2fdemo%2fcl_demo_very_long_namespaced_class"
+
+	clean := `<?xml version="1.0" encoding="utf-8"?>
+<chkrun:checkRunReports xmlns:chkrun="http://www.sap.com/adt/checkrun">
+  <chkrun:checkReport chkrun:reporter="abapCheckRun" chkrun:triggeringUri="` + objectURL + `" chkrun:status="processed" chkrun:statusText="Object ` + className + ` has been checked">
+    <chkrun:checkMessageList/>
+  </chkrun:checkReport>
+</chkrun:checkRunReports>`
+	withError := `<?xml version="1.0" encoding="utf-8"?>
+<chkrun:checkRunReports xmlns:chkrun="http://www.sap.com/adt/checkrun">
+  <chkrun:checkReport chkrun:reporter="abapCheckRun" chkrun:triggeringUri="` + objectURL + `" chkrun:status="processed">
+    <chkrun:checkMessageList>
+      <chkrun:checkMessage chkrun:uri="` + objectURL + `/source/main#start=12,6" chkrun:type="E" chkrun:shortText="The field &quot;LV_TOTL&quot; is unknown." chkrun:code="MESSAGEG[Q"/>
+    </chkrun:checkMessageList>
+  </chkrun:checkReport>
+</chkrun:checkRunReports>`
+
+	tests := []struct {
+		name      string
+		inputURL  string
+		response  string
+		wantCount int
+	}{
+		{"clean", objectURL, clean, 0},
+		{"clean blank body", objectURL, "", 0},
+		{"error at line", objectURL + "/source/main", withError, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var sent string
+			mock := &funcMockClient{doFunc: func(req *http.Request) (*http.Response, error) {
+				if strings.Contains(req.URL.Path, "discovery") {
+					r := newTestResponse("ok")
+					r.Header.Set("X-CSRF-Token", "test-token")
+					return r, nil
+				}
+				data, _ := io.ReadAll(req.Body)
+				sent = string(data)
+				return newTestResponse(tt.response), nil
+			}}
+			cfg := NewConfig("https://sap.example.com:44300", "user", "pass")
+			client := NewClientWithTransport(cfg, NewTransportWithClient(cfg, mock))
+
+			results, err := client.SyntaxCheck(context.Background(), tt.inputURL, "CLASS /demo/cl_demo_very_long_namespaced_class IMPLEMENTATION.\nENDCLASS.")
+			if err != nil {
+				t.Fatalf("SyntaxCheck failed: %v", err)
+			}
+			if results == nil || len(results) != tt.wantCount {
+				t.Fatalf("expected %d messages, got %#v", tt.wantCount, results)
+			}
+
+			// The check object is the bare URI; only the artifact points at the source
+			if !strings.Contains(sent, `<chkrun:checkObject adtcore:uri="`+objectURL+`" `) {
+				t.Errorf("check object must use the bare URI, body:\n%s", sent)
+			}
+			if strings.Contains(sent, "/source/main/source/main") {
+				t.Errorf("source suffix appended twice, body:\n%s", sent)
+			}
+
+			if tt.wantCount == 0 {
+				return
+			}
+			got := results[0]
+			if got.Severity != "E" || got.Line != 12 || got.Offset != 6 || got.CheckID != "MESSAGEG[Q" {
+				t.Errorf("unexpected message: %+v", got)
+			}
+			if got.Text != `The field "LV_TOTL" is unknown.` {
+				t.Errorf("text = %q", got.Text)
+			}
+			if got.URI != objectURL+"/source/main" {
+				t.Errorf("uri = %q", got.URI)
+			}
+		})
+	}
+}
+
+func TestActivateObjects_SortByDependencies(t *testing.T) {
+	doubles := func(name string, deps ...string) string {
+		var sb strings.Builder
+		fmt.Fprintf(&sb, `<cds:cdstobetested xmlns:cds="http://www.sap.com/adt/cds"><cdsundertest cds_name="%s"><doublelist>`, name)
+		for _, d := range deps {
+			fmt.Fprintf(&sb, `<double double_name="%s" double_type="CDS_VIEW"/>`, d)
+		}
+		sb.WriteString(`</doublelist></cdsundertest></cds:cdstobetested>`)
+		return sb.String()
+	}
+	run := func(t *testing.T, graph map[string]string) (string, *ActivationResult) {
+		var sent string
+		mock := &funcMockClient{doFunc: func(req *http.Request) (*http.Response, error) {
+			switch {
+			case strings.Contains(req.URL.Path, "discovery"):
+				r := newTestResponse("ok")
+				r.Header.Set("X-CSRF-Token", "test-token")
+				return r, nil
+			case strings.Contains(req.URL.Path, "/testcodegen/dependencies"):
+				return newTestResponse(graph[req.URL.Query().Get("ddlsourceName")]), nil
+			}
+			data, _ := io.ReadAll(req.Body)
+			sent = string(data)
+			return newTestResponse(""), nil
+		}}
+		cfg := NewConfig("https://sap.example.com:44300", "user", "pass")
+		client := NewClientWithTransport(cfg, NewTransportWithClient(cfg, mock))
+
+		refs := []ActivationRef{
+			{URI: "/sap/bc/adt/ddic/ddl/sources/zdemo_c", Name: "ZDEMO_C"},
+			{URI: "/sap/bc/adt/programs/programs/zdemo_report", Name: "ZDEMO_REPORT"},
+			{URI: "/sap/bc/adt/ddic/ddl/sources/zdemo_b", Name: "ZDEMO_B"},
+			{URI: "/sap/bc/adt/ddic/ddl/sources/zdemo_a", Name: "ZDEMO_A"},
+		}
+		result, err := client.ActivateObjectsWithOptions(context.Background(), refs, &ActivateObjectsOptions{SortByDependencies: true})
+		if err != nil {
+			t.Fatalf("ActivateObjectsWithOptions failed: %v", err)
+		}
+		return sent, result
+	}
+	order := func(body string) string {
+		var names []string
+		for _, m := range regexp.MustCompile(`adtcore:name="([^"]+)"`).FindAllStringSubmatch(body, -1) {
+			names = append(names, m[1])
+		}
+		return strings.Join(names, ",")
+	}
+
+	t.Run("chain", func(t *testing.T) {
+		sent, result := run(t, map[string]string{
+			"ZDEMO_A": doubles("ZDEMO_A", "ZDEMO_ORDERS"),
+			"ZDEMO_B": doubles("ZDEMO_B", "ZDEMO_A"),
+			"ZDEMO_C": doubles("ZDEMO_C", "ZDEMO_B"),
+		})
+		if got := order(sent); got != "ZDEMO_A,ZDEMO_REPORT,ZDEMO_B,ZDEMO_C" {
+			t.Errorf("activation order = %s", got)
+		}
+		if len(result.Warnings) != 0 {
+			t.Errorf("unexpected warnings: %v", result.Warnings)
+		}
+	})
+
+	t.Run("cycle", func(t *testing.T) {
+		sent, result := run(t, map[string]string{
+			"ZDEMO_A": doubles("ZDEMO_A", "ZDEMO_C"),
+			"ZDEMO_B": doubles("ZDEMO_B", "ZDEMO_A"),
+			"ZDEMO_C": doubles("ZDEMO_C", "ZDEMO_B"),
+		})
+		if got := order(sent); got != "ZDEMO_C,ZDEMO_REPORT,ZDEMO_B,ZDEMO_A" {
+			t.Errorf("expected the given order on a cycle, got %s", got)
+		}
+		if len(result.Warnings) != 1 || !strings.Contains(result.Warnings[0], "cycle") {
+			t.Errorf("expected a cycle warning, got %v", result.Warnings)
+		}
+	})
+}
@@ -0,0 +1,215 @@
+package adt
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DiffOp marks a line of a DiffHunk as unchanged, added or removed.
+type DiffOp byte
+
+const (
+	DiffEqual  DiffOp = ' '
+	DiffInsert DiffOp = '+'
+	DiffDelete DiffOp = '-'
+)
+
+// DiffLine is one line of a DiffHunk.
+type DiffLine struct {
+	Op   DiffOp `json:"op"`
+	Text string `json:"text"`
+}
+
+// DiffHunk is a run of changes with surrounding context, as in a unified
+// diff. Start lines are 1-based; a side with no lines has Start set to the
+// line after which the change applies.
+type DiffHunk struct {
+	OldStart int        `json:"oldStart"`
+	OldLines int        `json:"oldLines"`
+	NewStart int        `json:"newStart"`
+	NewLines int        `json:"newLines"`
+	Lines    []DiffLine `json:"lines"`
+}
+
+// String formats the hunk in unified diff format.
+func (h DiffHunk) String() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "@@ -%d,%d +%d,%d @@\n", h.OldStart, h.OldLines, h.NewStart, h.NewLines)
+	for _, l := range h.Lines {
+		sb.WriteByte(byte(l.Op))
+		sb.WriteString(l.Text)
+		sb.WriteByte('\n')
+	}
+	return sb.String()
+}
+
+// DiffOptions controls DiffSourcesWithOptions.
+type DiffOptions struct {
+	// Context is the number of unchanged lines shown around each change.
+	Context int
+	// IgnoreCase compares lines case-insensitively outside of string
+	// literals, so that a keyword changing from "data" to "DATA" is not a
+	// difference.
+	IgnoreCase bool
+}
+
+// DefaultDiffContext is the number of context lines DiffSources uses.
+const DefaultDiffContext = 3
+
+// DiffSources computes a line-based diff between two ABAP sources with
+// DefaultDiffContext lines of context. Trailing whitespace and line ending
+// differences are ignored; case is significant.
+func DiffSources(oldSource, newSource string) []DiffHunk {
+	return DiffSourcesWithOptions(oldSource, newSource, DiffOptions{Context: DefaultDiffContext})
+}
+
+// DiffSourcesWithOptions is DiffSources with configurable context and
+// case handling. It returns nil when the sources do not differ.
+func DiffSourcesWithOptions(oldSource, newSource string, opts DiffOptions) []DiffHunk {
+	oldLines := splitSourceLines(oldSource)
+	newLines := splitSourceLines(newSource)
+	key := func(line string) string {
+		if opts.IgnoreCase {
+			return foldABAPCase(line)
+		}
+		return line
+	}
+
+	// Longest common subsequence over the normalized lines.
+	m, n := len(oldLines), len(newLines)
+	oldKeys := make([]string, m)
+	for i, l := range oldLines {
+		oldKeys[i] = key(l)
+	}
+	newKeys := make([]string, n)
+	for j, l := range newLines {
+		newKeys[j] = key(l)
+	}
+	lcs := make([][]int, m+1)
+	for i := range lcs {
+		lcs[i] = make([]int, n+1)
+	}
+	for i := m - 1; i >= 0; i-- {
+		for j := n - 1; j >= 0; j-- {
+			if oldKeys[i] == newKeys[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	// Walk the table forwards, recording each line with its position on
+	// both sides. Deletions are emitted before insertions.
+	type edit struct {
+		op       DiffOp
+		text     string
+		old, new int // 0-based positions before this line
+	}
+	var edits []edit
+	i, j := 0, 0
+	for i < m || j < n {
+		switch {
+		case i < m && j < n && oldKeys[i] == newKeys[j]:
+			edits = append(edits, edit{DiffEqual, oldLines[i], i, j})
+			i++
+			j++
+		case i < m && (j == n || lcs[i+1][j] >= lcs[i][j+1]):
+			edits = append(edits, edit{DiffDelete, oldLines[i], i, j})
+			i++
+		default:
+			edits = append(edits, edit{DiffInsert, newLines[j], i, j})
+			j++
+		}
+	}
+
+	ctx := max(opts.Context, 0)
+	var hunks []DiffHunk
+	for k := 0; k < len(edits); {
+		if edits[k].op == DiffEqual {
+			k++
+			continue
+		}
+		// Extend the hunk while the next change is within 2*ctx lines.
+		first, last := k, k
+		for next := k + 1; next < len(edits); next++ {
+			if edits[next].op == DiffEqual {
+				continue
+			}
+			if next-last-1 > 2*ctx {
+				break
+			}
+			last = next
+		}
+		start := max(first-ctx, 0)
+		end := min(last+ctx+1, len(edits))
+
+		h := DiffHunk{OldStart: edits[start].old + 1, NewStart: edits[start].new + 1}
+		for _, e := range edits[start:end] {
+			h.Lines = append(h.Lines, DiffLine{Op: e.op, Text: e.text})
+			if e.op != DiffInsert {
+				h.OldLines++
+			}
+			if e.op != DiffDelete {
+				h.NewLines++
+			}
+		}
+		if h.OldLines == 0 {
+			h.OldStart--
+		}
+		if h.NewLines == 0 {
+			h.NewStart--
+		}
+		hunks = append(hunks, h)
+		k = end
+	}
+	return hunks
+}
+
+// splitSourceLines splits source into lines without line endings or
+// trailing whitespace. A final newline does not start an extra line.
+func splitSourceLines(source string) []string {
+	if source == "" {
+		return nil
+	}
+	lines := strings.Split(strings.TrimSuffix(source, "\n"), "\n")
+	for i, l := range lines {
+		lines[i] = strings.TrimRight(l, " \t\r")
+	}
+	return lines
+}
+
+// foldABAPCase upper-cases a line except inside string literals ('...',
+// `...` and |...| templates) and comments, whose case is significant.
+func foldABAPCase(line string) string {
+	if strings.HasPrefix(line, "*") {
+		return line
+	}
+	var sb strings.Builder
+	sb.Grow(len(line))
+	var quote rune
+	for i, r := range line {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '\'' || r == '`' || r == '|':
+			quote = r
+		case r == '"':
+			sb.WriteString(line[i:])
+			return sb.String()
+		default:
+			r = toUpperASCII(r)
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
+
+func toUpperASCII(r rune) rune {
+	if r >= 'a' && r <= 'z' {
+		return r - 'a' + 'A'
+	}
+	return r
+}
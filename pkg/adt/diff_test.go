@@ -0,0 +1,144 @@
+package adt
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const diffBaseSource = `REPORT zdemo_report.
+DATA lv_count TYPE i.
+lv_count = 1.
+WRITE lv_count.
+lv_count = lv_count + 1.
+WRITE lv_count.
+lv_count = lv_count * 2.
+WRITE lv_count.
+`
+
+func TestDiffSources_Added(t *testing.T) {
+	newSource := `REPORT zdemo_report.
+DATA lv_count TYPE i.
+DATA lv_text TYPE string.
+lv_count = 1.
+WRITE lv_count.
+lv_count = lv_count + 1.
+WRITE lv_count.
+lv_count = lv_count * 2.
+WRITE lv_count.
+`
+	hunks := DiffSourcesWithOptions(diffBaseSource, newSource, DiffOptions{Context: 1})
+	want := []DiffHunk{{
+		OldStart: 2, OldLines: 2, NewStart: 2, NewLines: 3,
+		Lines: []DiffLine{
+			{DiffEqual, "DATA lv_count TYPE i."},
+			{DiffInsert, "DATA lv_text TYPE string."},
+			{DiffEqual, "lv_count = 1."},
+		},
+	}}
+	if !reflect.DeepEqual(hunks, want) {
+		t.Errorf("hunks = %+v, want %+v", hunks, want)
+	}
+}
+
+func TestDiffSources_Removed(t *testing.T) {
+	newSource := `REPORT zdemo_report.
+DATA lv_count TYPE i.
+lv_count = 1.
+WRITE lv_count.
+lv_count = lv_count * 2.
+WRITE lv_count.
+`
+	hunks := DiffSourcesWithOptions(diffBaseSource, newSource, DiffOptions{Context: 0})
+	want := []DiffHunk{{
+		OldStart: 5, OldLines: 2, NewStart: 4, NewLines: 0,
+		Lines: []DiffLine{
+			{DiffDelete, "lv_count = lv_count + 1."},
+			{DiffDelete, "WRITE lv_count."},
+		},
+	}}
+	if !reflect.DeepEqual(hunks, want) {
+		t.Errorf("hunks = %+v, want %+v", hunks, want)
+	}
+}
+
+func TestDiffSources_Modified(t *testing.T) {
+	newSource := `REPORT zdemo_report.
+DATA lv_count TYPE i.
+lv_count = 10.
+WRITE lv_count.
+lv_count = lv_count + 1.
+WRITE lv_count.
+lv_count = lv_count * 3.
+WRITE lv_count.
+`
+	// Two changes four lines apart: separate hunks with one line of
+	// context, a single hunk with the default three.
+	hunks := DiffSourcesWithOptions(diffBaseSource, newSource, DiffOptions{Context: 1})
+	want := []DiffHunk{
+		{
+			OldStart: 2, OldLines: 3, NewStart: 2, NewLines: 3,
+			Lines: []DiffLine{
+				{DiffEqual, "DATA lv_count TYPE i."},
+				{DiffDelete, "lv_count = 1."},
+				{DiffInsert, "lv_count = 10."},
+				{DiffEqual, "WRITE lv_count."},
+			},
+		},
+		{
+			OldStart: 6, OldLines: 3, NewStart: 6, NewLines: 3,
+			Lines: []DiffLine{
+				{DiffEqual, "WRITE lv_count."},
+				{DiffDelete, "lv_count = lv_count * 2."},
+				{DiffInsert, "lv_count = lv_count * 3."},
+				{DiffEqual, "WRITE lv_count."},
+			},
+		},
+	}
+	if !reflect.DeepEqual(hunks, want) {
+		t.Errorf("hunks = %+v, want %+v", hunks, want)
+	}
+
+	if hunks := DiffSources(diffBaseSource, newSource); len(hunks) != 1 {
+		t.Errorf("expected one merged hunk with default context, got %d", len(hunks))
+	} else if got := hunks[0].String(); !strings.HasPrefix(got, "@@ -1,8 +1,8 @@\n") {
+		t.Errorf("String() = %q", got)
+	}
+}
+
+func TestDiffSources_Normalization(t *testing.T) {
+	if hunks := DiffSources(diffBaseSource, "REPORT zdemo_report.  \r\n"+diffBaseSource[len("REPORT zdemo_report.\n"):]); hunks != nil {
+		t.Errorf("trailing whitespace and CRLF should not differ, got %+v", hunks)
+	}
+
+	upper := "REPORT ZDEMO_REPORT.\nWRITE 'hello'. \" say hello\n"
+	lower := "report zdemo_report.\nwrite 'hello'. \" say hello\n"
+	if hunks := DiffSources(upper, lower); len(hunks) != 1 {
+		t.Errorf("case must be significant by default, got %+v", hunks)
+	}
+	if hunks := DiffSourcesWithOptions(upper, lower, DiffOptions{IgnoreCase: true}); hunks != nil {
+		t.Errorf("IgnoreCase should ignore keyword case, got %+v", hunks)
+	}
+	if hunks := DiffSourcesWithOptions(upper, "report zdemo_report.\nwrite 'HELLO'. \" say hello\n", DiffOptions{IgnoreCase: true}); len(hunks) != 1 {
+		t.Errorf("IgnoreCase must keep literal case significant, got %+v", hunks)
+	}
+}
+
+func TestGenerateUnifiedDiff(t *testing.T) {
+	newSource := strings.Replace(diffBaseSource, "lv_count = 1.", "lv_count = 2.", 1)
+	got := generateUnifiedDiff("PROG:A", "PROG:B", diffBaseSource, newSource)
+	want := `--- PROG:A
++++ PROG:B
+@@ -1,6 +1,6 @@
+ REPORT zdemo_report.
+ DATA lv_count TYPE i.
+-lv_count = 1.
++lv_count = 2.
+ WRITE lv_count.
+ lv_count = lv_count + 1.
+ WRITE lv_count.
+`
+	if got != want {
+		t.Errorf("generateUnifiedDiff =\n%s\nwant\n%s", got, want)
+	}
+}
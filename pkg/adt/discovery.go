@@ -0,0 +1,145 @@
+package adt
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// --- ADT Discovery ---
+
+// DiscoveryCollection is one collection (endpoint) listed in the ADT
+// discovery document.
+type DiscoveryCollection struct {
+	Workspace   string   `json:"workspace"` // e.g. "Object Repository"
+	Title       string   `json:"title"`     // e.g. "Search"
+	Href        string   `json:"href"`      // e.g. "/sap/bc/adt/repository/informationsystem/search"
+	AcceptTypes []string `json:"acceptTypes,omitempty"`
+	Category    string   `json:"category,omitempty"` // Category term, e.g. "search"
+}
+
+// discoveryCache holds the parsed discovery document of a system. It is
+// read once per client and shared with its stateful sessions.
+type discoveryCache struct {
+	mu          sync.Mutex
+	collections []DiscoveryCollection
+}
+
+// GetDiscovery returns the collections of the system's ADT discovery
+// document, which tells which endpoints the release supports. The document
+// is large, so it is read once and cached for the lifetime of the client;
+// a failed read is not cached.
+func (c *Client) GetDiscovery(ctx context.Context) ([]DiscoveryCollection, error) {
+	if c.discovery == nil {
+		return c.readDiscovery(ctx)
+	}
+	c.discovery.mu.Lock()
+	defer c.discovery.mu.Unlock()
+	if c.discovery.collections != nil {
+		return c.discovery.collections, nil
+	}
+	collections, err := c.readDiscovery(ctx)
+	if err != nil {
+		return nil, err
+	}
+	c.discovery.collections = collections
+	return collections, nil
+}
+
+func (c *Client) readDiscovery(ctx context.Context) ([]DiscoveryCollection, error) {
+	resp, err := c.transport.Request(ctx, "/sap/bc/adt/discovery", &RequestOptions{
+		Method: http.MethodGet,
+		Accept: "application/atomsvc+xml",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("reading discovery: %w", err)
+	}
+	return parseDiscovery(resp.Body)
+}
+
+// discoveryHrefs maps object types to the discovery collection that serves
+// them. Function modules live below their group's collection.
+var discoveryHrefs = map[CreatableObjectType]string{
+	ObjectTypeProgram:        "/sap/bc/adt/programs/programs",
+	ObjectTypeInclude:        "/sap/bc/adt/programs/includes",
+	ObjectTypeClass:          "/sap/bc/adt/oo/classes",
+	ObjectTypeInterface:      "/sap/bc/adt/oo/interfaces",
+	ObjectTypeFunctionGroup:  "/sap/bc/adt/functions/groups",
+	ObjectTypeFunctionMod:    "/sap/bc/adt/functions/groups",
+	ObjectTypeTable:          "/sap/bc/adt/ddic/tables",
+	ObjectTypeStructure:      "/sap/bc/adt/ddic/structures",
+	ObjectTypeView:           "/sap/bc/adt/ddic/views",
+	ObjectTypePackage:        "/sap/bc/adt/packages",
+	ObjectTypeTransformation: "/sap/bc/adt/xslt/transformations",
+	ObjectTypeTypeGroup:      "/sap/bc/adt/ddic/typegroups",
+	ObjectTypeDDLS:           "/sap/bc/adt/ddic/ddl/sources",
+	ObjectTypeBDEF:           "/sap/bc/adt/bo/behaviordefinitions",
+	ObjectTypeSRVD:           "/sap/bc/adt/ddic/srvd/sources",
+	ObjectTypeSRVB:           "/sap/bc/adt/businessservices/bindings",
+}
+
+// SupportsObjectType reports whether the system's discovery document lists
+// the endpoint for objType. Use it to fail early with a clear message on
+// releases that lack an object type (e.g. RAP objects below 7.54) instead
+// of running into a 404.
+func (c *Client) SupportsObjectType(ctx context.Context, objType CreatableObjectType) (bool, error) {
+	href, ok := discoveryHrefs[objType]
+	if !ok {
+		return false, fmt.Errorf("unsupported object type: %s", objType)
+	}
+	collections, err := c.GetDiscovery(ctx)
+	if err != nil {
+		return false, err
+	}
+	for _, col := range collections {
+		if strings.TrimSuffix(col.Href, "/") == href {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func parseDiscovery(data []byte) ([]DiscoveryCollection, error) {
+	type category struct {
+		Term string `xml:"term,attr"`
+	}
+	type collection struct {
+		Href     string   `xml:"href,attr"`
+		Title    string   `xml:"title"`
+		Accept   []string `xml:"accept"`
+		Category category `xml:"category"`
+	}
+	type workspace struct {
+		Title       string       `xml:"title"`
+		Collections []collection `xml:"collection"`
+	}
+	var service struct {
+		Workspaces []workspace `xml:"workspace"`
+	}
+	if err := xml.Unmarshal(data, &service); err != nil {
+		return nil, fmt.Errorf("parsing discovery: %w", err)
+	}
+
+	collections := []DiscoveryCollection{}
+	for _, ws := range service.Workspaces {
+		for _, col := range ws.Collections {
+			var accept []string
+			for _, a := range col.Accept {
+				if a = strings.TrimSpace(a); a != "" {
+					accept = append(accept, a)
+				}
+			}
+			collections = append(collections, DiscoveryCollection{
+				Workspace:   strings.TrimSpace(ws.Title),
+				Title:       strings.TrimSpace(col.Title),
+				Href:        col.Href,
+				AcceptTypes: accept,
+				Category:    col.Category.Term,
+			})
+		}
+	}
+	return collections, nil
+}
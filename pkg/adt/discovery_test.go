@@ -0,0 +1,130 @@
+package adt
+
+import (
+	"context"
+	"testing"
+)
+
+const discoveryXML = `<?xml version="1.0" encoding="utf-8"?>
+<app:service xmlns:app="http://www.w3.org/2007/app" xmlns:atom="http://www.w3.org/2005/Atom" xmlns:adtcomp="http://www.sap.com/adt/compatibility">
+  <app:workspace>
+    <atom:title>Object Repository</atom:title>
+    <app:collection href="/sap/bc/adt/repository/informationsystem/search">
+      <atom:title>Search</atom:title>
+      <atom:category term="search" scheme="http://www.sap.com/adt/categories/respository"/>
+      <adtcomp:templateLinks>
+        <adtcomp:templateLink rel="http://www.sap.com/adt/relations/informationsystem/search/quicksearch" template="/sap/bc/adt/repository/informationsystem/search{?operation,query,maxResults}"/>
+      </adtcomp:templateLinks>
+    </app:collection>
+  </app:workspace>
+  <app:workspace>
+    <atom:title>Core Data Services</atom:title>
+    <app:collection href="/sap/bc/adt/ddic/ddl/sources">
+      <atom:title>Data Definitions</atom:title>
+      <app:accept>application/vnd.sap.adt.ddlsource+xml</app:accept>
+      <app:accept>application/vnd.sap.adt.ddlSource.v2+xml</app:accept>
+      <atom:category term="ddlsource" scheme="http://www.sap.com/adt/categories/ddic/ddlsources"/>
+    </app:collection>
+    <app:collection href="/sap/bc/adt/bo/behaviordefinitions">
+      <atom:title>Behavior Definitions</atom:title>
+      <app:accept>application/vnd.sap.adt.blues.v1+xml</app:accept>
+      <atom:category term="blues" scheme="http://www.sap.com/adt/categories/bo"/>
+    </app:collection>
+  </app:workspace>
+</app:service>`
+
+func TestParseDiscovery(t *testing.T) {
+	collections, err := parseDiscovery([]byte(discoveryXML))
+	if err != nil {
+		t.Fatalf("parseDiscovery failed: %v", err)
+	}
+	if len(collections) != 3 {
+		t.Fatalf("expected 3 collections, got %d", len(collections))
+	}
+
+	search := collections[0]
+	if search.Workspace != "Object Repository" || search.Title != "Search" ||
+		search.Href != "/sap/bc/adt/repository/informationsystem/search" || search.Category != "search" {
+		t.Errorf("unexpected search collection: %+v", search)
+	}
+	if len(search.AcceptTypes) != 0 {
+		t.Errorf("search accepts nothing, got %v", search.AcceptTypes)
+	}
+
+	ddl := collections[1]
+	if ddl.Workspace != "Core Data Services" || ddl.Category != "ddlsource" || len(ddl.AcceptTypes) != 2 ||
+		ddl.AcceptTypes[1] != "application/vnd.sap.adt.ddlSource.v2+xml" {
+		t.Errorf("unexpected DDL collection: %+v", ddl)
+	}
+}
+
+func TestClient_GetDiscovery_Cached(t *testing.T) {
+	mock := &methodPathMock{routes: []routedResponse{
+		resp("GET", "/sap/bc/adt/discovery", 200, discoveryXML),
+	}}
+	cfg := NewConfig("https://sap.example.com:44300", "user", "pass")
+	client := NewClientWithTransport(cfg, NewTransportWithClient(cfg, mock))
+
+	for i := 0; i < 2; i++ {
+		collections, err := client.GetDiscovery(context.Background())
+		if err != nil {
+			t.Fatalf("GetDiscovery failed: %v", err)
+		}
+		if len(collections) != 3 {
+			t.Fatalf("expected 3 collections, got %d", len(collections))
+		}
+	}
+	if len(mock.calls) != 1 {
+		t.Errorf("expected the discovery document to be read once, got %d requests", len(mock.calls))
+	}
+}
+
+func TestClient_SupportsObjectType(t *testing.T) {
+	// A pre-RAP system: classes and DDL sources, but no behavior definitions
+	// or service definitions.
+	legacyDiscovery := `<?xml version="1.0" encoding="utf-8"?>
+<app:service xmlns:app="http://www.w3.org/2007/app" xmlns:atom="http://www.w3.org/2005/Atom">
+  <app:workspace>
+    <atom:title>Sources</atom:title>
+    <app:collection href="/sap/bc/adt/oo/classes">
+      <atom:title>Classes</atom:title>
+      <atom:category term="classes" scheme="http://www.sap.com/adt/categories/oo"/>
+    </app:collection>
+    <app:collection href="/sap/bc/adt/ddic/ddl/sources">
+      <atom:title>Data Definitions</atom:title>
+      <atom:category term="ddlsource" scheme="http://www.sap.com/adt/categories/ddic/ddlsources"/>
+    </app:collection>
+  </app:workspace>
+</app:service>`
+	mock := &methodPathMock{routes: []routedResponse{
+		resp("GET", "/sap/bc/adt/discovery", 200, legacyDiscovery),
+	}}
+	cfg := NewConfig("https://sap.example.com:44300", "user", "pass")
+	client := NewClientWithTransport(cfg, NewTransportWithClient(cfg, mock))
+
+	tests := []struct {
+		objType CreatableObjectType
+		want    bool
+	}{
+		{ObjectTypeClass, true},
+		{ObjectTypeDDLS, true},
+		{ObjectTypeBDEF, false},
+		{ObjectTypeSRVD, false},
+	}
+	for _, tt := range tests {
+		got, err := client.SupportsObjectType(context.Background(), tt.objType)
+		if err != nil {
+			t.Fatalf("SupportsObjectType(%s) failed: %v", tt.objType, err)
+		}
+		if got != tt.want {
+			t.Errorf("SupportsObjectType(%s) = %v, want %v", tt.objType, got, tt.want)
+		}
+	}
+	if len(mock.calls) != 1 {
+		t.Errorf("expected discovery to be read once, got %d requests", len(mock.calls))
+	}
+
+	if _, err := client.SupportsObjectType(context.Background(), CreatableObjectType("XXXX/YY")); err == nil {
+		t.Error("expected an error for an unknown object type")
+	}
+}
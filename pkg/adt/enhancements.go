@@ -0,0 +1,355 @@
+package adt
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// --- Enhancement Operations ---
+
+// BAdIFilterValue is a filter condition of a BAdI implementation.
+type BAdIFilterValue struct {
+	Name     string `json:"name"`
+	Operator string `json:"operator,omitempty"` // EQ, NE, CP, ...
+	Value    string `json:"value"`
+	Value2   string `json:"value2,omitempty"` // upper bound for BT
+}
+
+// BAdIImplementationEntry is one BAdI implementation inside an
+// enhancement implementation.
+type BAdIImplementationEntry struct {
+	Name              string            `json:"name"`
+	Description       string            `json:"description,omitempty"`
+	BAdIDefinition    string            `json:"badiDefinition"`
+	ImplementingClass string            `json:"implementingClass"`
+	Active            bool              `json:"active"`
+	Default           bool              `json:"default"`
+	Example           bool              `json:"example,omitempty"`
+	Filters           []BAdIFilterValue `json:"filters,omitempty"`
+}
+
+// BAdIImplementation represents a BAdI enhancement implementation (ENHO/XHB)
+// with the enhancement spot it belongs to and its BAdI implementations.
+type BAdIImplementation struct {
+	Name            string                    `json:"name"`
+	Description     string                    `json:"description,omitempty"`
+	EnhancementSpot string                    `json:"enhancementSpot"`
+	Implementations []BAdIImplementationEntry `json:"implementations"`
+}
+
+// GetBAdIImplementation retrieves a BAdI enhancement implementation: the
+// enhancement spot it implements and, per BAdI implementation, the BAdI
+// definition, implementing class, active/default flags and filter values.
+func (c *Client) GetBAdIImplementation(ctx context.Context, implName string) (*BAdIImplementation, error) {
+	implName = strings.ToUpper(implName)
+
+	path := fmt.Sprintf("/sap/bc/adt/enhancements/enhoxhb/%s", url.PathEscape(strings.ToLower(implName)))
+	resp, err := c.transport.Request(ctx, path, &RequestOptions{
+		Method: http.MethodGet,
+		Accept: "application/vnd.sap.adt.enh.enhoxhb.v4+xml, application/xml",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("getting BAdI implementation: %w", err)
+	}
+
+	return parseBAdIImplementation(resp.Body)
+}
+
+func parseBAdIImplementation(data []byte) (*BAdIImplementation, error) {
+	// Strip namespace prefixes
+	xmlStr := string(data)
+	xmlStr = strings.ReplaceAll(xmlStr, "enho:", "")
+	xmlStr = strings.ReplaceAll(xmlStr, "adtcore:", "")
+
+	type ref struct {
+		Name string `xml:"name,attr"`
+		URI  string `xml:"uri,attr"`
+	}
+	type filterValue struct {
+		Name     string `xml:"filterName,attr"`
+		Operator string `xml:"operator,attr"`
+		Value    string `xml:"value,attr"`
+		Value2   string `xml:"value2,attr"`
+	}
+	type badiImpl struct {
+		Name              string        `xml:"name,attr"`
+		ShortText         string        `xml:"shortText,attr"`
+		Active            bool          `xml:"active,attr"`
+		Default           bool          `xml:"default,attr"`
+		Example           bool          `xml:"example,attr"`
+		BAdIDefinition    ref           `xml:"badiDefinition"`
+		ImplementingClass ref           `xml:"implementingClass"`
+		Filters           []filterValue `xml:"filterValues>filterValue"`
+	}
+	type enhoRoot struct {
+		Name        string     `xml:"name,attr"`
+		Description string     `xml:"description,attr"`
+		Spot        ref        `xml:"contentCommon>enhancementSpot"`
+		Impls       []badiImpl `xml:"contentSpecific>badiTechnology>badiImplementations>badiImplementation"`
+	}
+
+	var root enhoRoot
+	if err := xml.Unmarshal([]byte(xmlStr), &root); err != nil {
+		return nil, fmt.Errorf("parsing BAdI implementation: %w", err)
+	}
+
+	impl := &BAdIImplementation{
+		Name:            root.Name,
+		Description:     root.Description,
+		EnhancementSpot: root.Spot.Name,
+		Implementations: []BAdIImplementationEntry{},
+	}
+	for _, bi := range root.Impls {
+		entry := BAdIImplementationEntry{
+			Name:              bi.Name,
+			Description:       bi.ShortText,
+			BAdIDefinition:    bi.BAdIDefinition.Name,
+			ImplementingClass: bi.ImplementingClass.Name,
+			Active:            bi.Active,
+			Default:           bi.Default,
+			Example:           bi.Example,
+		}
+		for _, f := range bi.Filters {
+			entry.Filters = append(entry.Filters, BAdIFilterValue{
+				Name:     f.Name,
+				Operator: f.Operator,
+				Value:    f.Value,
+				Value2:   f.Value2,
+			})
+		}
+		impl.Implementations = append(impl.Implementations, entry)
+	}
+
+	return impl, nil
+}
+
+// ErrNoSourceInclude is returned (wrapped) when an object exists but has no
+// source include to read, e.g. an enhancement implementation of a
+// technology that carries no ABAP source.
+var ErrNoSourceInclude = errors.New("object has no source include")
+
+// EnhancementSpotImplementation is an enhancement implementation assigned
+// to an enhancement spot.
+type EnhancementSpotImplementation struct {
+	Name        string `json:"name"`
+	Type        string `json:"type,omitempty"` // e.g. ENHO/XHB
+	Description string `json:"description,omitempty"`
+	URI         string `json:"uri,omitempty"`
+}
+
+// EnhancementSpot represents an enhancement spot (ENHS) with its technology,
+// the composite enhancement spot it belongs to and its implementations.
+type EnhancementSpot struct {
+	Name            string                          `json:"name"`
+	Description     string                          `json:"description,omitempty"`
+	Technology      string                          `json:"technology,omitempty"` // e.g. BADI_DEF
+	CompositeSpot   string                          `json:"compositeSpot,omitempty"`
+	BAdIDefinitions []string                        `json:"badiDefinitions,omitempty"`
+	Implementations []EnhancementSpotImplementation `json:"implementations"`
+}
+
+// GetEnhancementSpot retrieves the metadata of an enhancement spot: its
+// technology, composite enhancement spot, BAdI definitions and the
+// enhancement implementations assigned to it.
+func (c *Client) GetEnhancementSpot(ctx context.Context, spotName string) (*EnhancementSpot, error) {
+	spotName = strings.ToUpper(spotName)
+
+	path := fmt.Sprintf("/sap/bc/adt/enhancements/enhsxsb/%s", url.PathEscape(spotName))
+	resp, err := c.transport.Request(ctx, path, &RequestOptions{
+		Method: http.MethodGet,
+		Accept: "application/vnd.sap.adt.enh.enhsxsb.v4+xml, application/xml",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("getting enhancement spot: %w", err)
+	}
+
+	return parseEnhancementSpot(resp.Body)
+}
+
+func parseEnhancementSpot(data []byte) (*EnhancementSpot, error) {
+	// Strip namespace prefixes
+	xmlStr := string(data)
+	xmlStr = strings.ReplaceAll(xmlStr, "enhs:", "")
+	xmlStr = strings.ReplaceAll(xmlStr, "adtcore:", "")
+
+	type ref struct {
+		Name        string `xml:"name,attr"`
+		Type        string `xml:"type,attr"`
+		Description string `xml:"description,attr"`
+		URI         string `xml:"uri,attr"`
+	}
+	type enhsRoot struct {
+		Name        string `xml:"name,attr"`
+		Description string `xml:"description,attr"`
+		Technology  string `xml:"technology,attr"`
+		Composite   ref    `xml:"compositeEnhancementSpot"`
+		BAdIDefs    []ref  `xml:"badiDefinitions>badiDefinition"`
+		Impls       []ref  `xml:"implementations>implementation"`
+	}
+
+	var root enhsRoot
+	if err := xml.Unmarshal([]byte(xmlStr), &root); err != nil {
+		return nil, fmt.Errorf("parsing enhancement spot: %w", err)
+	}
+
+	spot := &EnhancementSpot{
+		Name:            root.Name,
+		Description:     root.Description,
+		Technology:      root.Technology,
+		CompositeSpot:   root.Composite.Name,
+		Implementations: []EnhancementSpotImplementation{},
+	}
+	for _, def := range root.BAdIDefs {
+		spot.BAdIDefinitions = append(spot.BAdIDefinitions, def.Name)
+	}
+	for _, impl := range root.Impls {
+		spot.Implementations = append(spot.Implementations, EnhancementSpotImplementation{
+			Name:        impl.Name,
+			Type:        impl.Type,
+			Description: impl.Description,
+			URI:         impl.URI,
+		})
+	}
+
+	return spot, nil
+}
+
+// GetEnhancementImplementation retrieves the ABAP source of an enhancement
+// implementation. If the implementation exists but has no source include,
+// the returned error wraps ErrNoSourceInclude.
+func (c *Client) GetEnhancementImplementation(ctx context.Context, implName string) (string, error) {
+	implName = strings.ToUpper(implName)
+
+	objectPath := fmt.Sprintf("/sap/bc/adt/enhancements/enhoxhh/%s", url.PathEscape(implName))
+	resp, err := c.transport.Request(ctx, objectPath+"/source/main", &RequestOptions{
+		Method: http.MethodGet,
+		Accept: "text/plain",
+	})
+	if err != nil {
+		if IsNotFoundError(err) {
+			// Distinguish a missing object from one without a source include
+			if _, probeErr := c.transport.Request(ctx, objectPath, &RequestOptions{
+				Method: http.MethodGet,
+				Accept: "application/xml",
+			}); probeErr == nil {
+				return "", fmt.Errorf("enhancement implementation %s: %w", implName, ErrNoSourceInclude)
+			}
+		}
+		return "", fmt.Errorf("getting enhancement implementation source: %w", err)
+	}
+
+	return string(resp.Body), nil
+}
+
+// Enhancement is source code injected into an ABAP source by a source code
+// enhancement (ENHO/XHH) at an enhancement point or section.
+type Enhancement struct {
+	Name     string `json:"name"`               // enhancement implementation
+	Type     string `json:"type,omitempty"`     // e.g. ENHO/XHH
+	FullName string `json:"fullName,omitempty"` // e.g. \PR:ZDEMO_REPORT\EX:ZDEMO_EI_HOOK\EI
+	Line     int    `json:"line"`               // insertion line in the enhanced source
+	URI      string `json:"uri,omitempty"`
+	Source   string `json:"source"`
+}
+
+var enhancementPositionRegex = regexp.MustCompile(`#start=(\d+)`)
+
+// GetEnhancements retrieves the source code enhancements injected into the
+// main source of an object. contextURL is the object URL of the main
+// program for includes and may be empty otherwise.
+func (c *Client) GetEnhancements(ctx context.Context, objectURL, contextURL string) ([]Enhancement, error) {
+	opts := &RequestOptions{
+		Method: http.MethodGet,
+		Accept: "application/vnd.sap.adt.enhancements.v3+xml, application/xml",
+	}
+	if contextURL != "" {
+		opts.Query = url.Values{"context": []string{contextURL}}
+	}
+
+	resp, err := c.transport.Request(ctx, objectURL+"/source/main/enhancements/elements", opts)
+	if err != nil {
+		return nil, fmt.Errorf("getting enhancements: %w", err)
+	}
+
+	return parseEnhancements(resp.Body)
+}
+
+// GetIncludeEnhancements retrieves the source code enhancements injected
+// into an include, resolved in the context of its main program, so an
+// export of the include can carry the enhancement code along.
+func (c *Client) GetIncludeEnhancements(ctx context.Context, includeName, parentProgram string) ([]Enhancement, error) {
+	includeName = strings.ToUpper(includeName)
+
+	objectURL := fmt.Sprintf("/sap/bc/adt/programs/includes/%s", url.PathEscape(includeName))
+	contextURL := ""
+	if parentProgram != "" {
+		contextURL = fmt.Sprintf("/sap/bc/adt/programs/programs/%s", url.PathEscape(strings.ToUpper(parentProgram)))
+	}
+
+	return c.GetEnhancements(ctx, objectURL, contextURL)
+}
+
+func parseEnhancements(data []byte) ([]Enhancement, error) {
+	// Strip namespace prefixes
+	xmlStr := string(data)
+	xmlStr = strings.ReplaceAll(xmlStr, "enh:", "")
+	xmlStr = strings.ReplaceAll(xmlStr, "adtcore:", "")
+
+	type ref struct {
+		Name string `xml:"name,attr"`
+		Type string `xml:"type,attr"`
+		URI  string `xml:"uri,attr"`
+	}
+	type element struct {
+		FullName       string `xml:"fullName,attr"`
+		Implementation ref    `xml:"enhancementImplementation"`
+		Position       ref    `xml:"position"`
+		Source         struct {
+			Encoding string `xml:"encoding,attr"`
+			Text     string `xml:",chardata"`
+		} `xml:"source"`
+	}
+	type elements struct {
+		Elements []element `xml:"element"`
+	}
+
+	var root elements
+	if err := xml.Unmarshal([]byte(xmlStr), &root); err != nil {
+		return nil, fmt.Errorf("parsing enhancements: %w", err)
+	}
+
+	result := make([]Enhancement, 0, len(root.Elements))
+	for _, el := range root.Elements {
+		enh := Enhancement{
+			Name:     el.Implementation.Name,
+			Type:     el.Implementation.Type,
+			FullName: el.FullName,
+			URI:      el.Implementation.URI,
+		}
+		if m := enhancementPositionRegex.FindStringSubmatch(el.Position.URI); m != nil {
+			enh.Line, _ = strconv.Atoi(m[1])
+		}
+		// The injected source may be transported base64-encoded; plain
+		// source is kept as is even when it happens to be valid base64.
+		source := strings.TrimSpace(el.Source.Text)
+		if strings.EqualFold(el.Source.Encoding, "base64") {
+			decoded, err := base64.StdEncoding.DecodeString(source)
+			if err != nil {
+				return nil, fmt.Errorf("decoding source of %s: %w", enh.Name, err)
+			}
+			source = string(decoded)
+		}
+		enh.Source = source
+		result = append(result, enh)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,181 @@
+package adt
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+const sampleBAdIImplementationXML = `<?xml version="1.0" encoding="UTF-8"?>
+<enho:objectData xmlns:enho="http://www.sap.com/adt/enhancements/enho" xmlns:adtcore="http://www.sap.com/adt/core"
+    adtcore:name="ZDEMO_EI_ORDER" adtcore:type="ENHO/XHB" adtcore:description="Demo order BAdI implementation">
+  <enho:contentCommon enho:toolType="BADI_IMPL">
+    <enho:enhancementSpot adtcore:name="ZDEMO_ES_ORDER" adtcore:uri="/sap/bc/adt/enhancements/enhsxsb/zdemo_es_order"/>
+  </enho:contentCommon>
+  <enho:contentSpecific>
+    <enho:badiTechnology>
+      <enho:badiImplementations>
+        <enho:badiImplementation enho:name="ZDEMO_BI_ORDER_DE" enho:shortText="Germany" enho:active="true" enho:default="false">
+          <enho:badiDefinition adtcore:name="ZDEMO_BADI_ORDER"/>
+          <enho:implementingClass adtcore:name="ZCL_DEMO_ORDER_DE" adtcore:uri="/sap/bc/adt/oo/classes/zcl_demo_order_de"/>
+          <enho:filterValues>
+            <enho:filterValue enho:filterName="COUNTRY" enho:operator="EQ" enho:value="DE"/>
+          </enho:filterValues>
+        </enho:badiImplementation>
+      </enho:badiImplementations>
+    </enho:badiTechnology>
+  </enho:contentSpecific>
+</enho:objectData>`
+
+func TestParseBAdIImplementation(t *testing.T) {
+	impl, err := parseBAdIImplementation([]byte(sampleBAdIImplementationXML))
+	if err != nil {
+		t.Fatalf("parseBAdIImplementation failed: %v", err)
+	}
+	if impl.Name != "ZDEMO_EI_ORDER" || impl.EnhancementSpot != "ZDEMO_ES_ORDER" {
+		t.Errorf("unexpected header: %+v", impl)
+	}
+	if len(impl.Implementations) != 1 {
+		t.Fatalf("expected 1 implementation, got %d", len(impl.Implementations))
+	}
+	bi := impl.Implementations[0]
+	if bi.BAdIDefinition != "ZDEMO_BADI_ORDER" || bi.ImplementingClass != "ZCL_DEMO_ORDER_DE" {
+		t.Errorf("unexpected implementation: %+v", bi)
+	}
+	if !bi.Active || bi.Default {
+		t.Errorf("active/default = %v/%v, want true/false", bi.Active, bi.Default)
+	}
+	if len(bi.Filters) != 1 || bi.Filters[0].Name != "COUNTRY" || bi.Filters[0].Operator != "EQ" || bi.Filters[0].Value != "DE" {
+		t.Errorf("unexpected filters: %+v", bi.Filters)
+	}
+}
+
+const sampleEnhancementSpotXML = `<?xml version="1.0" encoding="UTF-8"?>
+<enhs:objectData xmlns:enhs="http://www.sap.com/adt/enhancements/enhs" xmlns:adtcore="http://www.sap.com/adt/core"
+    adtcore:name="ZDEMO_ES_ORDER" adtcore:type="ENHS/XSB" adtcore:description="Demo order spot" enhs:technology="BADI_DEF">
+  <enhs:compositeEnhancementSpot adtcore:name="ZDEMO_CES_SALES"/>
+  <enhs:badiDefinitions>
+    <enhs:badiDefinition adtcore:name="ZDEMO_BADI_ORDER"/>
+  </enhs:badiDefinitions>
+  <enhs:implementations>
+    <enhs:implementation adtcore:name="ZDEMO_EI_ORDER" adtcore:type="ENHO/XHB" adtcore:uri="/sap/bc/adt/enhancements/enhoxhb/zdemo_ei_order"/>
+  </enhs:implementations>
+</enhs:objectData>`
+
+func TestClient_GetEnhancementSpot(t *testing.T) {
+	mock := &methodPathMock{routes: []routedResponse{
+		resp(http.MethodGet, "/sap/bc/adt/enhancements/enhsxsb/ZDEMO_ES_ORDER", http.StatusOK, sampleEnhancementSpotXML),
+	}}
+	cfg := NewConfig("https://sap.example.com:44300", "user", "pass")
+	client := NewClientWithTransport(cfg, NewTransportWithClient(cfg, mock))
+
+	spot, err := client.GetEnhancementSpot(context.Background(), "zdemo_es_order")
+	if err != nil {
+		t.Fatalf("GetEnhancementSpot failed: %v", err)
+	}
+	if spot.Name != "ZDEMO_ES_ORDER" || spot.Technology != "BADI_DEF" || spot.CompositeSpot != "ZDEMO_CES_SALES" {
+		t.Errorf("unexpected spot header: %+v", spot)
+	}
+	if len(spot.BAdIDefinitions) != 1 || spot.BAdIDefinitions[0] != "ZDEMO_BADI_ORDER" {
+		t.Errorf("unexpected BAdI definitions: %v", spot.BAdIDefinitions)
+	}
+	if len(spot.Implementations) != 1 || spot.Implementations[0].Name != "ZDEMO_EI_ORDER" || spot.Implementations[0].Type != "ENHO/XHB" {
+		t.Errorf("unexpected implementations: %+v", spot.Implementations)
+	}
+}
+
+func TestClient_GetEnhancementImplementation(t *testing.T) {
+	const path = "/sap/bc/adt/enhancements/enhoxhh/ZDEMO_EI_HOOK"
+	source := "ENHANCEMENT 1 zdemo_ei_hook.\n  CLEAR lv_demo.\nENDENHANCEMENT."
+
+	t.Run("source", func(t *testing.T) {
+		mock := &methodPathMock{routes: []routedResponse{
+			resp(http.MethodGet, path+"/source/main", http.StatusOK, source),
+		}}
+		cfg := NewConfig("https://sap.example.com:44300", "user", "pass")
+		client := NewClientWithTransport(cfg, NewTransportWithClient(cfg, mock))
+
+		got, err := client.GetEnhancementImplementation(context.Background(), "zdemo_ei_hook")
+		if err != nil {
+			t.Fatalf("GetEnhancementImplementation failed: %v", err)
+		}
+		if got != source {
+			t.Errorf("source = %q, want %q", got, source)
+		}
+	})
+
+	t.Run("no source include", func(t *testing.T) {
+		mock := &methodPathMock{routes: []routedResponse{
+			resp(http.MethodGet, path+"/source/main", http.StatusNotFound, "not found"),
+			resp(http.MethodGet, path, http.StatusOK, "<enho:objectData/>"),
+		}}
+		cfg := NewConfig("https://sap.example.com:44300", "user", "pass")
+		client := NewClientWithTransport(cfg, NewTransportWithClient(cfg, mock))
+
+		_, err := client.GetEnhancementImplementation(context.Background(), "ZDEMO_EI_HOOK")
+		if !errors.Is(err, ErrNoSourceInclude) {
+			t.Errorf("expected ErrNoSourceInclude, got %v", err)
+		}
+	})
+
+	t.Run("missing object", func(t *testing.T) {
+		mock := &methodPathMock{}
+		cfg := NewConfig("https://sap.example.com:44300", "user", "pass")
+		client := NewClientWithTransport(cfg, NewTransportWithClient(cfg, mock))
+
+		_, err := client.GetEnhancementImplementation(context.Background(), "ZDEMO_EI_HOOK")
+		if errors.Is(err, ErrNoSourceInclude) || !IsNotFoundError(err) {
+			t.Errorf("expected plain not-found error, got %v", err)
+		}
+	})
+}
+
+func TestClient_GetIncludeEnhancements(t *testing.T) {
+	// "CLEAR lv_demo." base64-encoded, as SAP transports injected source,
+	// and a plain source that is also valid base64
+	const enhancementsXML = `<?xml version="1.0" encoding="UTF-8"?>
+<enh:elements xmlns:enh="http://www.sap.com/adt/enhancements" xmlns:adtcore="http://www.sap.com/adt/core">
+  <enh:element enh:fullName="\PR:ZDEMO_REPORT\EX:ZDEMO_EI_HOOK\EI">
+    <enh:enhancementImplementation adtcore:name="ZDEMO_EI_HOOK" adtcore:type="ENHO/XHH" adtcore:uri="/sap/bc/adt/enhancements/enhoxhh/zdemo_ei_hook"/>
+    <enh:position adtcore:uri="/sap/bc/adt/programs/includes/zdemo_report_f01/source/main#start=12,0"/>
+    <enh:source enh:encoding="base64">Q0xFQVIgbHZfZGVtby4=</enh:source>
+  </enh:element>
+  <enh:element enh:fullName="\PR:ZDEMO_REPORT\EX:ZDEMO_EI_PLAIN\EI">
+    <enh:enhancementImplementation adtcore:name="ZDEMO_EI_PLAIN" adtcore:type="ENHO/XHH" adtcore:uri="/sap/bc/adt/enhancements/enhoxhh/zdemo_ei_plain"/>
+    <enh:position adtcore:uri="/sap/bc/adt/programs/includes/zdemo_report_f01/source/main#start=20,0"/>
+    <enh:source>ENDCLASS</enh:source>
+  </enh:element>
+</enh:elements>`
+
+	mock := &mockTransportClient{responses: map[string]*http.Response{
+		"/sap/bc/adt/programs/includes/ZDEMO_REPORT_F01/source/main/enhancements/elements": newTestResponse(enhancementsXML),
+	}}
+	cfg := NewConfig("https://sap.example.com:44300", "user", "pass")
+	client := NewClientWithTransport(cfg, NewTransportWithClient(cfg, mock))
+
+	enhancements, err := client.GetIncludeEnhancements(context.Background(), "zdemo_report_f01", "zdemo_report")
+	if err != nil {
+		t.Fatalf("GetIncludeEnhancements failed: %v", err)
+	}
+	if len(enhancements) != 2 {
+		t.Fatalf("expected 2 enhancements, got %d", len(enhancements))
+	}
+	enh := enhancements[0]
+	if enh.Name != "ZDEMO_EI_HOOK" || enh.Type != "ENHO/XHH" {
+		t.Errorf("unexpected enhancement: %+v", enh)
+	}
+	if enh.Line != 12 {
+		t.Errorf("Line = %d, want 12", enh.Line)
+	}
+	if enh.Source != "CLEAR lv_demo." {
+		t.Errorf("Source = %q, want decoded injected source", enh.Source)
+	}
+	if plain := enhancements[1].Source; plain != "ENDCLASS" {
+		t.Errorf("Source = %q, want the unmarked source unchanged", plain)
+	}
+
+	if got := mock.requests[0].URL.Query().Get("context"); got != "/sap/bc/adt/programs/programs/ZDEMO_REPORT" {
+		t.Errorf("context = %q, want parent program URL", got)
+	}
+}
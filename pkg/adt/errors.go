@@ -0,0 +1,105 @@
+package adt
+
+import (
+	"encoding/xml"
+	"errors"
+	"net/http"
+	"strings"
+)
+
+// ADTError is the error Transport.Request returns for non-2xx responses.
+// Use errors.As to inspect the status code and the SAP message:
+//
+//	var adtErr *adt.ADTError
+//	if errors.As(err, &adtErr) && adtErr.StatusCode == http.StatusLocked { ... }
+type ADTError = APIError
+
+// adtException mirrors the exc:exception document ADT sends with most
+// error responses.
+type adtException struct {
+	XMLName          xml.Name `xml:"exception"`
+	Message          string   `xml:"message"`
+	LocalizedMessage string   `xml:"localizedMessage"`
+	Type             struct {
+		ID string `xml:"id,attr"`
+	} `xml:"type"`
+	Properties []struct {
+		Key   string `xml:"key,attr"`
+		Value string `xml:",chardata"`
+	} `xml:"properties>entry"`
+}
+
+// newAPIError builds the error for a failed response, filling in the SAP
+// message details when body is an ADT exception document.
+func newAPIError(statusCode int, path string, body []byte) *APIError {
+	e := &APIError{
+		StatusCode: statusCode,
+		Message:    string(body),
+		Path:       path,
+	}
+	if !strings.Contains(e.Message, "exception") {
+		return e
+	}
+
+	var exc adtException
+	if err := xml.Unmarshal(body, &exc); err != nil {
+		return e
+	}
+	e.Type = strings.TrimSpace(exc.Type.ID)
+	e.Text = strings.TrimSpace(exc.LocalizedMessage)
+	if e.Text == "" {
+		e.Text = strings.TrimSpace(exc.Message)
+	}
+	for _, p := range exc.Properties {
+		switch p.Key {
+		case "T100KEY-ID":
+			e.SAPMessageClass = strings.TrimSpace(p.Value)
+		case "T100KEY-NO":
+			e.SAPMessageNumber = strings.TrimSpace(p.Value)
+		}
+	}
+	return e
+}
+
+// IsNotFound reports whether err, or any error it wraps, is an ADTError
+// for a 404 response. It is equivalent to IsNotFoundError.
+func IsNotFound(err error) bool {
+	return IsNotFoundError(err)
+}
+
+// IsAlreadyExists reports whether err, or any error it wraps, is an
+// ADTError rejecting the creation of an object that exists already.
+func IsAlreadyExists(err error) bool {
+	var adtErr *ADTError
+	return errors.As(err, &adtErr) && adtErr.IsAlreadyExists()
+}
+
+// IsLocked reports whether err, or any error it wraps, means the object is
+// locked by someone else: an ObjectLockedError, a 423 response, or the 403
+// "currently editing" answer ADT sends for enqueue conflicts.
+func IsLocked(err error) bool {
+	if err == nil {
+		return false
+	}
+	var lockErr *ObjectLockedError
+	if errors.As(err, &lockErr) {
+		return true
+	}
+	var adtErr *ADTError
+	if !errors.As(err, &adtErr) {
+		return false
+	}
+	return adtErr.StatusCode == http.StatusLocked ||
+		(adtErr.StatusCode == http.StatusForbidden && strings.Contains(strings.ToLower(adtErr.Message), "currently editing"))
+}
+
+// IsForbidden reports whether err, or any error it wraps, is an ADTError
+// for a 403 response that is not a lock conflict (see IsLocked), such as a
+// missing authorization or a rejected CSRF token.
+func IsForbidden(err error) bool {
+	if err == nil {
+		return false
+	}
+	var adtErr *ADTError
+	return errors.As(err, &adtErr) && adtErr.StatusCode == http.StatusForbidden && !IsLocked(err)
+}
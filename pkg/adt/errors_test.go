@@ -0,0 +1,136 @@
+package adt
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+const lockedExceptionXML = `<?xml version="1.0" encoding="utf-8"?>
+<exc:exception xmlns:exc="http://www.sap.com/abapxml/types/communicationframework">
+  <namespace id="com.sap.adt"/>
+  <type id="ExceptionResourceNoAccess"/>
+  <message lang="EN">User TESTUSER is currently editing ZCL_DEMO_ORDER</message>
+  <localizedMessage lang="EN">User TESTUSER is currently editing ZCL_DEMO_ORDER</localizedMessage>
+  <properties>
+    <entry key="T100KEY-ID">EU</entry>
+    <entry key="T100KEY-NO">510</entry>
+    <entry key="T100KEY-V1">TESTUSER</entry>
+  </properties>
+</exc:exception>`
+
+func TestTransport_ADTError(t *testing.T) {
+	mock := &funcMockClient{doFunc: func(req *http.Request) (*http.Response, error) {
+		if strings.Contains(req.URL.Path, "discovery") {
+			r := newTestResponse("ok")
+			r.Header.Set("X-CSRF-Token", "test-token")
+			return r, nil
+		}
+		return newMockResponse(http.StatusLocked, lockedExceptionXML, nil), nil
+	}}
+	cfg := NewConfig("https://sap.example.com:44300", "user", "pass")
+	transport := NewTransportWithClient(cfg, mock)
+
+	_, err := transport.Request(context.Background(), "/sap/bc/adt/oo/classes/zcl_demo_order", &RequestOptions{
+		Method: http.MethodPost,
+		Query:  map[string][]string{"_action": {"LOCK"}},
+	})
+	var adtErr *ADTError
+	if !errors.As(err, &adtErr) {
+		t.Fatalf("expected *ADTError, got %T: %v", err, err)
+	}
+	if adtErr.StatusCode != http.StatusLocked || adtErr.Path != "/sap/bc/adt/oo/classes/zcl_demo_order" {
+		t.Errorf("status/path = %d %s", adtErr.StatusCode, adtErr.Path)
+	}
+	if adtErr.SAPMessageClass != "EU" || adtErr.SAPMessageNumber != "510" {
+		t.Errorf("message key = %q %q", adtErr.SAPMessageClass, adtErr.SAPMessageNumber)
+	}
+	if adtErr.Text != "User TESTUSER is currently editing ZCL_DEMO_ORDER" {
+		t.Errorf("Text = %q", adtErr.Text)
+	}
+	if adtErr.Type != "ExceptionResourceNoAccess" {
+		t.Errorf("Type = %q", adtErr.Type)
+	}
+	if !strings.Contains(err.Error(), "currently editing ZCL_DEMO_ORDER (EU 510) [ExceptionResourceNoAccess]") {
+		t.Errorf("Error() = %q", err.Error())
+	}
+}
+
+func TestNewAPIError_PlainBody(t *testing.T) {
+	e := newAPIError(http.StatusInternalServerError, "/sap/bc/adt/programs/programs/zdemo_report", []byte("<html>Internal Server Error</html>"))
+	if e.Text != "" || e.SAPMessageClass != "" {
+		t.Errorf("non-exception body parsed as %+v", e)
+	}
+	if e.Message != "<html>Internal Server Error</html>" {
+		t.Errorf("Message = %q", e.Message)
+	}
+}
+
+func TestAPIError_TypePredicates(t *testing.T) {
+	exists := newAPIError(http.StatusBadRequest, "/sap/bc/adt/oo/classes", []byte(`<exc:exception xmlns:exc="http://www.sap.com/abapxml/types/communicationframework">
+  <namespace id="com.sap.adt"/>
+  <type id="ExceptionResourceAlreadyExists"/>
+  <localizedMessage lang="DE">Klasse ZCL_DEMO_ORDER ist bereits vorhanden</localizedMessage>
+</exc:exception>`))
+	if !IsAlreadyExists(fmt.Errorf("creating class: %w", exists)) {
+		t.Error("IsAlreadyExists should match the exception type regardless of language")
+	}
+	if !strings.Contains(exists.Error(), "ExceptionResourceAlreadyExists") {
+		t.Errorf("Error() lost the exception type: %q", exists.Error())
+	}
+	if exists.IsConflict() {
+		t.Error("already-exists error reported as conflict")
+	}
+
+	conflict := newAPIError(http.StatusBadRequest, "/sap/bc/adt/debugger/listeners", []byte(`<exc:exception xmlns:exc="http://www.sap.com/abapxml/types/communicationframework">
+  <type id="conflictNotification"/>
+  <localizedMessage lang="EN">Another debugger is listening for TESTUSER</localizedMessage>
+</exc:exception>`))
+	if !conflict.IsConflict() {
+		t.Error("conflictNotification should be a conflict")
+	}
+	if IsAlreadyExists(conflict) || IsAlreadyExists(nil) {
+		t.Error("IsAlreadyExists matched a non-matching error")
+	}
+}
+
+func TestErrorPredicates(t *testing.T) {
+	notFound := &ADTError{StatusCode: http.StatusNotFound, Path: "/sap/bc/adt/oo/classes/zcl_demo_missing"}
+	locked := &ADTError{StatusCode: http.StatusLocked}
+	editing := newAPIError(http.StatusForbidden, "/sap/bc/adt/oo/classes/zcl_demo_order", []byte(lockedExceptionXML))
+	forbidden := &ADTError{StatusCode: http.StatusForbidden, Message: "No authorization for S_DEVELOP"}
+	objLocked := &ObjectLockedError{ObjectURL: "/sap/bc/adt/oo/classes/zcl_demo_order", LockedBy: "TESTUSER", Err: editing}
+
+	tests := []struct {
+		name                         string
+		err                          error
+		notFound, isLocked, isForbid bool
+	}{
+		{"nil", nil, false, false, false},
+		{"unrelated", errors.New("status 404 in a string"), false, false, false},
+		{"not found", notFound, true, false, false},
+		{"wrapped not found", fmt.Errorf("reading class: %w", notFound), true, false, false},
+		{"423", locked, false, true, false},
+		{"wrapped 423", fmt.Errorf("locking: %w", locked), false, true, false},
+		{"403 currently editing", editing, false, true, false},
+		{"ObjectLockedError", objLocked, false, true, false},
+		{"403", forbidden, false, false, true},
+		{"wrapped 403", fmt.Errorf("writing source: %w", forbidden), false, false, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsNotFound(tt.err); got != tt.notFound {
+				t.Errorf("IsNotFound = %v, want %v", got, tt.notFound)
+			}
+			if got := IsLocked(tt.err); got != tt.isLocked {
+				t.Errorf("IsLocked = %v, want %v", got, tt.isLocked)
+			}
+			if got := IsForbidden(tt.err); got != tt.isForbid {
+				t.Errorf("IsForbidden = %v, want %v", got, tt.isForbid)
+			}
+		})
+	}
+}
@@ -14,7 +14,7 @@ type ABAPFileInfo struct {
 	FilePath          string
 	ObjectType        CreatableObjectType
 	ObjectName        string
-	ParentName        string           // For function modules: the function group name
+	ParentName        string           // For function modules: the function group name; for includes: the main program
 	Description       string           // Parsed from comments if available
 	ClassIncludeType  ClassIncludeType // For class includes (testclasses, definitions, etc.)
 	HasDefinition     bool             // For classes
@@ -43,6 +43,55 @@ func extractFunctionGroupFromFilename(filePath string) string {
 	return ""
 }
 
+// extractFunctionGroupMemberFromFilename splits abapGit function group member
+// filenames into the group and the member (a function module or include).
+// Pattern: {fugr_name}.fugr.{member}.abap → (FUGR_NAME, MEMBER)
+// Example: zdemo_util.fugr.z_demo_format.abap → (ZDEMO_UTIL, Z_DEMO_FORMAT)
+// Example: #dmo#util.fugr.#dmo#format.abap → (/DMO/UTIL, /DMO/FORMAT)
+func extractFunctionGroupMemberFromFilename(filePath string) (group, member string) {
+	baseName := filepath.Base(filePath)
+	lowerName := strings.ToLower(baseName)
+	fugrIdx := strings.Index(lowerName, ".fugr.")
+	if fugrIdx <= 0 || !strings.HasSuffix(lowerName, ".abap") ||
+		fugrIdx+len(".fugr.") > len(baseName)-len(".abap") {
+		return "", ""
+	}
+	member = baseName[fugrIdx+len(".fugr.") : len(baseName)-len(".abap")]
+	if member == "" || strings.Contains(member, ".") {
+		return "", ""
+	}
+	group = strings.ReplaceAll(strings.ToUpper(baseName[:fugrIdx]), "#", "/")
+	member = strings.ReplaceAll(strings.ToUpper(member), "#", "/")
+	return group, member
+}
+
+// functionGroupPrograms returns the main program and include prefix of a
+// function group: ZDEMO → (SAPLZDEMO, LZDEMO), /DMO/UTIL → (/DMO/SAPLUTIL, /DMO/LUTIL).
+func functionGroupPrograms(group string) (mainProgram, includePrefix string) {
+	namespace, bare := "", group
+	if strings.HasPrefix(group, "/") {
+		if idx := strings.Index(group[1:], "/"); idx >= 0 {
+			namespace, bare = group[:idx+2], group[idx+2:]
+		}
+	}
+	return namespace + "SAPL" + bare, namespace + "L" + bare
+}
+
+// extractIncludeFromFilename extracts the include and its main program from
+// filenames written by SaveToFile.
+// Pattern: {prog_name}.prog.{include_name}.incl.abap → (INCLUDE_NAME, PROG_NAME)
+// Example: zdemo_report.prog.zdemo_report_top.incl.abap → (ZDEMO_REPORT_TOP, ZDEMO_REPORT)
+func extractIncludeFromFilename(filePath string) (include, parent string) {
+	baseName := filepath.Base(filePath)
+	name := baseName[:len(baseName)-len(".incl.abap")]
+	if idx := strings.Index(strings.ToLower(name), ".prog."); idx > 0 {
+		parent = strings.ReplaceAll(strings.ToUpper(name[:idx]), "#", "/")
+		name = name[idx+len(".prog."):]
+	}
+	include = strings.ReplaceAll(strings.ToUpper(name), "#", "/")
+	return include, parent
+}
+
 // extractClassNameFromFilename extracts the parent class name from abapGit-style filenames.
 // Examples:
 //   - zcl_foo.clas.testclasses.abap → ZCL_FOO
@@ -110,15 +159,46 @@ func ParseABAPFile(filePath string) (*ABAPFileInfo, error) {
 	case strings.HasSuffix(baseName, ".clas.abap"):
 		info.ObjectType = ObjectTypeClass
 		info.ClassIncludeType = ClassIncludeMain
+	// Program include exported with its parent program (must be before .abap)
+	case strings.HasSuffix(strings.ToLower(baseName), ".incl.abap"):
+		info.ObjectType = ObjectTypeInclude
+		info.ObjectName, info.ParentName = extractIncludeFromFilename(filePath)
+		info.Description = fmt.Sprintf("Include of %s", info.ParentName)
+		return info, nil
 	case strings.HasSuffix(baseName, ".prog.abap"):
 		info.ObjectType = ObjectTypeProgram
 	case strings.HasSuffix(baseName, ".intf.abap"):
 		info.ObjectType = ObjectTypeInterface
-	case strings.HasSuffix(baseName, ".fugr.abap"):
+	case strings.HasSuffix(strings.ToLower(baseName), ".fugr.abap"):
 		info.ObjectType = ObjectTypeFunctionGroup
+	case strings.HasSuffix(baseName, ".type.abap"):
+		info.ObjectType = ObjectTypeTypeGroup
 	case strings.HasSuffix(baseName, ".func.abap"):
 		info.ObjectType = ObjectTypeFunctionMod
 		info.ParentName = extractFunctionGroupFromFilename(filePath)
+	// abapGit function group members: {fugr}.fugr.{member}.abap holds either
+	// a function module or one of the group's own includes
+	case strings.Contains(strings.ToLower(baseName), ".fugr.") && ext == ".abap":
+		group, member := extractFunctionGroupMemberFromFilename(filePath)
+		if group == "" {
+			return nil, fmt.Errorf("unsupported function group filename: %s", baseName)
+		}
+		mainProgram, includePrefix := functionGroupPrograms(group)
+		switch {
+		case member == mainProgram:
+			info.ObjectType = ObjectTypeFunctionGroup
+			info.ObjectName = group
+		case strings.HasPrefix(member, includePrefix):
+			info.ObjectType = ObjectTypeInclude
+			info.ObjectName = member
+			info.ParentName = mainProgram
+			info.Description = fmt.Sprintf("Include of %s", mainProgram)
+			return info, nil
+		default:
+			info.ObjectType = ObjectTypeFunctionMod
+			info.ObjectName = member
+			info.ParentName = group
+		}
 	// RAP object types (ABAPGit-compatible extensions)
 	case strings.HasSuffix(baseName, ".ddls.asddls"):
 		info.ObjectType = ObjectTypeDDLS
@@ -126,11 +206,17 @@ func ParseABAPFile(filePath string) (*ABAPFileInfo, error) {
 		info.ObjectType = ObjectTypeBDEF
 	case strings.HasSuffix(baseName, ".srvd.srvdsrv"):
 		info.ObjectType = ObjectTypeSRVD
+	// Transformations carry no name in their XML source: take it from the filename
+	case strings.HasSuffix(strings.ToLower(baseName), ".xslt.source.xml"):
+		info.ObjectType = ObjectTypeTransformation
+		name := baseName[:len(baseName)-len(".xslt.source.xml")]
+		info.ObjectName = strings.ReplaceAll(strings.ToUpper(name), "#", "/")
+		return info, nil
 	case ext == ".abap":
 		// Generic .abap: detect from content
 		return parseFromContent(filePath)
 	default:
-		return nil, fmt.Errorf("unsupported file extension: %s (expected .clas.abap, .clas.testclasses.abap, .clas.locals_def.abap, .clas.locals_imp.abap, .prog.abap, .intf.abap, .fugr.abap, .func.abap, .ddls.asddls, .bdef.asbdef, or .srvd.srvdsrv)", ext)
+		return nil, fmt.Errorf("unsupported file extension: %s (expected .clas.abap, .clas.testclasses.abap, .clas.locals_def.abap, .clas.locals_imp.abap, .prog.abap, .intf.abap, .type.abap, .fugr.abap, .func.abap, .ddls.asddls, .bdef.asbdef, .srvd.srvdsrv, or .xslt.source.xml)", ext)
 	}
 
 	// 2. Parse file content to extract name and metadata
@@ -188,6 +274,11 @@ func ParseABAPFile(filePath string) (*ABAPFileInfo, error) {
 				info.ObjectName = name
 			}
 
+		case ObjectTypeTypeGroup:
+			if name := parseTypeGroupName(line); name != "" {
+				info.ObjectName = name
+			}
+
 		// RAP object types
 		case ObjectTypeDDLS:
 			if name := parseDDLSName(line); name != "" {
@@ -240,7 +331,7 @@ func ParseABAPFile(filePath string) (*ABAPFileInfo, error) {
 	}
 
 	if info.ObjectName == "" {
-		return nil, fmt.Errorf("could not parse object name from file (expected CLASS/PROGRAM/INTERFACE/FUNCTION GROUP/FUNCTION statement in first 200 lines)")
+		return nil, fmt.Errorf("could not parse object name from file (expected CLASS/PROGRAM/INTERFACE/FUNCTION GROUP/FUNCTION/TYPE-POOL statement in first 200 lines)")
 	}
 
 	// Provide default description if none found
@@ -339,6 +430,16 @@ func parseFunctionModuleName(line string) string {
 	return ""
 }
 
+// parseTypeGroupName extracts type group name from TYPE-POOL statement
+func parseTypeGroupName(line string) string {
+	re := regexp.MustCompile(`(?i)^\s*TYPE-POOL\s+([a-z0-9_/]+)`)
+	matches := re.FindStringSubmatch(line)
+	if len(matches) > 1 {
+		return strings.ToUpper(matches[1])
+	}
+	return ""
+}
+
 // parseDDLSName extracts CDS view name from "define view [entity] <name>" or "@AbapCatalog.viewEnhancementCategory"
 func parseDDLSName(line string) string {
 	// Pattern: define view [entity] NAME
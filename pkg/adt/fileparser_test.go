@@ -373,3 +373,95 @@ END-OF-DEFINITION.
 		t.Errorf("Expected ClassIncludeType %s, got %s", ClassIncludeMacros, info.ClassIncludeType)
 	}
 }
+
+func TestParseABAPFile_Transformation(t *testing.T) {
+	tmpDir := t.TempDir()
+	filePath := filepath.Join(tmpDir, "#dmo#st_flight.xslt.source.xml")
+
+	source := `<?sap.transform simple?>
+<tt:transform xmlns:tt="http://www.sap.com/transformation-templates"/>
+`
+	if err := os.WriteFile(filePath, []byte(source), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	info, err := ParseABAPFile(filePath)
+	if err != nil {
+		t.Fatalf("ParseABAPFile failed: %v", err)
+	}
+	if info.ObjectType != ObjectTypeTransformation {
+		t.Errorf("Expected ObjectType %s, got %s", ObjectTypeTransformation, info.ObjectType)
+	}
+	if info.ObjectName != "/DMO/ST_FLIGHT" {
+		t.Errorf("Expected ObjectName /DMO/ST_FLIGHT, got %s", info.ObjectName)
+	}
+}
+
+func TestParseABAPFile_FunctionGroupMembers(t *testing.T) {
+	tests := []struct {
+		file       string
+		source     string
+		wantType   CreatableObjectType
+		wantName   string
+		wantParent string
+	}{
+		{
+			file:       "zdemo_util.fugr.z_demo_format.abap",
+			source:     "FUNCTION z_demo_format.\nENDFUNCTION.\n",
+			wantType:   ObjectTypeFunctionMod,
+			wantName:   "Z_DEMO_FORMAT",
+			wantParent: "ZDEMO_UTIL",
+		},
+		{
+			file:       "#dmo#util.fugr.#dmo#format.abap",
+			source:     "FUNCTION /dmo/format.\nENDFUNCTION.\n",
+			wantType:   ObjectTypeFunctionMod,
+			wantName:   "/DMO/FORMAT",
+			wantParent: "/DMO/UTIL",
+		},
+		{
+			file:     "zdemo_util.fugr.saplzdemo_util.abap",
+			source:   "FUNCTION-POOL zdemo_util.\n",
+			wantType: ObjectTypeFunctionGroup,
+			wantName: "ZDEMO_UTIL",
+		},
+		{
+			file:     "zdemo.FUGR.abap",
+			source:   "FUNCTION-POOL zdemo.\n",
+			wantType: ObjectTypeFunctionGroup,
+			wantName: "ZDEMO",
+		},
+		{
+			file:       "#dmo#util.fugr.#dmo#lutiltop.abap",
+			source:     "FUNCTION-POOL /dmo/util.\n",
+			wantType:   ObjectTypeInclude,
+			wantName:   "/DMO/LUTILTOP",
+			wantParent: "/DMO/SAPLUTIL",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			filePath := filepath.Join(t.TempDir(), tt.file)
+			if err := os.WriteFile(filePath, []byte(tt.source), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			info, err := ParseABAPFile(filePath)
+			if err != nil {
+				t.Fatalf("ParseABAPFile failed: %v", err)
+			}
+			if info.ObjectType != tt.wantType || info.ObjectName != tt.wantName || info.ParentName != tt.wantParent {
+				t.Errorf("got (%s, %s, %s), want (%s, %s, %s)",
+					info.ObjectType, info.ObjectName, info.ParentName, tt.wantType, tt.wantName, tt.wantParent)
+			}
+		})
+	}
+}
+
+func TestExtractFunctionGroupMemberFromFilename_NoMember(t *testing.T) {
+	for _, file := range []string{"zdemo.FUGR.abap", "zdemo.fugr.abap", "zdemo.fugr..abap"} {
+		if group, member := extractFunctionGroupMemberFromFilename(file); group != "" || member != "" {
+			t.Errorf("extractFunctionGroupMemberFromFilename(%q) = (%q, %q), want empty", file, group, member)
+		}
+	}
+}
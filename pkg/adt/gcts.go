@@ -151,7 +151,7 @@ func (c *Client) GctsGetRepository(ctx context.Context, rid string) (*GctsReposi
 
 // GctsCreateRepository creates a new gCTS repository.
 func (c *Client) GctsCreateRepository(ctx context.Context, opts GctsCreateOptions) (*GctsRepository, error) {
-	if err := c.checkSafety(OpTransport, "GctsCreateRepository"); err != nil {
+	if err := c.checkWriteSafety(OpTransport, "GctsCreateRepository"); err != nil {
 		return nil, err
 	}
 
@@ -184,7 +184,7 @@ func (c *Client) GctsCreateRepository(ctx context.Context, opts GctsCreateOption
 
 // GctsDeleteRepository deletes a gCTS repository.
 func (c *Client) GctsDeleteRepository(ctx context.Context, rid string) error {
-	if err := c.checkSafety(OpTransport, "GctsDeleteRepository"); err != nil {
+	if err := c.checkWriteSafety(OpTransport, "GctsDeleteRepository"); err != nil {
 		return err
 	}
 
@@ -209,7 +209,7 @@ func (c *Client) GctsDeleteRepository(ctx context.Context, rid string) error {
 
 // GctsCloneRepository clones a gCTS repository on the SAP system.
 func (c *Client) GctsCloneRepository(ctx context.Context, rid string) error {
-	if err := c.checkSafety(OpTransport, "GctsCloneRepository"); err != nil {
+	if err := c.checkWriteSafety(OpTransport, "GctsCloneRepository"); err != nil {
 		return err
 	}
 
@@ -233,7 +233,7 @@ func (c *Client) GctsCloneRepository(ctx context.Context, rid string) error {
 
 // GctsPull pulls a specific commit into a gCTS repository.
 func (c *Client) GctsPull(ctx context.Context, rid, commitID string) (*GctsPullResult, error) {
-	if err := c.checkSafety(OpTransport, "GctsPull"); err != nil {
+	if err := c.checkWriteSafety(OpTransport, "GctsPull"); err != nil {
 		return nil, err
 	}
 
@@ -265,7 +265,7 @@ func (c *Client) GctsPull(ctx context.Context, rid, commitID string) (*GctsPullR
 
 // GctsCommit creates a commit in a gCTS repository.
 func (c *Client) GctsCommit(ctx context.Context, rid string, opts GctsCommitOptions) (*GctsCommitResult, error) {
-	if err := c.checkSafety(OpTransport, "GctsCommit"); err != nil {
+	if err := c.checkWriteSafety(OpTransport, "GctsCommit"); err != nil {
 		return nil, err
 	}
 
@@ -324,7 +324,7 @@ func (c *Client) GctsListBranches(ctx context.Context, rid string) ([]GctsBranch
 
 // GctsSwitchBranch switches the active branch of a gCTS repository.
 func (c *Client) GctsSwitchBranch(ctx context.Context, rid, branch string) error {
-	if err := c.checkSafety(OpTransport, "GctsSwitchBranch"); err != nil {
+	if err := c.checkWriteSafety(OpTransport, "GctsSwitchBranch"); err != nil {
 		return err
 	}
 
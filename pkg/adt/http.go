@@ -33,6 +33,12 @@ type Transport struct {
 	sessionID string
 	sessionMu sync.RWMutex
 
+	// Explicit stateful session (see Client.OpenStatefulSession): every
+	// request is sent stateful and carries the captured session cookies.
+	// sessionCookies is nil for regular transports.
+	stateful       bool
+	sessionCookies map[string]string
+
 	// Cookie access protection: guards config.Cookies against concurrent
 	// read (Request/retryRequest) and write (callReauthFunc) access.
 	cookiesMu sync.RWMutex
@@ -41,13 +47,21 @@ type Transport struct {
 	// from triggering simultaneous SAML dances.
 	reauthMu   sync.Mutex
 	lastReauth time.Time
+
+	// Source responses with their ETags (see WithSourceCache)
+	sources *sourceCache
 }
 
 // NewTransport creates a new Transport with the given configuration.
 func NewTransport(cfg *Config) *Transport {
+	client := cfg.NewHTTPClient()
+	// Config.Timeout is applied per attempt (see attemptContext) so that
+	// WithRequestTimeout can extend it as well as shorten it.
+	client.Timeout = 0
 	return &Transport{
 		config:     cfg,
-		httpClient: cfg.NewHTTPClient(),
+		httpClient: client,
+		sources:    newSourceCache(cfg.SourceCacheSize),
 	}
 }
 
@@ -57,6 +71,7 @@ func NewTransportWithClient(cfg *Config, client HTTPDoer) *Transport {
 	return &Transport{
 		config:     cfg,
 		httpClient: client,
+		sources:    newSourceCache(cfg.SourceCacheSize),
 	}
 }
 
@@ -89,7 +104,8 @@ type Response struct {
 	Body       []byte
 }
 
-// Request performs an HTTP request to the ADT API.
+// Request performs an HTTP request to the ADT API. With WithRetry, transient
+// failures are retried with exponential backoff.
 func (t *Transport) Request(ctx context.Context, path string, opts *RequestOptions) (*Response, error) {
 	if opts == nil {
 		opts = &RequestOptions{}
@@ -97,7 +113,20 @@ func (t *Transport) Request(ctx context.Context, path string, opts *RequestOptio
 	if opts.Method == "" {
 		opts.Method = http.MethodGet
 	}
+	resp, err := t.withRetry(ctx, opts.Method, func() (*Response, error) {
+		return t.request(ctx, path, opts)
+	})
+	t.audit(opts.Method, path, opts.Query, err)
+	if err != nil {
+		if id := CorrelationID(ctx); id != "" {
+			err = fmt.Errorf("request %s: %w", id, err)
+		}
+	}
+	return resp, err
+}
 
+// request performs a single attempt of Request.
+func (t *Transport) request(ctx context.Context, path string, opts *RequestOptions) (*Response, error) {
 	// Build URL
 	reqURL, err := t.buildURL(path, opts.Query, opts.OverrideLanguage)
 	if err != nil {
@@ -110,14 +139,16 @@ func (t *Transport) Request(ctx context.Context, path string, opts *RequestOptio
 		bodyReader = bytes.NewReader(opts.Body)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, opts.Method, reqURL, bodyReader)
+	attemptCtx, cancel := t.attemptContext(ctx)
+	defer cancel()
+	req, err := http.NewRequestWithContext(attemptCtx, opts.Method, reqURL, bodyReader)
 	if err != nil {
 		return nil, fmt.Errorf("creating request: %w", err)
 	}
 
-	// Set authentication - either basic auth or cookies
-	if t.config.HasBasicAuth() {
-		req.SetBasicAuth(t.config.Username, t.config.Password)
+	// Set authentication - bearer token, basic auth or cookies
+	if err := t.setAuth(ctx, req); err != nil {
+		return nil, err
 	}
 
 	// Add user-provided cookies for cookie-based authentication
@@ -126,6 +157,22 @@ func (t *Transport) Request(ctx context.Context, path string, opts *RequestOptio
 	// Set default headers
 	t.setDefaultHeaders(req, opts)
 
+	// Send cached source reads conditionally
+	var cached sourceCacheEntry
+	cacheKey := ""
+	if opts.Method == http.MethodGet && t.sources != nil && isSourcePath(path) {
+		cacheKey = opts.Accept + " " + reqURL
+		if entry, ok := t.sources.get(cacheKey); ok {
+			cached = entry
+			if entry.etag != "" {
+				req.Header.Set("If-None-Match", entry.etag)
+			}
+			if entry.lastModified != "" {
+				req.Header.Set("If-Modified-Since", entry.lastModified)
+			}
+		}
+	}
+
 	// Add CSRF token for modifying requests
 	if isModifyingMethod(opts.Method) {
 		token := t.getCSRFToken()
@@ -140,9 +187,11 @@ func (t *Transport) Request(ctx context.Context, path string, opts *RequestOptio
 	}
 
 	// Execute request
+	start := time.Now()
 	resp, err := t.httpClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("executing request: %w", err)
+		t.observeRequest(opts.Method, path, 0, len(opts.Body), 0, start)
+		return nil, fmt.Errorf("executing request: %w", &networkError{err: err})
 	}
 	defer resp.Body.Close()
 
@@ -151,10 +200,24 @@ func (t *Transport) Request(ctx context.Context, path string, opts *RequestOptio
 	if err != nil {
 		return nil, fmt.Errorf("reading response body: %w", err)
 	}
+	t.observeRequest(opts.Method, path, resp.StatusCode, len(opts.Body), len(body), start)
 
-	// Handle CSRF token refresh on 403
-	if resp.StatusCode == http.StatusForbidden && isModifyingMethod(opts.Method) {
+	if cacheKey != "" {
+		switch {
+		case resp.StatusCode == http.StatusNotModified && cached.body != nil:
+			return &Response{StatusCode: http.StatusOK, Headers: resp.Header, Body: cached.body}, nil
+		case resp.StatusCode == http.StatusOK:
+			t.sources.put(cacheKey, path, resp.Header, body)
+		}
+	} else if isModifyingMethod(opts.Method) {
+		t.sources.invalidatePath(path)
+	}
+
+	// Handle CSRF token refresh on 403. Other 403s (missing authorization,
+	// object locked by another user) are returned as they are.
+	if isModifyingMethod(opts.Method) && csrfTokenRequired(resp, body) {
 		// Try to refresh CSRF token and retry once
+		t.setCSRFToken("")
 		if err := t.fetchCSRFToken(ctx); err != nil {
 			return nil, fmt.Errorf("refreshing CSRF token: %w", err)
 		}
@@ -172,14 +235,12 @@ func (t *Transport) Request(ctx context.Context, path string, opts *RequestOptio
 	if sessionID := t.extractSessionID(resp); sessionID != "" {
 		t.setSessionID(sessionID)
 	}
+	t.captureSessionCookies(resp)
 
 	// Check for error status codes
 	if resp.StatusCode >= 400 {
-		apiErr := &APIError{
-			StatusCode: resp.StatusCode,
-			Message:    string(body),
-			Path:       path,
-		}
+		apiErr := newAPIError(resp.StatusCode, path, body)
+		apiErr.csrfRejected = csrfTokenRequired(resp, body)
 
 		// Handle session timeout - refresh session and retry once
 		if apiErr.IsSessionExpired() {
@@ -237,27 +298,31 @@ func (t *Transport) retryRequest(ctx context.Context, path string, opts *Request
 		bodyReader = bytes.NewReader(opts.Body)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, opts.Method, reqURL, bodyReader)
+	attemptCtx, cancel := t.attemptContext(ctx)
+	defer cancel()
+	req, err := http.NewRequestWithContext(attemptCtx, opts.Method, reqURL, bodyReader)
 	if err != nil {
 		return nil, fmt.Errorf("creating request: %w", err)
 	}
 
 	// Set authentication
-	if t.config.HasBasicAuth() {
-		req.SetBasicAuth(t.config.Username, t.config.Password)
+	if err := t.setAuth(ctx, req); err != nil {
+		return nil, err
 	}
 	t.addCookies(req)
 	t.setDefaultHeaders(req, opts)
 	req.Header.Set("X-CSRF-Token", t.getCSRFToken())
 
 	// Ensure session type header is set for retry
-	if t.config.SessionType == SessionStateful {
+	if t.isStateful() {
 		req.Header.Set("X-sap-adt-sessiontype", "stateful")
 	}
 
+	start := time.Now()
 	resp, err := t.httpClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("executing retry request: %w", err)
+		t.observeRequest(opts.Method, path, 0, len(opts.Body), 0, start)
+		return nil, fmt.Errorf("executing retry request: %w", &networkError{err: err})
 	}
 	defer resp.Body.Close()
 
@@ -265,13 +330,13 @@ func (t *Transport) retryRequest(ctx context.Context, path string, opts *Request
 	if err != nil {
 		return nil, fmt.Errorf("reading response body: %w", err)
 	}
+	t.observeRequest(opts.Method, path, resp.StatusCode, len(opts.Body), len(body), start)
+	t.captureSessionCookies(resp)
 
 	if resp.StatusCode >= 400 {
-		return nil, &APIError{
-			StatusCode: resp.StatusCode,
-			Message:    string(body),
-			Path:       path,
-		}
+		apiErr := newAPIError(resp.StatusCode, path, body)
+		apiErr.csrfRejected = csrfTokenRequired(resp, body)
+		return nil, apiErr
 	}
 
 	return &Response{
@@ -290,21 +355,23 @@ func (t *Transport) fetchCSRFToken(ctx context.Context) error {
 	}
 
 	// Use HEAD instead of GET for faster CSRF token fetch (~5s vs ~56s on slow systems)
-	req, err := http.NewRequestWithContext(ctx, http.MethodHead, reqURL, nil)
+	attemptCtx, cancel := t.attemptContext(ctx)
+	defer cancel()
+	req, err := http.NewRequestWithContext(attemptCtx, http.MethodHead, reqURL, nil)
 	if err != nil {
 		return fmt.Errorf("creating request: %w", err)
 	}
 
 	// Set authentication
-	if t.config.HasBasicAuth() {
-		req.SetBasicAuth(t.config.Username, t.config.Password)
+	if err := t.setAuth(ctx, req); err != nil {
+		return err
 	}
 	t.addCookies(req)
 	req.Header.Set("X-CSRF-Token", "fetch")
 	req.Header.Set("Accept", "*/*")
 
 	// Set session type header for stateful sessions
-	if t.config.SessionType == SessionStateful {
+	if t.isStateful() {
 		req.Header.Set("X-sap-adt-sessiontype", "stateful")
 	}
 
@@ -316,6 +383,7 @@ func (t *Transport) fetchCSRFToken(ctx context.Context) error {
 
 	// Drain body to allow connection reuse
 	_, _ = io.Copy(io.Discard, resp.Body)
+	t.captureSessionCookies(resp)
 
 	// Note: HEAD may return 400 but still provides CSRF token in headers
 	// But 401/403 indicates auth failure and won't have a valid token
@@ -376,6 +444,21 @@ func (t *Transport) buildURL(path string, query url.Values, overrideLang ...stri
 	return u.String(), nil
 }
 
+// setAuth sets the Authorization header: a bearer token when one is
+// configured, basic auth otherwise. Cookie auth is added by addCookies.
+func (t *Transport) setAuth(ctx context.Context, req *http.Request) error {
+	if token, ok, err := t.config.bearerToken(ctx); err != nil {
+		return fmt.Errorf("obtaining bearer token: %w", err)
+	} else if ok {
+		req.Header.Set("Authorization", "Bearer "+token)
+		return nil
+	}
+	if t.config.HasBasicAuth() {
+		req.SetBasicAuth(t.config.Username, t.config.Password)
+	}
+	return nil
+}
+
 // setDefaultHeaders sets default headers on a request.
 func (t *Transport) setDefaultHeaders(req *http.Request, opts *RequestOptions) {
 	// Set Accept header - SAP ADT requires */* for many endpoints
@@ -394,6 +477,10 @@ func (t *Transport) setDefaultHeaders(req *http.Request, opts *RequestOptions) {
 		req.Header.Set("Content-Type", contentType)
 	}
 
+	if id := CorrelationID(req.Context()); id != "" {
+		req.Header.Set(CorrelationIDHeader, id)
+	}
+
 	// Set custom headers
 	for k, v := range opts.Headers {
 		req.Header.Set(k, v)
@@ -402,7 +489,7 @@ func (t *Transport) setDefaultHeaders(req *http.Request, opts *RequestOptions) {
 	// Set session header: per-request Stateful flag overrides global default.
 	// Lock→write→unlock sequences require stateful mode to maintain session
 	// affinity for lock handles (issue #88).
-	if opts.Stateful || t.config.SessionType == SessionStateful {
+	if opts.Stateful || t.isStateful() {
 		req.Header.Set("X-sap-adt-sessiontype", "stateful")
 	} else {
 		req.Header.Set("X-sap-adt-sessiontype", "stateless")
@@ -445,6 +532,47 @@ func (t *Transport) setSessionID(id string) {
 	t.sessionID = id
 }
 
+// isStateful reports whether requests default to the stateful session type,
+// either globally or because this transport belongs to an explicit session.
+func (t *Transport) isStateful() bool {
+	t.sessionMu.RLock()
+	defer t.sessionMu.RUnlock()
+	return t.stateful || t.config.SessionType == SessionStateful
+}
+
+// captureSessionCookies records the sap-contextid cookie a response sets on
+// a session transport. An expired or emptied cookie is dropped.
+func (t *Transport) captureSessionCookies(resp *http.Response) {
+	t.sessionMu.Lock()
+	defer t.sessionMu.Unlock()
+	if t.sessionCookies == nil {
+		return
+	}
+	for _, cookie := range resp.Cookies() {
+		if cookie.Name != "sap-contextid" {
+			continue
+		}
+		if cookie.Value == "" || cookie.MaxAge < 0 {
+			delete(t.sessionCookies, cookie.Name)
+			continue
+		}
+		t.sessionCookies[cookie.Name] = cookie.Value
+	}
+}
+
+// csrfTokenRequired reports whether a 403 response rejected the CSRF token
+// of the request. SAP signals this with the "X-CSRF-Token: Required" header;
+// some gateways drop the header but keep the message.
+func csrfTokenRequired(resp *http.Response, body []byte) bool {
+	if resp.StatusCode != http.StatusForbidden {
+		return false
+	}
+	if strings.EqualFold(resp.Header.Get("X-CSRF-Token"), "Required") {
+		return true
+	}
+	return bytes.Contains(bytes.ToLower(body), []byte("csrf token validation failed"))
+}
+
 // isModifyingMethod returns true for HTTP methods that modify server state.
 func isModifyingMethod(method string) bool {
 	switch method {
@@ -458,12 +586,36 @@ func isModifyingMethod(method string) bool {
 // APIError represents an error from the ADT API.
 type APIError struct {
 	StatusCode int
-	Message    string
-	Path       string
+	// Message is the raw response body.
+	Message string
+	Path    string
+
+	// SAP message details, parsed from an exc:exception response body.
+	// They are empty when the server answered with something else.
+	// Type is the exception type id (e.g. "ExceptionResourceAlreadyExists"),
+	// which unlike Text does not depend on the logon language.
+	Type             string
+	SAPMessageClass  string
+	SAPMessageNumber string
+	Text             string
+
+	// csrfRejected is set when the response rejected the request's CSRF
+	// token, as decided by csrfTokenRequired.
+	csrfRejected bool
 }
 
 func (e *APIError) Error() string {
-	return fmt.Sprintf("ADT API error: status %d at %s: %s", e.StatusCode, e.Path, e.Message)
+	if e.Text == "" {
+		return fmt.Sprintf("ADT API error: status %d at %s: %s", e.StatusCode, e.Path, e.Message)
+	}
+	msg := fmt.Sprintf("ADT API error: status %d at %s: %s", e.StatusCode, e.Path, e.Text)
+	if e.SAPMessageClass != "" {
+		msg += fmt.Sprintf(" (%s %s)", e.SAPMessageClass, e.SAPMessageNumber)
+	}
+	if e.Type != "" {
+		msg += " [" + e.Type + "]"
+	}
+	return msg
 }
 
 // IsNotFound returns true if the error is a 404 Not Found error.
@@ -484,6 +636,61 @@ func (e *APIError) IsSessionExpired() bool {
 		strings.Contains(msg, "session not found")
 }
 
+// IsAlreadyExists returns true if the request failed because the object
+// to be created exists already.
+func (e *APIError) IsAlreadyExists() bool {
+	if e.Type != "" {
+		return strings.Contains(e.Type, "AlreadyExists")
+	}
+	return strings.Contains(strings.ToLower(e.Message), "already exist")
+}
+
+// IsConflict returns true for a 409 response or a conflict exception, such
+// as a debug listener that is already registered by another client.
+func (e *APIError) IsConflict() bool {
+	if e.StatusCode == http.StatusConflict {
+		return true
+	}
+	if e.Type != "" {
+		return strings.Contains(strings.ToLower(e.Type), "conflict")
+	}
+	return strings.Contains(strings.ToLower(e.Message), "conflict")
+}
+
+// IsLockBusy returns true if a lock request failed because the enqueue
+// server was momentarily busy, as opposed to the object being locked by
+// another user. Such failures usually clear within milliseconds.
+func (e *APIError) IsLockBusy() bool {
+	msg := strings.ToLower(e.Message)
+	if strings.Contains(msg, "currently editing") {
+		return false
+	}
+	return strings.Contains(msg, "resource busy") ||
+		strings.Contains(msg, "enqueue server busy") ||
+		strings.Contains(msg, "lock table overflow")
+}
+
+// ErrNotSupported is returned (wrapped) when the system does not expose the
+// ADT endpoint a call needs, e.g. on releases that predate it.
+var ErrNotSupported = errors.New("not supported by this system")
+
+// IsEndpointMissing returns true if the error is a 404 for the endpoint
+// itself ("No suitable resource found") rather than for a missing object.
+func (e *APIError) IsEndpointMissing() bool {
+	return e.StatusCode == http.StatusNotFound &&
+		strings.Contains(strings.ToLower(e.Message), "no suitable resource found")
+}
+
+// unsupportedIfEndpointMissing maps a missing-endpoint 404 to ErrNotSupported
+// so callers can distinguish "not available here" from "object not found".
+func unsupportedIfEndpointMissing(err error, feature string) error {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.IsEndpointMissing() {
+		return fmt.Errorf("%s: %w", feature, ErrNotSupported)
+	}
+	return err
+}
+
 // IsNotFoundError checks if an error is an API 404 Not Found error.
 func IsNotFoundError(err error) bool {
 	if err == nil {
@@ -496,6 +703,18 @@ func IsNotFoundError(err error) bool {
 	return false
 }
 
+// IsLockBusyError checks if an error is a transient lock-acquisition failure.
+func IsLockBusyError(err error) bool {
+	if err == nil {
+		return false
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.IsLockBusy()
+	}
+	return false
+}
+
 // IsSessionExpiredError checks if an error indicates SAP session timeout.
 func IsSessionExpiredError(err error) bool {
 	if err == nil {
@@ -535,6 +754,23 @@ func (t *Transport) callReauthFunc(ctx context.Context) error {
 		return nil
 	}
 
+	return t.reauth(ctx)
+}
+
+// RefreshCookies runs config.ReauthFunc now, replaces the session cookies
+// with the ones it returns and fetches a new CSRF token. Unlike the
+// automatic re-authentication on 401, it is never skipped by the cooldown.
+func (t *Transport) RefreshCookies(ctx context.Context) error {
+	if t.config.ReauthFunc == nil {
+		return fmt.Errorf("refreshing cookies: no login configured (use WithSAMLLogin or WithReauthFunc)")
+	}
+	t.reauthMu.Lock()
+	defer t.reauthMu.Unlock()
+	return t.reauth(ctx)
+}
+
+// reauth performs the re-authentication. Callers hold reauthMu.
+func (t *Transport) reauth(ctx context.Context) error {
 	// Apply a timeout so the mutex is not held indefinitely during network I/O.
 	reauthCtx, cancel := context.WithTimeout(ctx, reauthTimeout)
 	defer cancel()
@@ -565,4 +801,12 @@ func (t *Transport) addCookies(req *http.Request) {
 	for name, value := range t.config.Cookies {
 		req.AddCookie(&http.Cookie{Name: name, Value: value})
 	}
+
+	t.sessionMu.RLock()
+	defer t.sessionMu.RUnlock()
+	for name, value := range t.sessionCookies {
+		if _, ok := t.config.Cookies[name]; !ok {
+			req.AddCookie(&http.Cookie{Name: name, Value: value})
+		}
+	}
 }
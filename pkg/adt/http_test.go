@@ -2,12 +2,15 @@ package adt
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"strings"
 	"testing"
+	"time"
 )
 
 // mockHTTPClient is a mock HTTP client for testing.
@@ -157,8 +160,8 @@ func TestTransport_Request_CSRFRefreshOn403(t *testing.T) {
 		responses: []*http.Response{
 			// First: fetch initial CSRF token
 			newMockResponse(200, "OK", map[string]string{"X-CSRF-Token": "old-token"}),
-			// Second: POST fails with 403
-			newMockResponse(403, "Forbidden", nil),
+			// Second: POST fails with 403, token rejected
+			newMockResponse(403, "Forbidden", map[string]string{"X-CSRF-Token": "Required"}),
 			// Third: refresh CSRF token
 			newMockResponse(200, "OK", map[string]string{"X-CSRF-Token": "new-token"}),
 			// Fourth: retry POST
@@ -186,6 +189,30 @@ func TestTransport_Request_CSRFRefreshOn403(t *testing.T) {
 	}
 }
 
+func TestTransport_Request_PlainForbiddenNotRetried(t *testing.T) {
+	mock := &mockHTTPClient{
+		responses: []*http.Response{
+			newMockResponse(200, "OK", map[string]string{"X-CSRF-Token": "token"}),
+			// No "X-CSRF-Token: Required": the token was fine
+			newMockResponse(403, "User TESTUSER is currently editing ZDEMO_REPORT", nil),
+		},
+	}
+
+	cfg := NewConfig("https://sap.example.com:44300", "user", "pass")
+	transport := NewTransportWithClient(cfg, mock)
+
+	_, err := transport.Request(context.Background(), "/sap/bc/adt/test", &RequestOptions{
+		Method: http.MethodPost,
+	})
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusForbidden {
+		t.Fatalf("expected the 403 to be returned, got %v", err)
+	}
+	if len(mock.requests) != 2 {
+		t.Errorf("expected no token refresh, got %d requests", len(mock.requests))
+	}
+}
+
 func TestTransport_Request_RetryOn401(t *testing.T) {
 	mock := &mockHTTPClient{
 		responses: []*http.Response{
@@ -625,3 +652,98 @@ func TestTransport_Request_BothAuthMethods(t *testing.T) {
 		t.Error("Cookie should also be present when both auth methods are set")
 	}
 }
+
+func TestTransport_BearerToken(t *testing.T) {
+	var auth []string
+	mock := &funcMockClient{doFunc: func(req *http.Request) (*http.Response, error) {
+		auth = append(auth, req.Header.Get("Authorization"))
+		return newTestResponse("ok"), nil
+	}}
+	cfg := NewConfig("https://sap.example.com:44300", "user", "pass", WithBearerToken("static-token"))
+	transport := NewTransportWithClient(cfg, mock)
+
+	if _, err := transport.Request(context.Background(), "/sap/bc/adt/discovery", nil); err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	if len(auth) != 1 || auth[0] != "Bearer static-token" {
+		t.Errorf("Authorization = %q, want bearer token instead of basic auth", auth)
+	}
+}
+
+func TestTransport_TokenSourceRefresh(t *testing.T) {
+	calls := 0
+	source := func(ctx context.Context) (string, error) {
+		calls++
+		return fmt.Sprintf("token-%d", calls), nil
+	}
+
+	var auth []string
+	mock := &funcMockClient{doFunc: func(req *http.Request) (*http.Response, error) {
+		auth = append(auth, req.Header.Get("Authorization"))
+		if req.Header.Get("Authorization") == "Bearer token-1" {
+			return newMockResponse(http.StatusUnauthorized, "token expired", nil), nil
+		}
+		r := newTestResponse("ok")
+		r.Header.Set("X-CSRF-Token", "test-token")
+		return r, nil
+	}}
+	cfg := NewConfig("https://sap.example.com:44300", "user", "pass", WithTokenSource(source))
+	transport := NewTransportWithClient(cfg, mock)
+
+	if _, err := transport.Request(context.Background(), "/sap/bc/adt/oo/classes/zcl_demo_order", nil); err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	// The first token is rejected as expired; the CSRF refresh and the retry
+	// must ask the source again instead of reusing it.
+	if calls < 2 {
+		t.Fatalf("token source called %d times, want it re-invoked after expiry", calls)
+	}
+	if last := auth[len(auth)-1]; last != fmt.Sprintf("Bearer token-%d", calls) {
+		t.Errorf("retry sent %q, want the latest token", last)
+	}
+	for _, a := range auth {
+		if !strings.HasPrefix(a, "Bearer ") {
+			t.Errorf("request sent without bearer token: %q", a)
+		}
+	}
+
+	failing := NewConfig("https://sap.example.com:44300", "", "", WithTokenSource(func(ctx context.Context) (string, error) {
+		return "", fmt.Errorf("identity provider unreachable")
+	}))
+	if _, err := NewTransportWithClient(failing, mock).Request(context.Background(), "/sap/bc/adt/discovery", nil); err == nil {
+		t.Error("expected token source error to fail the request")
+	}
+}
+
+func TestTransport_RequestTimeout(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(200 * time.Millisecond):
+			fmt.Fprint(w, "<ok/>")
+		case <-r.Context().Done():
+		}
+	}))
+	defer srv.Close()
+
+	// A short per-request timeout fires long before the global one.
+	transport := NewTransport(NewConfig(srv.URL, "user", "pass", WithTimeout(time.Minute)))
+	start := time.Now()
+	ctx := WithRequestTimeout(context.Background(), 20*time.Millisecond)
+	_, err := transport.Request(ctx, "/sap/bc/adt/discovery", nil)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 150*time.Millisecond {
+		t.Errorf("request took %v, per-request timeout was not applied", elapsed)
+	}
+
+	// A longer per-request timeout outlasts a short global one.
+	transport = NewTransport(NewConfig(srv.URL, "user", "pass", WithTimeout(20*time.Millisecond)))
+	if _, err := transport.Request(context.Background(), "/sap/bc/adt/discovery", nil); err == nil {
+		t.Fatal("expected the global timeout to fire")
+	}
+	ctx = WithRequestTimeout(context.Background(), 5*time.Second)
+	if _, err := transport.Request(ctx, "/sap/bc/adt/discovery", nil); err != nil {
+		t.Errorf("request with extended timeout failed: %v", err)
+	}
+}
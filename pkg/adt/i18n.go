@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"sort"
+	"strconv"
 	"strings"
 )
 
@@ -21,9 +23,16 @@ type DataElementLabels struct {
 
 // TextPoolEntry represents a single text pool entry (text element/symbol) of a program.
 type TextPoolEntry struct {
-	ID   string `json:"id" xml:"id,attr"`
-	Key  string `json:"key" xml:"key,attr"`
-	Text string `json:"text" xml:"entry,attr"`
+	ID     string `json:"id" xml:"id,attr"`
+	Key    string `json:"key" xml:"key,attr"`
+	Text   string `json:"text" xml:"entry,attr"`
+	Length int    `json:"length,omitempty" xml:"length,attr,omitempty"` // reserved length, 0 = text length
+}
+
+// textPool is the XML document of a program text pool.
+type textPool struct {
+	XMLName xml.Name        `xml:"textPool"`
+	Entries []TextPoolEntry `xml:"entry"`
 }
 
 // LanguageComparison holds the result of comparing an object's texts in two languages.
@@ -92,29 +101,12 @@ func (c *Client) GetDataElementLabels(ctx context.Context, name, lang string) (*
 }
 
 // GetMessageClassTexts retrieves all messages of a message class in a specific language.
+// It is GetMessageClassInLanguage without the message class header.
 func (c *Client) GetMessageClassTexts(ctx context.Context, name, lang string) ([]MessageClassMessage, error) {
-	if err := c.checkSafety(OpRead, "GetMessageClassTexts"); err != nil {
-		return nil, err
-	}
-
-	name = strings.ToUpper(name)
-	lang = strings.ToUpper(lang)
-
-	path := fmt.Sprintf("/sap/bc/adt/messageclass/%s", url.PathEscape(strings.ToLower(name)))
-	resp, err := c.transport.Request(ctx, path, &RequestOptions{
-		Method:           http.MethodGet,
-		Accept:           "application/vnd.sap.adt.mc.messageclass+xml",
-		OverrideLanguage: lang,
-	})
+	mc, err := c.GetMessageClassInLanguage(ctx, name, lang)
 	if err != nil {
-		return nil, fmt.Errorf("get message class texts: %w", err)
-	}
-
-	var mc MessageClass
-	if err := xml.Unmarshal(resp.Body, &mc); err != nil {
-		return nil, fmt.Errorf("parse message class XML: %w", err)
+		return nil, err
 	}
-
 	return mc.Messages, nil
 }
 
@@ -158,6 +150,7 @@ func (c *Client) WriteMessageClassTexts(ctx context.Context, name, lang string,
 		Body:             body,
 		ContentType:      "application/vnd.sap.adt.mc.messageclass+xml",
 		OverrideLanguage: lang,
+		Stateful:         true, // Must match lock session (issue #88)
 	})
 	if err != nil {
 		return fmt.Errorf("write message class texts: %w", err)
@@ -166,6 +159,185 @@ func (c *Client) WriteMessageClassTexts(ctx context.Context, name, lang string,
 	return nil
 }
 
+// GetMessageClassInLanguage retrieves a message class with its texts in
+// lang. An empty lang reads in the session language.
+func (c *Client) GetMessageClassInLanguage(ctx context.Context, name, lang string) (*MessageClass, error) {
+	if err := c.checkSafety(OpRead, "GetMessageClassInLanguage"); err != nil {
+		return nil, err
+	}
+	lang, err := c.messageClassLanguage(lang)
+	if err != nil {
+		return nil, err
+	}
+
+	name = strings.ToUpper(name)
+	path := fmt.Sprintf("/sap/bc/adt/messageclass/%s", url.PathEscape(strings.ToLower(name)))
+	resp, err := c.transport.Request(ctx, path, &RequestOptions{
+		Method:           http.MethodGet,
+		Accept:           "application/vnd.sap.adt.mc.messageclass+xml",
+		OverrideLanguage: lang,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get message class in %s: %w", lang, err)
+	}
+
+	var mc MessageClass
+	if err := xml.Unmarshal(resp.Body, &mc); err != nil {
+		return nil, fmt.Errorf("parse message class XML: %w", err)
+	}
+	mc.Name = name
+	return &mc, nil
+}
+
+// WriteMessageClassInLanguage sets the texts of the given messages in lang,
+// adding messages that do not exist yet. Other messages of that language
+// are kept, and texts in other languages are not touched since only the
+// lang version is written. An empty lang writes in the session language.
+//
+// Workflow: Lock → GetMessageClassInLanguage → Merge → WriteMessageClassTexts → Unlock
+func (c *Client) WriteMessageClassInLanguage(ctx context.Context, name, lang string, messages []MessageClassMessage, transport string) error {
+	if err := c.checkSafety(OpUpdate, "WriteMessageClassInLanguage"); err != nil {
+		return err
+	}
+	lang, err := c.messageClassLanguage(lang)
+	if err != nil {
+		return err
+	}
+
+	return c.modifyMessageClass(ctx, name, lang, transport, func(current []MessageClassMessage) ([]MessageClassMessage, error) {
+		return mergeMessages(current, messages), nil
+	})
+}
+
+// AddMessages appends texts as new messages to a message class in the
+// session language and returns them with their numbers. Numbers continue
+// after the highest existing one; once 999 is reached, free numbers below it
+// are used. All messages are written in one PUT.
+//
+// Workflow: Lock → GetMessageClassInLanguage → Number → WriteMessageClassTexts → Unlock
+func (c *Client) AddMessages(ctx context.Context, msgClassName string, texts []string) ([]MessageClassMessage, error) {
+	if err := c.checkSafety(OpUpdate, "AddMessages"); err != nil {
+		return nil, err
+	}
+	if len(texts) == 0 {
+		return nil, nil
+	}
+	lang, err := c.messageClassLanguage("")
+	if err != nil {
+		return nil, err
+	}
+
+	var added []MessageClassMessage
+	err = c.modifyMessageClass(ctx, msgClassName, lang, "", func(current []MessageClassMessage) ([]MessageClassMessage, error) {
+		numbers, err := nextMessageNumbers(current, len(texts))
+		if err != nil {
+			return nil, err
+		}
+		added = make([]MessageClassMessage, len(texts))
+		for i, text := range texts {
+			added[i] = MessageClassMessage{Number: numbers[i], Text: text}
+		}
+		return append(append([]MessageClassMessage(nil), current...), added...), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return added, nil
+}
+
+// modifyMessageClass rewrites the lang texts of a message class under its
+// lock. change receives the current messages and returns the full new list.
+func (c *Client) modifyMessageClass(ctx context.Context, name, lang, transport string, change func([]MessageClassMessage) ([]MessageClassMessage, error)) error {
+	name = strings.ToUpper(name)
+	objectURL := fmt.Sprintf("/sap/bc/adt/messageclass/%s", url.PathEscape(strings.ToLower(name)))
+	lock, err := c.LockObject(ctx, objectURL, "MODIFY")
+	if err != nil {
+		return err
+	}
+
+	current, err := c.GetMessageClassInLanguage(ctx, name, lang)
+	var messages []MessageClassMessage
+	if err == nil {
+		messages, err = change(current.Messages)
+	}
+	if err == nil {
+		err = c.WriteMessageClassTexts(ctx, name, lang, messages, lock.LockHandle, transport)
+	}
+	if err != nil {
+		_ = c.UnlockObject(ctx, objectURL, lock.LockHandle)
+		return err
+	}
+
+	return c.UnlockObject(ctx, objectURL, lock.LockHandle)
+}
+
+// nextMessageNumbers picks count unused three-digit message numbers,
+// continuing after the highest one in use and wrapping around to fill gaps
+// when 999 is reached.
+func nextMessageNumbers(existing []MessageClassMessage, count int) ([]string, error) {
+	used := make(map[int]bool, len(existing))
+	highest := 0
+	for _, m := range existing {
+		n, err := strconv.Atoi(strings.TrimSpace(m.Number))
+		if err != nil {
+			continue
+		}
+		used[n] = true
+		if n > highest {
+			highest = n
+		}
+	}
+
+	numbers := make([]string, 0, count)
+	next := func(from, to int) {
+		for n := from; n <= to && len(numbers) < count; n++ {
+			if !used[n] {
+				used[n] = true
+				numbers = append(numbers, fmt.Sprintf("%03d", n))
+			}
+		}
+	}
+	next(highest+1, 999)
+	next(1, highest)
+	if len(numbers) < count {
+		return nil, fmt.Errorf("message class has room for %d more message(s), %d requested", len(numbers), count)
+	}
+	return numbers, nil
+}
+
+// messageClassLanguage returns lang, or the session language when empty.
+func (c *Client) messageClassLanguage(lang string) (string, error) {
+	if lang == "" {
+		lang = c.config.Language
+	}
+	if lang != "" && !sapLanguagePattern.MatchString(lang) {
+		return "", fmt.Errorf("invalid language %q: must be a one- or two-character SAP language code", lang)
+	}
+	return strings.ToUpper(lang), nil
+}
+
+// mergeMessages overlays updates onto current by message number. Existing
+// messages keep their position; new ones are appended in number order.
+func mergeMessages(current, updates []MessageClassMessage) []MessageClassMessage {
+	merged := append([]MessageClassMessage(nil), current...)
+	index := make(map[string]int, len(merged))
+	for i, m := range merged {
+		index[m.Number] = i
+	}
+	existing := len(merged)
+	for _, u := range updates {
+		if i, ok := index[u.Number]; ok {
+			merged[i].Text = u.Text
+			continue
+		}
+		index[u.Number] = len(merged)
+		merged = append(merged, u)
+	}
+	added := merged[existing:]
+	sort.Slice(added, func(i, j int) bool { return added[i].Number < added[j].Number })
+	return merged
+}
+
 // WriteDataElementLabels updates data element labels in a specific language.
 // Requires a lock handle from LockObject and optionally a transport request number.
 func (c *Client) WriteDataElementLabels(ctx context.Context, name, lang string, labels *DataElementLabels, lockHandle, transport string) error {
@@ -209,7 +381,9 @@ func (c *Client) WriteDataElementLabels(ctx context.Context, name, lang string,
 	return nil
 }
 
-// GetTextPoolInLanguage retrieves the text pool (text elements/symbols) of a program in a specific language.
+// GetTextPoolInLanguage retrieves the text pool of a program: text symbols
+// (I), selection texts (S), the title (R) and list headings (H/T).
+// An empty lang reads the text pool in the configured session language.
 func (c *Client) GetTextPoolInLanguage(ctx context.Context, programName, lang string) ([]TextPoolEntry, error) {
 	if err := c.checkSafety(OpRead, "GetTextPoolInLanguage"); err != nil {
 		return nil, err
@@ -218,8 +392,7 @@ func (c *Client) GetTextPoolInLanguage(ctx context.Context, programName, lang st
 	programName = strings.ToUpper(programName)
 	lang = strings.ToUpper(lang)
 
-	path := fmt.Sprintf("/sap/bc/adt/programs/programs/%s/textelements", url.PathEscape(programName))
-	resp, err := c.transport.Request(ctx, path, &RequestOptions{
+	resp, err := c.transport.Request(ctx, textPoolPath(programName), &RequestOptions{
 		Method:           http.MethodGet,
 		Accept:           "application/xml",
 		OverrideLanguage: lang,
@@ -228,17 +401,83 @@ func (c *Client) GetTextPoolInLanguage(ctx context.Context, programName, lang st
 		return nil, fmt.Errorf("get text pool: %w", err)
 	}
 
-	type textPool struct {
-		Entries []TextPoolEntry `xml:"entry"`
+	return parseTextPool(resp.Body)
+}
+
+// textPoolPath returns the text pool URL of a program, used for reading
+// and writing.
+func textPoolPath(programName string) string {
+	return fmt.Sprintf("/sap/bc/adt/programs/programs/%s/textelements", url.PathEscape(programName))
+}
+
+// WriteTextPool replaces the text pool of a program in a language. The
+// program is locked for the write and unlocked afterwards. An empty lang
+// writes the text pool in the configured session language.
+func (c *Client) WriteTextPool(ctx context.Context, programName, lang string, entries []TextPoolEntry, transport string) error {
+	programName = strings.ToUpper(programName)
+	lang = strings.ToUpper(lang)
+	objectURL := fmt.Sprintf("/sap/bc/adt/programs/programs/%s", url.PathEscape(programName))
+
+	// Unified mutation policy gate (op type + package + transport)
+	if err := c.checkMutation(ctx, MutationContext{
+		Op:        OpUpdate,
+		OpName:    "WriteTextPool",
+		ObjectURL: objectURL,
+		Transport: transport,
+	}); err != nil {
+		return err
+	}
+
+	body, err := marshalTextPool(entries)
+	if err != nil {
+		return err
 	}
+
+	lock, err := c.LockObject(ctx, objectURL, "MODIFY")
+	if err != nil {
+		return fmt.Errorf("write text pool: %w", err)
+	}
+	defer func() {
+		_ = c.UnlockObject(ctx, objectURL, lock.LockHandle)
+	}()
+
+	params := url.Values{}
+	params.Set("lockHandle", lock.LockHandle)
+	if transport != "" {
+		params.Set("corrNr", transport)
+	}
+
+	_, err = c.transport.Request(ctx, textPoolPath(programName), &RequestOptions{
+		Method:           http.MethodPut,
+		Query:            params,
+		Body:             body,
+		ContentType:      "application/xml",
+		OverrideLanguage: lang,
+		Stateful:         true, // Must match lock session
+	})
+	if err != nil {
+		return fmt.Errorf("write text pool: %w", err)
+	}
+
+	return nil
+}
+
+func parseTextPool(data []byte) ([]TextPoolEntry, error) {
 	var tp textPool
-	if err := xml.Unmarshal(resp.Body, &tp); err != nil {
+	if err := xml.Unmarshal(data, &tp); err != nil {
 		return nil, fmt.Errorf("parse text pool XML: %w", err)
 	}
-
 	return tp.Entries, nil
 }
 
+func marshalTextPool(entries []TextPoolEntry) ([]byte, error) {
+	body, err := xml.MarshalIndent(textPool{Entries: entries}, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("marshal text pool XML: %w", err)
+	}
+	return append([]byte(xml.Header), body...), nil
+}
+
 // CompareObjectLanguages compares the text content of an object in two languages.
 // Returns a comparison showing which texts differ or are missing in the target language.
 func (c *Client) CompareObjectLanguages(ctx context.Context, objectSourceURL, sourceLang, targetLang string) (*LanguageComparison, error) {
@@ -2,7 +2,11 @@ package adt
 
 import (
 	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
 	"net/http"
+	"reflect"
 	"strings"
 	"testing"
 )
@@ -133,6 +137,67 @@ func TestGetTextPoolInLanguage(t *testing.T) {
 	}
 }
 
+const sampleTextPoolXML = `<?xml version="1.0" encoding="UTF-8"?>
+<textPool>
+  <entry id="R" key="" entry="Demo app index calculation" length="70"/>
+  <entry id="I" key="001" entry="Processed entries:" length="30"/>
+  <entry id="S" key="P_DEMO" entry="        Demo parameter" length="30"/>
+</textPool>`
+
+func TestTextPool_RoundTrip(t *testing.T) {
+	const programPath = "/sap/bc/adt/programs/programs/%2FUI5%2FAPP_INDEX_CALCULATE"
+
+	var written []byte
+	var languages []string
+	mock := &funcMockClient{
+		doFunc: func(req *http.Request) (*http.Response, error) {
+			path := req.URL.EscapedPath()
+			switch {
+			case strings.Contains(path, "discovery"):
+				resp := newTestResponse("OK")
+				resp.Header.Set("X-CSRF-Token", "test-token")
+				return resp, nil
+			case req.Method == http.MethodPost && path == programPath:
+				return newTestResponse(lockResponseXML), nil
+			case path == programPath+"/textelements":
+				languages = append(languages, req.URL.Query().Get("sap-language"))
+				if req.Method == http.MethodPut {
+					written, _ = io.ReadAll(req.Body)
+					return newTestResponse(""), nil
+				}
+				return newTestResponse(sampleTextPoolXML), nil
+			}
+			return &http.Response{StatusCode: http.StatusNotFound, Body: io.NopCloser(strings.NewReader("not found")), Header: http.Header{}}, nil
+		},
+	}
+	cfg := NewConfig("https://sap.example.com:44300", "user", "pass", WithLanguage("DE"))
+	client := NewClientWithTransport(cfg, NewTransportWithClient(cfg, mock))
+	ctx := context.Background()
+
+	entries, err := client.GetTextPoolInLanguage(ctx, "/ui5/app_index_calculate", "")
+	if err != nil {
+		t.Fatalf("GetTextPoolInLanguage failed: %v", err)
+	}
+	if len(entries) != 3 || entries[1].Key != "001" || entries[1].Length != 30 {
+		t.Fatalf("unexpected entries: %+v", entries)
+	}
+
+	if err := client.WriteTextPool(ctx, "/UI5/APP_INDEX_CALCULATE", "en", entries, ""); err != nil {
+		t.Fatalf("WriteTextPool failed: %v", err)
+	}
+	roundTripped, err := parseTextPool(written)
+	if err != nil {
+		t.Fatalf("parsing written text pool: %v", err)
+	}
+	if !reflect.DeepEqual(roundTripped, entries) {
+		t.Errorf("round trip mismatch:\n got %+v\nwant %+v", roundTripped, entries)
+	}
+
+	if want := []string{"DE", "EN"}; !reflect.DeepEqual(languages, want) {
+		t.Errorf("sap-language = %v, want %v (config default, then explicit)", languages, want)
+	}
+}
+
 func TestCompareObjectLanguages(t *testing.T) {
 	// Use a func-based mock that returns different content based on sap-language query param
 	mock := &funcMockClient{
@@ -242,3 +307,165 @@ func TestWriteOperationsCheckSafety(t *testing.T) {
 		t.Error("GetDataElementLabels should not be blocked by read-only mode")
 	}
 }
+
+func TestWriteMessageClassInLanguage_KeepsOtherLanguages(t *testing.T) {
+	texts := map[string]map[string]string{
+		"EN": {"001": "Order not found", "002": "Order locked"},
+		"DE": {"001": "Auftrag fehlt", "002": "Auftrag gesperrt"},
+	}
+	var putLangs []string
+	mock := &funcMockClient{doFunc: func(req *http.Request) (*http.Response, error) {
+		if strings.Contains(req.URL.Path, "discovery") {
+			resp := newTestResponse("OK")
+			resp.Header.Set("X-CSRF-Token", "test-token")
+			return resp, nil
+		}
+		lang := req.URL.Query().Get("sap-language")
+		switch {
+		case req.URL.Query().Get("_action") == "LOCK":
+			return newTestResponse(lockResponseXML), nil
+		case req.URL.Query().Get("_action") == "UNLOCK":
+			return newTestResponse(""), nil
+		case req.Method == http.MethodPut:
+			putLangs = append(putLangs, lang)
+			var mc MessageClass
+			body, _ := io.ReadAll(req.Body)
+			if err := xml.Unmarshal(body, &mc); err != nil {
+				t.Fatalf("invalid PUT body: %v", err)
+			}
+			texts[lang] = map[string]string{}
+			for _, m := range mc.Messages {
+				texts[lang][m.Number] = m.Text
+			}
+			return newTestResponse(""), nil
+		default:
+			var b strings.Builder
+			b.WriteString(`<mc:messageclass xmlns:mc="http://www.sap.com/adt/mc" name="ZDEMO_MC">`)
+			for _, no := range []string{"001", "002"} {
+				fmt.Fprintf(&b, `<mc:messages msgno="%s" msgtext="%s"/>`, no, texts[lang][no])
+			}
+			b.WriteString(`</mc:messageclass>`)
+			return newTestResponse(b.String()), nil
+		}
+	}}
+
+	cfg := NewConfig("https://sap.example.com:44300", "user", "pass")
+	client := NewClientWithTransport(cfg, NewTransportWithClient(cfg, mock))
+
+	err := client.WriteMessageClassInLanguage(context.Background(), "ZDEMO_MC", "de",
+		[]MessageClassMessage{{Number: "001", Text: "Auftrag nicht gefunden"}}, "")
+	if err != nil {
+		t.Fatalf("WriteMessageClassInLanguage failed: %v", err)
+	}
+
+	if len(putLangs) != 1 || putLangs[0] != "DE" {
+		t.Fatalf("PUT languages = %v, want [DE]", putLangs)
+	}
+	if texts["DE"]["001"] != "Auftrag nicht gefunden" || texts["DE"]["002"] != "Auftrag gesperrt" {
+		t.Errorf("DE texts = %v", texts["DE"])
+	}
+	if texts["EN"]["001"] != "Order not found" || texts["EN"]["002"] != "Order locked" {
+		t.Errorf("EN texts changed: %v", texts["EN"])
+	}
+
+	mc, err := client.GetMessageClassInLanguage(context.Background(), "zdemo_mc", "")
+	if err != nil {
+		t.Fatalf("GetMessageClassInLanguage failed: %v", err)
+	}
+	if mc.Name != "ZDEMO_MC" || len(mc.Messages) != 2 || mc.Messages[0].Text != "Order not found" {
+		t.Errorf("session-language read = %+v, want the EN texts", mc)
+	}
+}
+
+func TestMergeMessages(t *testing.T) {
+	current := []MessageClassMessage{{Number: "001", Text: "a"}, {Number: "005", Text: "e"}}
+	got := mergeMessages(current, []MessageClassMessage{
+		{Number: "005", Text: "E"},
+		{Number: "003", Text: "c"},
+		{Number: "002", Text: "b"},
+		{Number: "003", Text: "C"},
+	})
+	want := []MessageClassMessage{{"001", "a"}, {"005", "E"}, {"002", "b"}, {"003", "C"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("mergeMessages = %+v, want %+v", got, want)
+	}
+	if current[1].Text != "e" {
+		t.Error("mergeMessages modified its input")
+	}
+}
+
+func TestAddMessages_ContinuesFromHighestNumber(t *testing.T) {
+	existing := `<mc:messageclass xmlns:mc="http://www.sap.com/adt/mc" name="ZDEMO_MC">
+  <mc:messages msgno="001" msgtext="First"/>
+  <mc:messages msgno="007" msgtext="Seventh"/>
+  <mc:messages msgno="004" msgtext="Fourth"/>
+</mc:messageclass>`
+	var puts []MessageClass
+	var actions []string
+	mock := &funcMockClient{doFunc: func(req *http.Request) (*http.Response, error) {
+		if strings.Contains(req.URL.Path, "discovery") {
+			resp := newTestResponse("OK")
+			resp.Header.Set("X-CSRF-Token", "test-token")
+			return resp, nil
+		}
+		action := req.URL.Query().Get("_action")
+		actions = append(actions, req.Method+" "+action)
+		switch {
+		case action == "LOCK":
+			return newTestResponse(lockResponseXML), nil
+		case req.Method == http.MethodPut:
+			if got := req.Header.Get("X-sap-adt-sessiontype"); got != "stateful" {
+				t.Errorf("PUT session type = %q, want stateful to match the lock", got)
+			}
+			var mc MessageClass
+			body, _ := io.ReadAll(req.Body)
+			if err := xml.Unmarshal(body, &mc); err != nil {
+				t.Fatalf("invalid PUT body: %v", err)
+			}
+			puts = append(puts, mc)
+			return newTestResponse(""), nil
+		case req.Method == http.MethodGet:
+			return newTestResponse(existing), nil
+		}
+		return newTestResponse(""), nil
+	}}
+
+	cfg := NewConfig("https://sap.example.com:44300", "user", "pass")
+	client := NewClientWithTransport(cfg, NewTransportWithClient(cfg, mock))
+
+	added, err := client.AddMessages(context.Background(), "ZDEMO_MC", []string{"Eighth", "Ninth"})
+	if err != nil {
+		t.Fatalf("AddMessages failed: %v", err)
+	}
+	if len(added) != 2 || added[0].Number != "008" || added[1].Number != "009" {
+		t.Fatalf("added = %+v, want numbers 008 and 009", added)
+	}
+	if len(puts) != 1 {
+		t.Fatalf("expected one PUT, got %d", len(puts))
+	}
+	if got := len(puts[0].Messages); got != 5 {
+		t.Errorf("PUT carries %d messages, want all 5", got)
+	}
+	if want := []string{"POST LOCK", "GET ", "PUT ", "POST UNLOCK"}; !reflect.DeepEqual(actions, want) {
+		t.Errorf("requests = %v, want %v", actions, want)
+	}
+}
+
+func TestNextMessageNumbers_WrapsIntoGaps(t *testing.T) {
+	existing := []MessageClassMessage{{Number: "001"}, {Number: "003"}, {Number: "998"}, {Number: "999"}}
+	got, err := nextMessageNumbers(existing, 3)
+	if err != nil {
+		t.Fatalf("nextMessageNumbers failed: %v", err)
+	}
+	if want := []string{"002", "004", "005"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("nextMessageNumbers = %v, want %v", got, want)
+	}
+
+	full := make([]MessageClassMessage, 0, 999)
+	for n := 1; n <= 998; n++ {
+		full = append(full, MessageClassMessage{Number: fmt.Sprintf("%03d", n)})
+	}
+	if _, err := nextMessageNumbers(full, 2); err == nil {
+		t.Error("expected an error when the message class runs out of numbers")
+	}
+}
@@ -0,0 +1,170 @@
+package adt
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// --- BSP Application Operations ---
+
+// BSPPage is a page, page fragment or controller of a BSP application.
+type BSPPage struct {
+	Name        string `json:"name"`
+	Kind        string `json:"kind"` // page, fragment, controller, view
+	Description string `json:"description,omitempty"`
+	Controller  string `json:"controllerClass,omitempty"` // controller class for controller pages
+}
+
+// BSPApplication represents a classic BSP application with its pages and
+// controllers.
+type BSPApplication struct {
+	Name             string    `json:"name"`
+	Description      string    `json:"description,omitempty"`
+	ApplicationClass string    `json:"applicationClass,omitempty"`
+	Pages            []BSPPage `json:"pages"`
+}
+
+// GetBSPApplication lists the pages, fragments and controllers of a classic
+// BSP application. Returns an error wrapping ErrNotSupported if the system
+// has no BSP endpoint.
+func (c *Client) GetBSPApplication(ctx context.Context, name string) (*BSPApplication, error) {
+	name = strings.ToUpper(name)
+
+	path := fmt.Sprintf("/sap/bc/adt/bsp/applications/%s", url.PathEscape(strings.ToLower(name)))
+	resp, err := c.transport.Request(ctx, path, &RequestOptions{
+		Method: http.MethodGet,
+		Accept: "application/xml",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("getting BSP application: %w", unsupportedIfEndpointMissing(err, "BSP applications"))
+	}
+
+	return parseBSPApplication(resp.Body)
+}
+
+func parseBSPApplication(data []byte) (*BSPApplication, error) {
+	// Strip namespace prefixes
+	xmlStr := string(data)
+	xmlStr = strings.ReplaceAll(xmlStr, "bsp:", "")
+	xmlStr = strings.ReplaceAll(xmlStr, "adtcore:", "")
+
+	type page struct {
+		Name        string `xml:"name,attr"`
+		Kind        string `xml:"kind,attr"`
+		Description string `xml:"description,attr"`
+		Controller  string `xml:"controllerClass,attr"`
+	}
+	type bspRoot struct {
+		Name             string `xml:"name,attr"`
+		Description      string `xml:"description,attr"`
+		ApplicationClass string `xml:"applicationClass,attr"`
+		Pages            []page `xml:"pages>page"`
+	}
+
+	var root bspRoot
+	if err := xml.Unmarshal([]byte(xmlStr), &root); err != nil {
+		return nil, fmt.Errorf("parsing BSP application: %w", err)
+	}
+
+	app := &BSPApplication{
+		Name:             root.Name,
+		Description:      root.Description,
+		ApplicationClass: root.ApplicationClass,
+		Pages:            []BSPPage{},
+	}
+	for _, p := range root.Pages {
+		app.Pages = append(app.Pages, BSPPage{
+			Name:        p.Name,
+			Kind:        p.Kind,
+			Description: p.Description,
+			Controller:  p.Controller,
+		})
+	}
+
+	return app, nil
+}
+
+// --- Web Dynpro Operations ---
+
+// WebDynproElement is a view, window or controller of a Web Dynpro component.
+type WebDynproElement struct {
+	Name        string `json:"name"`
+	Kind        string `json:"kind"` // view, window, component_controller, custom_controller, interface_controller
+	Description string `json:"description,omitempty"`
+}
+
+// WebDynproComponent represents a Web Dynpro ABAP component with its views,
+// windows and controllers.
+type WebDynproComponent struct {
+	Name        string             `json:"name"`
+	Description string             `json:"description,omitempty"`
+	Views       []WebDynproElement `json:"views"`
+	Windows     []WebDynproElement `json:"windows"`
+	Controllers []WebDynproElement `json:"controllers"`
+}
+
+// GetWebDynproComponent lists the views, windows and controllers of a Web
+// Dynpro ABAP component. Returns an error wrapping ErrNotSupported if the
+// system has no Web Dynpro endpoint.
+func (c *Client) GetWebDynproComponent(ctx context.Context, name string) (*WebDynproComponent, error) {
+	name = strings.ToUpper(name)
+
+	path := fmt.Sprintf("/sap/bc/adt/wdy/components/%s", url.PathEscape(strings.ToLower(name)))
+	resp, err := c.transport.Request(ctx, path, &RequestOptions{
+		Method: http.MethodGet,
+		Accept: "application/xml",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("getting Web Dynpro component: %w", unsupportedIfEndpointMissing(err, "Web Dynpro components"))
+	}
+
+	return parseWebDynproComponent(resp.Body)
+}
+
+func parseWebDynproComponent(data []byte) (*WebDynproComponent, error) {
+	// Strip namespace prefixes
+	xmlStr := string(data)
+	xmlStr = strings.ReplaceAll(xmlStr, "wdy:", "")
+	xmlStr = strings.ReplaceAll(xmlStr, "adtcore:", "")
+
+	type element struct {
+		Name        string `xml:"name,attr"`
+		Kind        string `xml:"kind,attr"`
+		Description string `xml:"description,attr"`
+	}
+	type wdyRoot struct {
+		Name        string    `xml:"name,attr"`
+		Description string    `xml:"description,attr"`
+		Elements    []element `xml:"elements>element"`
+	}
+
+	var root wdyRoot
+	if err := xml.Unmarshal([]byte(xmlStr), &root); err != nil {
+		return nil, fmt.Errorf("parsing Web Dynpro component: %w", err)
+	}
+
+	comp := &WebDynproComponent{
+		Name:        root.Name,
+		Description: root.Description,
+		Views:       []WebDynproElement{},
+		Windows:     []WebDynproElement{},
+		Controllers: []WebDynproElement{},
+	}
+	for _, e := range root.Elements {
+		el := WebDynproElement{Name: e.Name, Kind: e.Kind, Description: e.Description}
+		switch {
+		case e.Kind == "view":
+			comp.Views = append(comp.Views, el)
+		case e.Kind == "window":
+			comp.Windows = append(comp.Windows, el)
+		case strings.HasSuffix(e.Kind, "controller"):
+			comp.Controllers = append(comp.Controllers, el)
+		}
+	}
+
+	return comp, nil
+}
@@ -0,0 +1,57 @@
+package adt
+
+import "testing"
+
+func TestParseBSPApplication(t *testing.T) {
+	data := `<?xml version="1.0" encoding="UTF-8"?>
+<bsp:application xmlns:bsp="http://www.sap.com/adt/bsp" xmlns:adtcore="http://www.sap.com/adt/core"
+    adtcore:name="ZDEMO_BSP" adtcore:description="Demo BSP" bsp:applicationClass="ZCL_DEMO_BSP_APP">
+  <bsp:pages>
+    <bsp:page adtcore:name="default.htm" bsp:kind="page" adtcore:description="Start page"/>
+    <bsp:page adtcore:name="header.htm" bsp:kind="fragment"/>
+    <bsp:page adtcore:name="main.do" bsp:kind="controller" bsp:controllerClass="ZCL_DEMO_BSP_MAIN"/>
+  </bsp:pages>
+</bsp:application>`
+
+	app, err := parseBSPApplication([]byte(data))
+	if err != nil {
+		t.Fatalf("parseBSPApplication failed: %v", err)
+	}
+	if app.Name != "ZDEMO_BSP" || app.ApplicationClass != "ZCL_DEMO_BSP_APP" {
+		t.Errorf("unexpected header: %+v", app)
+	}
+	if len(app.Pages) != 3 {
+		t.Fatalf("expected 3 pages, got %d", len(app.Pages))
+	}
+	if p := app.Pages[2]; p.Name != "main.do" || p.Kind != "controller" || p.Controller != "ZCL_DEMO_BSP_MAIN" {
+		t.Errorf("unexpected controller page: %+v", p)
+	}
+}
+
+func TestParseWebDynproComponent(t *testing.T) {
+	data := `<?xml version="1.0" encoding="UTF-8"?>
+<wdy:component xmlns:wdy="http://www.sap.com/adt/wdy" xmlns:adtcore="http://www.sap.com/adt/core"
+    adtcore:name="ZDEMO_WDA" adtcore:description="Demo component">
+  <wdy:elements>
+    <wdy:element adtcore:name="COMPONENTCONTROLLER" wdy:kind="component_controller"/>
+    <wdy:element adtcore:name="V_MAIN" wdy:kind="view" adtcore:description="Main view"/>
+    <wdy:element adtcore:name="V_DETAIL" wdy:kind="view"/>
+    <wdy:element adtcore:name="W_MAIN" wdy:kind="window"/>
+    <wdy:element adtcore:name="INTERFACECONTROLLER" wdy:kind="interface_controller"/>
+  </wdy:elements>
+</wdy:component>`
+
+	comp, err := parseWebDynproComponent([]byte(data))
+	if err != nil {
+		t.Fatalf("parseWebDynproComponent failed: %v", err)
+	}
+	if comp.Name != "ZDEMO_WDA" {
+		t.Errorf("Name = %q", comp.Name)
+	}
+	if len(comp.Views) != 2 || comp.Views[0].Name != "V_MAIN" {
+		t.Errorf("unexpected views: %+v", comp.Views)
+	}
+	if len(comp.Windows) != 1 || len(comp.Controllers) != 2 {
+		t.Errorf("windows/controllers = %d/%d, want 1/2", len(comp.Windows), len(comp.Controllers))
+	}
+}
@@ -0,0 +1,170 @@
+package adt
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RequestMetric describes a single HTTP exchange performed by the Transport.
+type RequestMetric struct {
+	// Operation is the ADT resource family derived from the path and method,
+	// e.g. "GET programs/programs" or "POST repository/nodestructure".
+	Operation     string
+	Method        string
+	Path          string
+	StatusCode    int // 0 when the request failed before a response arrived
+	RequestBytes  int
+	ResponseBytes int
+	Duration      time.Duration
+}
+
+// MetricsSink receives per-request metrics from the Transport.
+// Implementations must be safe for concurrent use.
+type MetricsSink interface {
+	ObserveRequest(m RequestMetric)
+}
+
+// LatencyBuckets are the upper bounds of the latency histogram kept by
+// InMemoryMetrics. Requests slower than the last bound land in an overflow bucket.
+var LatencyBuckets = []time.Duration{
+	10 * time.Millisecond,
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	250 * time.Millisecond,
+	500 * time.Millisecond,
+	time.Second,
+	2500 * time.Millisecond,
+	5 * time.Second,
+	10 * time.Second,
+}
+
+// OperationStats aggregates metrics for one (operation, status) pair.
+type OperationStats struct {
+	Operation     string        `json:"operation"`
+	StatusCode    int           `json:"statusCode"`
+	Count         int           `json:"count"`
+	RequestBytes  int64         `json:"requestBytes"`
+	ResponseBytes int64         `json:"responseBytes"`
+	TotalDuration time.Duration `json:"totalDuration"`
+	MaxDuration   time.Duration `json:"maxDuration"`
+	// Histogram holds one counter per LatencyBuckets entry plus an overflow bucket.
+	Histogram []int `json:"histogram"`
+}
+
+// AvgDuration returns the mean latency of the recorded requests.
+func (s OperationStats) AvgDuration() time.Duration {
+	if s.Count == 0 {
+		return 0
+	}
+	return s.TotalDuration / time.Duration(s.Count)
+}
+
+// InMemoryMetrics is a MetricsSink that aggregates metrics in memory.
+// Use Snapshot to read the collected statistics, e.g. after an export run.
+type InMemoryMetrics struct {
+	mu    sync.Mutex
+	stats map[string]*OperationStats
+}
+
+// NewInMemoryMetrics creates an empty in-memory metrics collector.
+func NewInMemoryMetrics() *InMemoryMetrics {
+	return &InMemoryMetrics{stats: make(map[string]*OperationStats)}
+}
+
+// ObserveRequest records a single request.
+func (m *InMemoryMetrics) ObserveRequest(r RequestMetric) {
+	key := fmt.Sprintf("%s|%d", r.Operation, r.StatusCode)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	s, ok := m.stats[key]
+	if !ok {
+		s = &OperationStats{
+			Operation:  r.Operation,
+			StatusCode: r.StatusCode,
+			Histogram:  make([]int, len(LatencyBuckets)+1),
+		}
+		m.stats[key] = s
+	}
+
+	s.Count++
+	s.RequestBytes += int64(r.RequestBytes)
+	s.ResponseBytes += int64(r.ResponseBytes)
+	s.TotalDuration += r.Duration
+	if r.Duration > s.MaxDuration {
+		s.MaxDuration = r.Duration
+	}
+	s.Histogram[latencyBucket(r.Duration)]++
+}
+
+// Snapshot returns a copy of the aggregated statistics, sorted by operation
+// and status code.
+func (m *InMemoryMetrics) Snapshot() []OperationStats {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	out := make([]OperationStats, 0, len(m.stats))
+	for _, s := range m.stats {
+		cp := *s
+		cp.Histogram = append([]int(nil), s.Histogram...)
+		out = append(out, cp)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Operation != out[j].Operation {
+			return out[i].Operation < out[j].Operation
+		}
+		return out[i].StatusCode < out[j].StatusCode
+	})
+	return out
+}
+
+// Reset discards all collected statistics.
+func (m *InMemoryMetrics) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.stats = make(map[string]*OperationStats)
+}
+
+func latencyBucket(d time.Duration) int {
+	for i, bound := range LatencyBuckets {
+		if d <= bound {
+			return i
+		}
+	}
+	return len(LatencyBuckets)
+}
+
+// metricsOperation derives a low-cardinality operation name from a request.
+// Only the first two path segments below /sap/bc/adt/ are kept so that
+// object names do not explode the number of series.
+func metricsOperation(method, path string) string {
+	rest := strings.TrimPrefix(path, "/sap/bc/adt/")
+	if idx := strings.IndexByte(rest, '?'); idx >= 0 {
+		rest = rest[:idx]
+	}
+	parts := strings.Split(strings.Trim(rest, "/"), "/")
+	if len(parts) > 2 {
+		parts = parts[:2]
+	}
+	return method + " " + strings.Join(parts, "/")
+}
+
+// observeRequest reports a completed exchange to the configured MetricsSink.
+func (t *Transport) observeRequest(method, path string, statusCode, requestBytes, responseBytes int, start time.Time) {
+	if t.config.Metrics == nil {
+		return
+	}
+	t.config.Metrics.ObserveRequest(RequestMetric{
+		Operation:     metricsOperation(method, path),
+		Method:        method,
+		Path:          path,
+		StatusCode:    statusCode,
+		RequestBytes:  requestBytes,
+		ResponseBytes: responseBytes,
+		Duration:      time.Since(start),
+	})
+}
@@ -0,0 +1,81 @@
+package adt
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestInMemoryMetrics_RecordsRequests(t *testing.T) {
+	metrics := NewInMemoryMetrics()
+	mock := &mockTransportClient{
+		responses: map[string]*http.Response{
+			"/sap/bc/adt/programs/programs/ZTEST/source/main":  newTestResponse("REPORT ztest."),
+			"/sap/bc/adt/programs/programs/ZOTHER/source/main": newTestResponse("REPORT zother."),
+		},
+	}
+
+	cfg := NewConfig("https://sap.example.com:44300", "user", "pass", WithMetrics(metrics))
+	client := NewClientWithTransport(cfg, NewTransportWithClient(cfg, mock))
+
+	if _, err := client.GetProgram(context.Background(), "ZTEST"); err != nil {
+		t.Fatalf("GetProgram failed: %v", err)
+	}
+	if _, err := client.GetProgram(context.Background(), "ZOTHER"); err != nil {
+		t.Fatalf("GetProgram failed: %v", err)
+	}
+	_, _ = client.GetProgram(context.Background(), "ZMISSING")
+
+	snap := metrics.Snapshot()
+	if len(snap) != 2 {
+		t.Fatalf("expected 2 series (200 and 404), got %+v", snap)
+	}
+
+	ok := snap[0]
+	if ok.Operation != "GET programs/programs" || ok.StatusCode != http.StatusOK {
+		t.Fatalf("unexpected first series: %+v", ok)
+	}
+	if ok.Count != 2 {
+		t.Errorf("Count = %d, want 2", ok.Count)
+	}
+	if want := int64(len("REPORT ztest.") + len("REPORT zother.")); ok.ResponseBytes != want {
+		t.Errorf("ResponseBytes = %d, want %d", ok.ResponseBytes, want)
+	}
+
+	total := 0
+	for _, n := range ok.Histogram {
+		total += n
+	}
+	if total != ok.Count {
+		t.Errorf("histogram total = %d, want %d", total, ok.Count)
+	}
+
+	if snap[1].StatusCode != http.StatusNotFound || snap[1].Count != 1 {
+		t.Errorf("unexpected 404 series: %+v", snap[1])
+	}
+}
+
+func TestMetricsOperation(t *testing.T) {
+	tests := []struct {
+		method, path, want string
+	}{
+		{"GET", "/sap/bc/adt/programs/programs/ZTEST/source/main", "GET programs/programs"},
+		{"POST", "/sap/bc/adt/repository/nodestructure", "POST repository/nodestructure"},
+		{"GET", "/sap/bc/adt/discovery", "GET discovery"},
+	}
+	for _, tt := range tests {
+		if got := metricsOperation(tt.method, tt.path); got != tt.want {
+			t.Errorf("metricsOperation(%q, %q) = %q, want %q", tt.method, tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestLatencyBucket(t *testing.T) {
+	if got := latencyBucket(5 * time.Millisecond); got != 0 {
+		t.Errorf("5ms bucket = %d, want 0", got)
+	}
+	if got := latencyBucket(time.Minute); got != len(LatencyBuckets) {
+		t.Errorf("1m bucket = %d, want overflow %d", got, len(LatencyBuckets))
+	}
+}
@@ -92,7 +92,7 @@ func (c *Client) checkMutation(ctx context.Context, m MutationContext) error {
 // checkMutationPackage validates the target package for a mutation. If no
 // package whitelist is configured, the check is a no-op.
 func (c *Client) checkMutationPackage(ctx context.Context, m MutationContext) error {
-	if len(c.config.Safety.AllowedPackages) == 0 {
+	if !c.config.Safety.HasPackageRestrictions() {
 		return nil
 	}
 
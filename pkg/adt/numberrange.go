@@ -0,0 +1,107 @@
+package adt
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// --- Number Range Operations ---
+
+// NumberRangeInterval is a single interval of a number range object.
+type NumberRangeInterval struct {
+	Number        string `json:"number"` // interval number, e.g. "01"
+	SubObject     string `json:"subObject,omitempty"`
+	ToYear        string `json:"toYear,omitempty"`
+	FromNumber    string `json:"fromNumber"`
+	ToNumber      string `json:"toNumber"`
+	CurrentNumber string `json:"currentNumber,omitempty"`
+	External      bool   `json:"external"`
+}
+
+// NumberRangeObject represents a number range object (SNRO) and its intervals.
+type NumberRangeObject struct {
+	Name          string                `json:"name"`
+	Description   string                `json:"description,omitempty"`
+	Domain        string                `json:"domain,omitempty"` // number length domain
+	BufferType    string                `json:"bufferType,omitempty"`
+	BufferSize    int                   `json:"bufferSize,omitempty"`
+	YearDependent bool                  `json:"yearDependent"`
+	Intervals     []NumberRangeInterval `json:"intervals"`
+}
+
+// GetNumberRange retrieves a number range object with its intervals
+// (from/to number, current number, external flag).
+// Returns an error wrapping ErrNotSupported if the system has no number
+// range endpoint.
+func (c *Client) GetNumberRange(ctx context.Context, objectName string) (*NumberRangeObject, error) {
+	objectName = strings.ToUpper(objectName)
+
+	path := fmt.Sprintf("/sap/bc/adt/numberranges/objects/%s", url.PathEscape(objectName))
+	resp, err := c.transport.Request(ctx, path, &RequestOptions{
+		Method: http.MethodGet,
+		Accept: "application/xml",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("getting number range: %w", unsupportedIfEndpointMissing(err, "number ranges"))
+	}
+
+	return parseNumberRange(resp.Body)
+}
+
+func parseNumberRange(data []byte) (*NumberRangeObject, error) {
+	// Strip namespace prefixes
+	xmlStr := string(data)
+	xmlStr = strings.ReplaceAll(xmlStr, "nr:", "")
+	xmlStr = strings.ReplaceAll(xmlStr, "adtcore:", "")
+
+	type interval struct {
+		Number        string `xml:"number,attr"`
+		SubObject     string `xml:"subObject,attr"`
+		ToYear        string `xml:"toYear,attr"`
+		FromNumber    string `xml:"fromNumber,attr"`
+		ToNumber      string `xml:"toNumber,attr"`
+		CurrentNumber string `xml:"currentNumber,attr"`
+		External      bool   `xml:"external,attr"`
+	}
+	type nrRoot struct {
+		Name          string     `xml:"name,attr"`
+		Description   string     `xml:"description,attr"`
+		Domain        string     `xml:"domain,attr"`
+		BufferType    string     `xml:"bufferType,attr"`
+		BufferSize    int        `xml:"bufferSize,attr"`
+		YearDependent bool       `xml:"yearDependent,attr"`
+		Intervals     []interval `xml:"intervals>interval"`
+	}
+
+	var root nrRoot
+	if err := xml.Unmarshal([]byte(xmlStr), &root); err != nil {
+		return nil, fmt.Errorf("parsing number range: %w", err)
+	}
+
+	nr := &NumberRangeObject{
+		Name:          root.Name,
+		Description:   root.Description,
+		Domain:        root.Domain,
+		BufferType:    root.BufferType,
+		BufferSize:    root.BufferSize,
+		YearDependent: root.YearDependent,
+		Intervals:     []NumberRangeInterval{},
+	}
+	for _, iv := range root.Intervals {
+		nr.Intervals = append(nr.Intervals, NumberRangeInterval{
+			Number:        iv.Number,
+			SubObject:     iv.SubObject,
+			ToYear:        iv.ToYear,
+			FromNumber:    iv.FromNumber,
+			ToNumber:      iv.ToNumber,
+			CurrentNumber: iv.CurrentNumber,
+			External:      iv.External,
+		})
+	}
+
+	return nr, nil
+}
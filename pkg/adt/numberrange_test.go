@@ -0,0 +1,61 @@
+package adt
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+const sampleNumberRangeXML = `<?xml version="1.0" encoding="UTF-8"?>
+<nr:numberRangeObject xmlns:nr="http://www.sap.com/adt/numberranges" xmlns:adtcore="http://www.sap.com/adt/core"
+    adtcore:name="ZDEMO_ORD" adtcore:description="Demo order numbers"
+    nr:domain="CHAR10" nr:bufferType="main" nr:bufferSize="10" nr:yearDependent="false">
+  <nr:intervals>
+    <nr:interval nr:number="01" nr:fromNumber="0000000001" nr:toNumber="0099999999" nr:currentNumber="0000004711" nr:external="false"/>
+    <nr:interval nr:number="02" nr:fromNumber="A000000000" nr:toNumber="ZZZZZZZZZZ" nr:external="true"/>
+  </nr:intervals>
+</nr:numberRangeObject>`
+
+func TestParseNumberRange(t *testing.T) {
+	nr, err := parseNumberRange([]byte(sampleNumberRangeXML))
+	if err != nil {
+		t.Fatalf("parseNumberRange failed: %v", err)
+	}
+	if nr.Name != "ZDEMO_ORD" || nr.Domain != "CHAR10" || nr.BufferSize != 10 {
+		t.Errorf("unexpected header: %+v", nr)
+	}
+	if len(nr.Intervals) != 2 {
+		t.Fatalf("expected 2 intervals, got %d", len(nr.Intervals))
+	}
+
+	internal := nr.Intervals[0]
+	if internal.FromNumber != "0000000001" || internal.ToNumber != "0099999999" || internal.CurrentNumber != "0000004711" || internal.External {
+		t.Errorf("unexpected internal interval: %+v", internal)
+	}
+	external := nr.Intervals[1]
+	if external.Number != "02" || !external.External || external.CurrentNumber != "" {
+		t.Errorf("unexpected external interval: %+v", external)
+	}
+}
+
+func TestGetNumberRange_Unsupported(t *testing.T) {
+	mock := &mockTransportClient{
+		responses: map[string]*http.Response{
+			"/sap/bc/adt/numberranges/objects/ZDEMO_ORD": {
+				StatusCode: http.StatusNotFound,
+				Body:       io.NopCloser(strings.NewReader("No suitable resource found")),
+				Header:     http.Header{},
+			},
+		},
+	}
+	cfg := NewConfig("https://sap.example.com:44300", "user", "pass")
+	client := NewClientWithTransport(cfg, NewTransportWithClient(cfg, mock))
+
+	_, err := client.GetNumberRange(context.Background(), "zdemo_ord")
+	if !errors.Is(err, ErrNotSupported) {
+		t.Fatalf("expected ErrNotSupported, got %v", err)
+	}
+}
@@ -0,0 +1,83 @@
+package adt
+
+import (
+	"container/list"
+	"sync"
+)
+
+// --- Object Package Cache ---
+
+// packageCache is a bounded LRU mapping canonical object URLs to their
+// package, so that safety and transport checks on the same object do not
+// resolve the package again. A nil cache (size 0) caches nothing.
+type packageCache struct {
+	mu      sync.Mutex
+	max     int
+	order   *list.List // front = most recently used
+	entries map[string]*list.Element
+}
+
+type packageCacheEntry struct {
+	objectURL string
+	pkg       string
+}
+
+func newPackageCache(maxEntries int) *packageCache {
+	if maxEntries <= 0 {
+		return nil
+	}
+	return &packageCache{
+		max:     maxEntries,
+		order:   list.New(),
+		entries: make(map[string]*list.Element),
+	}
+}
+
+func (p *packageCache) get(objectURL string) (string, bool) {
+	if p == nil {
+		return "", false
+	}
+	key := canonicalizeObjectURL(objectURL)
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	el, ok := p.entries[key]
+	if !ok {
+		return "", false
+	}
+	p.order.MoveToFront(el)
+	return el.Value.(*packageCacheEntry).pkg, true
+}
+
+func (p *packageCache) put(objectURL, pkg string) {
+	if p == nil {
+		return
+	}
+	key := canonicalizeObjectURL(objectURL)
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if el, ok := p.entries[key]; ok {
+		el.Value.(*packageCacheEntry).pkg = pkg
+		p.order.MoveToFront(el)
+		return
+	}
+	p.entries[key] = p.order.PushFront(&packageCacheEntry{objectURL: key, pkg: pkg})
+	if p.order.Len() > p.max {
+		oldest := p.order.Back()
+		p.order.Remove(oldest)
+		delete(p.entries, oldest.Value.(*packageCacheEntry).objectURL)
+	}
+}
+
+// invalidate drops the entry of an object, e.g. after it was deleted.
+func (p *packageCache) invalidate(objectURL string) {
+	if p == nil {
+		return
+	}
+	key := canonicalizeObjectURL(objectURL)
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if el, ok := p.entries[key]; ok {
+		p.order.Remove(el)
+		delete(p.entries, key)
+	}
+}
@@ -0,0 +1,70 @@
+package adt
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestPackageCache_OneLookupForRepeatedSafetyChecks(t *testing.T) {
+	mock := &methodPathMock{routes: []routedResponse{
+		resp("", "discovery", http.StatusOK, "ok"),
+		resp(http.MethodGet, "/repository/informationsystem/search", http.StatusOK, `<adtcore:objectReferences xmlns:adtcore="http://www.sap.com/adt/core">
+  <adtcore:objectReference adtcore:uri="/sap/bc/adt/programs/programs/zdemo_report" adtcore:type="PROG/P" adtcore:name="ZDEMO_REPORT" adtcore:packageName="$TMP"/>
+</adtcore:objectReferences>`),
+		resp(http.MethodPut, "/programs/programs/ZDEMO_REPORT/source/main", http.StatusOK, ""),
+		resp(http.MethodDelete, "/programs/programs/ZDEMO_REPORT", http.StatusOK, ""),
+	}}
+	cfg := NewConfig("https://sap.example.com:44300", "user", "pass", WithAllowedPackages("$TMP"), WithPackageCache(16))
+	client := NewClientWithTransport(cfg, NewTransportWithClient(cfg, mock))
+	ctx := context.Background()
+
+	searches := func() int {
+		n := 0
+		for _, call := range mock.calls {
+			if strings.Contains(call.path, "/informationsystem/search") {
+				n++
+			}
+		}
+		return n
+	}
+
+	for i := 0; i < 3; i++ {
+		if err := client.UpdateSource(ctx, "/sap/bc/adt/programs/programs/ZDEMO_REPORT/source/main", "REPORT zdemo_report.", "TESTHANDLE", ""); err != nil {
+			t.Fatalf("UpdateSource #%d failed: %v", i+1, err)
+		}
+	}
+	if n := searches(); n != 1 {
+		t.Errorf("expected the package to be resolved once, got %d lookups", n)
+	}
+
+	// Deleting the object drops its cached package
+	if err := client.DeleteObject(ctx, "/sap/bc/adt/programs/programs/ZDEMO_REPORT", "TESTHANDLE", ""); err != nil {
+		t.Fatalf("DeleteObject failed: %v", err)
+	}
+	if _, ok := client.packages.get("/sap/bc/adt/programs/programs/ZDEMO_REPORT"); ok {
+		t.Error("package entry must be invalidated after delete")
+	}
+}
+
+func TestPackageCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	cache := newPackageCache(2)
+	cache.put("/sap/bc/adt/oo/classes/ZCL_DEMO_A", "$TMP")
+	cache.put("/sap/bc/adt/oo/classes/ZCL_DEMO_B", "$ZDEMO")
+	cache.get("/sap/bc/adt/oo/classes/zcl_demo_a") // A is now most recent
+	cache.put("/sap/bc/adt/oo/classes/ZCL_DEMO_C", "$ZDEMO")
+
+	if _, ok := cache.get("/sap/bc/adt/oo/classes/ZCL_DEMO_B"); ok {
+		t.Error("least recently used entry B should have been evicted")
+	}
+	if pkg, ok := cache.get("/sap/bc/adt/oo/classes/ZCL_DEMO_A/source/main"); !ok || pkg != "$TMP" {
+		t.Errorf("A = %q, %v; want $TMP", pkg, ok)
+	}
+
+	var disabled *packageCache = newPackageCache(0)
+	disabled.put("/sap/bc/adt/oo/classes/ZCL_DEMO_A", "$TMP")
+	if _, ok := disabled.get("/sap/bc/adt/oo/classes/ZCL_DEMO_A"); ok {
+		t.Error("disabled cache must not return entries")
+	}
+}
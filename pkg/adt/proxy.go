@@ -0,0 +1,121 @@
+package adt
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// --- Proxy Operations ---
+
+// ProxyObject is a repository object generated for a proxy
+// (proxy class, interface, message structure or data element).
+type ProxyObject struct {
+	Type string `json:"type"`
+	Name string `json:"name"`
+	URI  string `json:"uri,omitempty"`
+}
+
+// ProxyNameMapping maps an external (WSDL/ESR/IDoc) name to the ABAP name
+// generated for it.
+type ProxyNameMapping struct {
+	ExternalName string `json:"externalName"`
+	ABAPName     string `json:"abapName"`
+	Kind         string `json:"kind,omitempty"` // message, type, operation, ...
+}
+
+// Proxy represents an ABAP proxy (SPRX) generated from a WSDL, the ESR or
+// an IDoc, together with the objects generated for it.
+type Proxy struct {
+	Name              string             `json:"name"`
+	Description       string             `json:"description,omitempty"`
+	Kind              string             `json:"kind,omitempty"`   // consumer, provider
+	Source            string             `json:"source,omitempty"` // WSDL, ESR, IDOC
+	ExternalName      string             `json:"externalName,omitempty"`
+	ExternalNamespace string             `json:"externalNamespace,omitempty"`
+	Classes           []string           `json:"classes,omitempty"`
+	Interfaces        []string           `json:"interfaces,omitempty"`
+	GeneratedObjects  []ProxyObject      `json:"generatedObjects,omitempty"`
+	NameMappings      []ProxyNameMapping `json:"nameMappings,omitempty"`
+}
+
+// GetProxy retrieves the definition of an ABAP proxy: its external name,
+// the generated classes, interfaces and structures, and the mapping from
+// external names to ABAP names.
+func (c *Client) GetProxy(ctx context.Context, name string) (*Proxy, error) {
+	name = strings.ToUpper(name)
+
+	path := fmt.Sprintf("/sap/bc/adt/sproxy/proxies/%s", url.PathEscape(name))
+	resp, err := c.transport.Request(ctx, path, &RequestOptions{
+		Method: http.MethodGet,
+		Accept: "application/xml",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("getting proxy: %w", err)
+	}
+
+	return parseProxy(resp.Body)
+}
+
+func parseProxy(data []byte) (*Proxy, error) {
+	// Strip namespace prefixes
+	xmlStr := string(data)
+	xmlStr = strings.ReplaceAll(xmlStr, "sproxy:", "")
+	xmlStr = strings.ReplaceAll(xmlStr, "adtcore:", "")
+
+	type generated struct {
+		Type string `xml:"type,attr"`
+		Name string `xml:"name,attr"`
+		URI  string `xml:"uri,attr"`
+	}
+	type mapping struct {
+		ExternalName string `xml:"externalName,attr"`
+		ABAPName     string `xml:"abapName,attr"`
+		Kind         string `xml:"kind,attr"`
+	}
+	type proxyRoot struct {
+		Name              string      `xml:"name,attr"`
+		Description       string      `xml:"description,attr"`
+		Kind              string      `xml:"kind,attr"`
+		Source            string      `xml:"source,attr"`
+		ExternalName      string      `xml:"externalName,attr"`
+		ExternalNamespace string      `xml:"externalNamespace,attr"`
+		Generated         []generated `xml:"generatedObject"`
+		Mappings          []mapping   `xml:"nameMapping"`
+	}
+
+	var root proxyRoot
+	if err := xml.Unmarshal([]byte(xmlStr), &root); err != nil {
+		return nil, fmt.Errorf("parsing proxy metadata: %w", err)
+	}
+
+	proxy := &Proxy{
+		Name:              root.Name,
+		Description:       root.Description,
+		Kind:              root.Kind,
+		Source:            root.Source,
+		ExternalName:      root.ExternalName,
+		ExternalNamespace: root.ExternalNamespace,
+	}
+	for _, g := range root.Generated {
+		proxy.GeneratedObjects = append(proxy.GeneratedObjects, ProxyObject{Type: g.Type, Name: g.Name, URI: g.URI})
+		switch {
+		case strings.HasPrefix(g.Type, "CLAS"):
+			proxy.Classes = append(proxy.Classes, g.Name)
+		case strings.HasPrefix(g.Type, "INTF"):
+			proxy.Interfaces = append(proxy.Interfaces, g.Name)
+		}
+	}
+	for _, m := range root.Mappings {
+		proxy.NameMappings = append(proxy.NameMappings, ProxyNameMapping{
+			ExternalName: m.ExternalName,
+			ABAPName:     m.ABAPName,
+			Kind:         m.Kind,
+		})
+	}
+
+	return proxy, nil
+}
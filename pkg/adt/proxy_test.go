@@ -0,0 +1,44 @@
+package adt
+
+import "testing"
+
+const sampleProxyXML = `<?xml version="1.0" encoding="UTF-8"?>
+<sproxy:proxy xmlns:sproxy="http://www.sap.com/adt/sproxy" xmlns:adtcore="http://www.sap.com/adt/core"
+    adtcore:name="ZDEMO_CO_ORDER_SERVICE" adtcore:type="SPRX" adtcore:description="Order service consumer"
+    sproxy:kind="consumer" sproxy:source="WSDL"
+    sproxy:externalName="OrderService" sproxy:externalNamespace="urn:demo:orders">
+  <sproxy:generatedObject adtcore:type="CLAS/OC" adtcore:name="ZDEMO_CO_ORDER_SERVICE" adtcore:uri="/sap/bc/adt/oo/classes/zdemo_co_order_service"/>
+  <sproxy:generatedObject adtcore:type="INTF/OI" adtcore:name="ZIF_DEMO_ORDER_SERVICE" adtcore:uri="/sap/bc/adt/oo/interfaces/zif_demo_order_service"/>
+  <sproxy:generatedObject adtcore:type="TABL/DS" adtcore:name="ZDEMO_ORDER_REQUEST" adtcore:uri="/sap/bc/adt/ddic/structures/zdemo_order_request"/>
+  <sproxy:nameMapping sproxy:externalName="OrderRequest" sproxy:abapName="ZDEMO_ORDER_REQUEST" sproxy:kind="message"/>
+  <sproxy:nameMapping sproxy:externalName="createOrder" sproxy:abapName="CREATE_ORDER" sproxy:kind="operation"/>
+</sproxy:proxy>`
+
+func TestParseProxy(t *testing.T) {
+	proxy, err := parseProxy([]byte(sampleProxyXML))
+	if err != nil {
+		t.Fatalf("parseProxy failed: %v", err)
+	}
+
+	if proxy.Name != "ZDEMO_CO_ORDER_SERVICE" || proxy.Kind != "consumer" || proxy.Source != "WSDL" {
+		t.Errorf("unexpected header: %+v", proxy)
+	}
+	if proxy.ExternalName != "OrderService" || proxy.ExternalNamespace != "urn:demo:orders" {
+		t.Errorf("unexpected external name: %q %q", proxy.ExternalName, proxy.ExternalNamespace)
+	}
+	if len(proxy.Classes) != 1 || proxy.Classes[0] != "ZDEMO_CO_ORDER_SERVICE" {
+		t.Errorf("Classes = %v", proxy.Classes)
+	}
+	if len(proxy.Interfaces) != 1 || proxy.Interfaces[0] != "ZIF_DEMO_ORDER_SERVICE" {
+		t.Errorf("Interfaces = %v", proxy.Interfaces)
+	}
+	if len(proxy.GeneratedObjects) != 3 {
+		t.Errorf("expected 3 generated objects, got %d", len(proxy.GeneratedObjects))
+	}
+	if len(proxy.NameMappings) != 2 {
+		t.Fatalf("expected 2 name mappings, got %d", len(proxy.NameMappings))
+	}
+	if m := proxy.NameMappings[1]; m.ExternalName != "createOrder" || m.ABAPName != "CREATE_ORDER" || m.Kind != "operation" {
+		t.Errorf("unexpected mapping: %+v", m)
+	}
+}
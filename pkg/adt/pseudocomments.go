@@ -0,0 +1,89 @@
+package adt
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/oisee/vibing-steampunk/pkg/abaplint"
+)
+
+// --- Pseudo-Comments, Pragmas and Work Markers ---
+
+// Pseudo-comment kinds reported by FindPseudoComments.
+const (
+	PseudoCommentEC      = "ec"       // "#EC pseudo-comment suppressing a check finding
+	PseudoCommentPragma  = "pragma"   // ##PRAGMA suppressing a check finding
+	PseudoCommentTodo    = "todo"     // TODO marker in a comment
+	PseudoCommentFixme   = "fixme"    // FIXME marker in a comment
+	PseudoCommentABAPDoc = "abap_doc" // "! ABAP Doc comment
+)
+
+// PseudoComment is a suppression or work marker found in ABAP source.
+type PseudoComment struct {
+	Kind string `json:"kind"`
+	Code string `json:"code,omitempty"` // e.g. CI_NOWHERE for "#EC CI_NOWHERE, NEEDED for ##NEEDED
+	Line int    `json:"line"`
+	Text string `json:"text"`
+}
+
+var workMarkerRegex = regexp.MustCompile(`(?i)\b(TODO|FIXME)\b:?\s*(.*)`)
+
+// FindPseudoComments scans ABAP source for "#EC pseudo-comments, ##pragmas,
+// TODO/FIXME markers and "! ABAP Doc comments. Only comment and pragma
+// tokens are considered, so markers inside string literals are ignored.
+func FindPseudoComments(source string) []PseudoComment {
+	tokens := (&abaplint.Lexer{}).Run(source)
+
+	found := []PseudoComment{}
+	for _, tok := range tokens {
+		switch tok.Type {
+		case abaplint.TokenPragma:
+			code := strings.TrimPrefix(tok.Str, "##")
+			if i := strings.Index(code, "["); i >= 0 {
+				code = code[:i]
+			}
+			found = append(found, PseudoComment{
+				Kind: PseudoCommentPragma,
+				Code: strings.ToUpper(code),
+				Line: tok.Row,
+				Text: tok.Str,
+			})
+
+		case abaplint.TokenComment:
+			text := strings.TrimSpace(tok.Str)
+			if strings.HasPrefix(text, `"#EC`) {
+				fields := strings.Fields(strings.TrimPrefix(text, `"#EC`))
+				code := ""
+				if len(fields) > 0 {
+					code = strings.ToUpper(fields[0])
+				}
+				found = append(found, PseudoComment{
+					Kind: PseudoCommentEC,
+					Code: code,
+					Line: tok.Row,
+					Text: text,
+				})
+				continue
+			}
+			if strings.HasPrefix(text, `"!`) {
+				found = append(found, PseudoComment{
+					Kind: PseudoCommentABAPDoc,
+					Line: tok.Row,
+					Text: strings.TrimSpace(strings.TrimPrefix(text, `"!`)),
+				})
+			}
+			if m := workMarkerRegex.FindStringSubmatch(text); m != nil {
+				kind := PseudoCommentTodo
+				if strings.EqualFold(m[1], "FIXME") {
+					kind = PseudoCommentFixme
+				}
+				found = append(found, PseudoComment{
+					Kind: kind,
+					Line: tok.Row,
+					Text: strings.TrimSpace(m[2]),
+				})
+			}
+		}
+	}
+	return found
+}
@@ -0,0 +1,37 @@
+package adt
+
+import "testing"
+
+func TestFindPseudoComments(t *testing.T) {
+	source := `* TODO: split into smaller methods
+SELECT * FROM zdemo_order INTO TABLE @DATA(lt_orders). "#EC CI_NOWHERE
+DATA lv_unused TYPE i ##NEEDED.
+DATA lv_text TYPE string. " fixme handle empty text
+"! Returns the order total
+lv_text = 'TODO in a literal is not a marker'.
+lv_text = text-001. "#EC NOTEXT`
+
+	got := FindPseudoComments(source)
+	want := []PseudoComment{
+		{Kind: PseudoCommentTodo, Line: 1, Text: "split into smaller methods"},
+		{Kind: PseudoCommentEC, Code: "CI_NOWHERE", Line: 2, Text: `"#EC CI_NOWHERE`},
+		{Kind: PseudoCommentPragma, Code: "NEEDED", Line: 3, Text: "##NEEDED"},
+		{Kind: PseudoCommentFixme, Line: 4, Text: "handle empty text"},
+		{Kind: PseudoCommentABAPDoc, Line: 5, Text: "Returns the order total"},
+		{Kind: PseudoCommentEC, Code: "NOTEXT", Line: 7, Text: `"#EC NOTEXT`},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d findings, want %d: %+v", len(got), len(want), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("finding %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestFindPseudoComments_None(t *testing.T) {
+	if got := FindPseudoComments("WRITE 'FIXME'.\n* plain comment"); len(got) != 0 {
+		t.Errorf("expected no findings, got %+v", got)
+	}
+}
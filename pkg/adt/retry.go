@@ -0,0 +1,81 @@
+package adt
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net/http"
+	"time"
+)
+
+// networkError marks a request that failed before any response arrived,
+// e.g. a connection reset. Such failures are retried by WithRetry.
+type networkError struct {
+	err error
+}
+
+func (e *networkError) Error() string { return e.err.Error() }
+func (e *networkError) Unwrap() error { return e.err }
+
+// withRetry runs attempt until it succeeds, fails permanently or the
+// configured number of attempts is used up, sleeping with exponential
+// backoff in between. Without WithRetry it runs attempt exactly once.
+func (t *Transport) withRetry(ctx context.Context, method string, attempt func() (*Response, error)) (*Response, error) {
+	maxAttempts := t.config.RetryMaxAttempts
+	if maxAttempts <= 1 || (isModifyingMethod(method) && !t.config.RetryNonIdempotent) {
+		return attempt()
+	}
+
+	for n := 1; ; n++ {
+		resp, err := attempt()
+		if err == nil || n >= maxAttempts || ctx.Err() != nil || !isTransientError(err) {
+			return resp, err
+		}
+
+		// A rejected CSRF token is fetched again by the next attempt.
+		if isCSRFRejection(err) {
+			t.setCSRFToken("")
+		}
+
+		timer := time.NewTimer(t.retryDelay(n))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// retryDelay returns the backoff before retry n (1-based): the base delay
+// doubled n-1 times, plus up to 50% random jitter.
+func (t *Transport) retryDelay(n int) time.Duration {
+	delay := t.config.RetryBaseDelay
+	if delay <= 0 {
+		delay = 100 * time.Millisecond
+	}
+	delay <<= n - 1
+	return delay + time.Duration(rand.Int63n(int64(delay)/2+1))
+}
+
+// isTransientError reports whether a failed request may succeed when sent
+// again: server errors, network errors and rejected CSRF tokens.
+func isTransientError(err error) bool {
+	var netErr *networkError
+	if errors.As(err, &netErr) {
+		return true
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode >= http.StatusInternalServerError || isCSRFRejection(err)
+	}
+	return false
+}
+
+// isCSRFRejection reports whether the server refused the request because
+// its CSRF token expired or was invalid. It uses the same header and body
+// check as the transport's own token refresh (csrfTokenRequired).
+func isCSRFRejection(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.csrfRejected
+}
@@ -0,0 +1,176 @@
+package adt
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+// flakyDoer fails the first len(failures) non-discovery requests with the
+// given responses (or network errors) and answers 200 afterwards.
+type flakyDoer struct {
+	failures  []*http.Response // nil entries become network errors
+	calls     []string
+	discovery int
+	onFailure func()
+}
+
+func (f *flakyDoer) Do(req *http.Request) (*http.Response, error) {
+	if strings.Contains(req.URL.Path, "discovery") {
+		f.discovery++
+		return newMockResponse(200, "", map[string]string{"X-CSRF-Token": "fresh-token"}), nil
+	}
+	f.calls = append(f.calls, req.Method+" "+req.Header.Get("X-CSRF-Token"))
+	if len(f.calls) > len(f.failures) {
+		return newMockResponse(200, "Success", nil), nil
+	}
+	if f.onFailure != nil {
+		f.onFailure()
+	}
+	if resp := f.failures[len(f.calls)-1]; resp != nil {
+		return resp, nil
+	}
+	return nil, errors.New("connection reset by peer")
+}
+
+func newRetryTransport(doer HTTPDoer, opts ...Option) *Transport {
+	opts = append([]Option{WithRetry(3, time.Millisecond)}, opts...)
+	cfg := NewConfig("https://sap.example.com:44300", "user", "pass", opts...)
+	return NewTransportWithClient(cfg, doer)
+}
+
+func TestTransport_Retry_FailsTwiceThenSucceeds(t *testing.T) {
+	tests := []struct {
+		name     string
+		failures []*http.Response
+	}{
+		{"service unavailable", []*http.Response{
+			newMockResponse(503, "Service Unavailable", nil),
+			newMockResponse(502, "Bad Gateway", nil),
+		}},
+		{"connection reset", []*http.Response{nil, nil}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doer := &flakyDoer{failures: tt.failures}
+			resp, err := newRetryTransport(doer).Request(context.Background(), "/sap/bc/adt/test", nil)
+			if err != nil {
+				t.Fatalf("Request should succeed on the third attempt, got: %v", err)
+			}
+			if string(resp.Body) != "Success" || len(doer.calls) != 3 {
+				t.Errorf("body %q after %d attempts", resp.Body, len(doer.calls))
+			}
+		})
+	}
+}
+
+func TestTransport_Retry_GivesUp(t *testing.T) {
+	doer := &flakyDoer{failures: []*http.Response{
+		newMockResponse(503, "down", nil),
+		newMockResponse(503, "down", nil),
+		newMockResponse(503, "down", nil),
+	}}
+	_, err := newRetryTransport(doer).Request(context.Background(), "/sap/bc/adt/test", nil)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != 503 {
+		t.Fatalf("expected the last 503 after all attempts, got %v", err)
+	}
+	if len(doer.calls) != 3 {
+		t.Errorf("expected 3 attempts, got %d", len(doer.calls))
+	}
+}
+
+func TestTransport_Retry_PermanentErrorNotRetried(t *testing.T) {
+	doer := &flakyDoer{failures: []*http.Response{newMockResponse(404, "not found", nil)}}
+	_, err := newRetryTransport(doer).Request(context.Background(), "/sap/bc/adt/test", nil)
+	if !IsNotFoundError(err) || len(doer.calls) != 1 {
+		t.Errorf("expected a single failed attempt, got %d attempts and %v", len(doer.calls), err)
+	}
+}
+
+func TestTransport_Retry_NonIdempotent(t *testing.T) {
+	post := &RequestOptions{Method: http.MethodPost, Body: []byte("x")}
+
+	doer := &flakyDoer{failures: []*http.Response{nil}}
+	if _, err := newRetryTransport(doer).Request(context.Background(), "/sap/bc/adt/test", post); err == nil {
+		t.Fatal("POST must not be retried by default")
+	}
+	if len(doer.calls) != 1 {
+		t.Errorf("expected 1 POST attempt, got %d", len(doer.calls))
+	}
+
+	doer = &flakyDoer{failures: []*http.Response{nil}}
+	if _, err := newRetryTransport(doer, WithRetryNonIdempotent()).Request(context.Background(), "/sap/bc/adt/test", post); err != nil {
+		t.Fatalf("opted-in POST should be retried, got: %v", err)
+	}
+	if len(doer.calls) != 2 {
+		t.Errorf("expected 2 POST attempts, got %d", len(doer.calls))
+	}
+}
+
+func TestTransport_Retry_RefetchesRejectedCSRFToken(t *testing.T) {
+	rejected := func() *http.Response {
+		return newMockResponse(403, "CSRF token validation failed", map[string]string{"X-CSRF-Token": "Required"})
+	}
+	// The built-in refresh handles the first rejection; the retry loop
+	// drops the token again after the second.
+	doer := &flakyDoer{failures: []*http.Response{rejected(), rejected()}}
+	transport := newRetryTransport(doer, WithRetryNonIdempotent())
+	transport.setCSRFToken("stale-token")
+
+	_, err := transport.Request(context.Background(), "/sap/bc/adt/test", &RequestOptions{Method: http.MethodPost})
+	if err != nil {
+		t.Fatalf("Request should succeed with a fresh token, got: %v", err)
+	}
+	if doer.discovery != 2 {
+		t.Errorf("expected 2 token fetches, got %d", doer.discovery)
+	}
+	if last := doer.calls[len(doer.calls)-1]; last != "POST fresh-token" {
+		t.Errorf("last attempt sent %q", last)
+	}
+}
+
+func TestTransport_CSRFRejectionMatchesTokenRefresh(t *testing.T) {
+	tests := []struct {
+		name string
+		resp *http.Response
+		want bool
+	}{
+		{"required header", newMockResponse(403, "", map[string]string{"X-CSRF-Token": "Required"}), true},
+		{"validation message", newMockResponse(403, "CSRF token validation failed", nil), true},
+		{"other csrf text", newMockResponse(403, "No authorization for CSRF-protected service", nil), false},
+		{"not forbidden", newMockResponse(400, "CSRF token validation failed", nil), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := NewConfig("https://sap.example.com:44300", "user", "pass")
+			transport := NewTransportWithClient(cfg, &mockHTTPClient{responses: []*http.Response{tt.resp}})
+			_, err := transport.Request(context.Background(), "/sap/bc/adt/test", nil)
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			if got := isCSRFRejection(err); got != tt.want {
+				t.Errorf("isCSRFRejection = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTransport_Retry_StopsOnCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	doer := &flakyDoer{
+		failures:  []*http.Response{newMockResponse(503, "down", nil), newMockResponse(503, "down", nil)},
+		onFailure: cancel,
+	}
+	cfg := NewConfig("https://sap.example.com:44300", "user", "pass", WithRetry(3, time.Hour))
+	_, err := NewTransportWithClient(cfg, doer).Request(ctx, "/sap/bc/adt/test", nil)
+	if err == nil {
+		t.Fatal("expected the failed attempt to be returned")
+	}
+	if len(doer.calls) != 1 {
+		t.Errorf("expected no retry after cancellation, got %d attempts", len(doer.calls))
+	}
+}
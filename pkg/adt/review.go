@@ -0,0 +1,153 @@
+package adt
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// --- Static Review ---
+
+// ReviewOptions toggles the sub-checks of StaticReview. A nil options value
+// runs the syntax check and all local source scanners, but not ATC.
+type ReviewOptions struct {
+	SkipSyntaxCheck bool
+	RunATC          bool   // ATC runs are slow, so they are opt-in
+	ATCVariant      string // empty uses the system default variant
+	SkipSQLSmells   bool
+	SkipSecrets     bool
+	SkipAuthChecks  bool
+	Parent          string // function group for FUNC objects
+}
+
+// ReviewFinding is one finding of a StaticReview, whatever check produced it.
+type ReviewFinding struct {
+	Source   string `json:"source"`   // syntax, atc, sql, secret, auth
+	Severity string `json:"severity"` // error, warning, info
+	Category string `json:"category,omitempty"`
+	Line     int    `json:"line"`
+	Message  string `json:"message"`
+}
+
+// ReviewResult is the merged outcome of StaticReview.
+type ReviewResult struct {
+	ObjectType string          `json:"objectType"`
+	Name       string          `json:"name"`
+	Findings   []ReviewFinding `json:"findings"`
+	// Errors lists sub-checks that could not run; the review itself still succeeds.
+	Errors []string `json:"errors,omitempty"`
+}
+
+// reviewSeverityRank orders findings on the same line: errors first.
+var reviewSeverityRank = map[string]int{"error": 0, "warning": 1, "info": 2}
+
+// StaticReview reviews an object in one call: it reads the source, runs the
+// syntax check, optionally ATC, and the local scanners (FindSQLSmells,
+// FindSecrets, FindAuthorityChecks), and merges all findings into one list
+// sorted by line, errors before warnings before infos on the same line.
+// A failing syntax check or ATC run is reported in Errors instead of
+// failing the review.
+func (c *Client) StaticReview(ctx context.Context, objectType, name string, opts *ReviewOptions) (*ReviewResult, error) {
+	if opts == nil {
+		opts = &ReviewOptions{}
+	}
+	objectType = strings.ToUpper(objectType)
+	name = strings.ToUpper(name)
+
+	source, err := c.GetSource(ctx, objectType, name, &GetSourceOptions{Parent: opts.Parent})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ReviewResult{ObjectType: objectType, Name: name, Findings: []ReviewFinding{}}
+	add := func(f ReviewFinding) { result.Findings = append(result.Findings, f) }
+
+	objectURL := ""
+	if t, ok := objectTypeFromShort(objectType); ok {
+		objectURL = GetObjectURL(t, name, opts.Parent)
+	}
+
+	if !opts.SkipSyntaxCheck && objectURL != "" {
+		msgs, err := c.SyntaxCheck(ctx, objectURL, source)
+		if err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("syntax check: %v", err))
+		}
+		for _, m := range msgs {
+			add(ReviewFinding{Source: "syntax", Severity: syntaxSeverity(m.Severity), Line: m.Line, Message: m.Text})
+		}
+	}
+
+	if opts.RunATC && objectURL != "" {
+		worklist, err := c.RunATCCheck(ctx, objectURL, opts.ATCVariant, 100)
+		if err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("ATC: %v", err))
+		} else {
+			for _, obj := range worklist.Objects {
+				for _, f := range obj.Findings {
+					add(ReviewFinding{
+						Source:   "atc",
+						Severity: atcSeverity(f.Priority),
+						Category: f.CheckTitle,
+						Line:     f.Line,
+						Message:  f.MessageTitle,
+					})
+				}
+			}
+		}
+	}
+
+	if !opts.SkipSQLSmells {
+		for _, s := range FindSQLSmells(source) {
+			add(ReviewFinding{Source: "sql", Severity: "warning", Category: s.Category, Line: s.Line, Message: s.Statement})
+		}
+	}
+
+	if !opts.SkipSecrets {
+		for _, s := range FindSecrets(source) {
+			add(ReviewFinding{Source: "secret", Severity: "error", Category: s.Kind, Line: s.Line, Message: s.Snippet})
+		}
+	}
+
+	if !opts.SkipAuthChecks {
+		for _, a := range FindAuthorityChecks(source) {
+			severity, msg := "info", fmt.Sprintf("AUTHORITY-CHECK on object %s", a.Object)
+			if a.Dynamic {
+				severity, msg = "warning", fmt.Sprintf("AUTHORITY-CHECK on dynamic object %s", a.Object)
+			}
+			add(ReviewFinding{Source: "auth", Severity: severity, Category: "authority_check", Line: a.Line, Message: msg})
+		}
+	}
+
+	sort.SliceStable(result.Findings, func(i, j int) bool {
+		a, b := result.Findings[i], result.Findings[j]
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return reviewSeverityRank[a.Severity] < reviewSeverityRank[b.Severity]
+	})
+
+	return result, nil
+}
+
+func syntaxSeverity(s string) string {
+	switch s {
+	case "E", "A", "X":
+		return "error"
+	case "W":
+		return "warning"
+	default:
+		return "info"
+	}
+}
+
+func atcSeverity(priority int) string {
+	switch priority {
+	case 1:
+		return "error"
+	case 2:
+		return "warning"
+	default:
+		return "info"
+	}
+}
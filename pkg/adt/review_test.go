@@ -0,0 +1,84 @@
+package adt
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestStaticReview_MergesAndSortsFindings(t *testing.T) {
+	source := `REPORT zdemo_review.
+AUTHORITY-CHECK OBJECT 'Z_DEMO_ORD' ID 'ACTVT' FIELD '03'.
+SELECT * FROM sflight INTO TABLE @DATA(lt_all) WHERE carrid = 'LH'.
+lv_password = 'Demo-Secret'.
+`
+	syntaxXML := `<?xml version="1.0" encoding="UTF-8"?>
+<chkrun:checkRunReports xmlns:chkrun="http://www.sap.com/adt/checkrun">
+  <chkrun:checkReport>
+    <chkrun:checkMessageList>
+      <chkrun:checkMessage chkrun:uri="/sap/bc/adt/programs/programs/ZDEMO_REVIEW/source/main#start=4,0" chkrun:type="W" chkrun:shortText="Variable LV_PASSWORD is not declared"/>
+    </chkrun:checkMessageList>
+  </chkrun:checkReport>
+</chkrun:checkRunReports>`
+
+	mock := &methodPathMock{routes: []routedResponse{
+		resp("", "discovery", http.StatusOK, "ok"),
+		resp("GET", "/sap/bc/adt/programs/programs/ZDEMO_REVIEW/source/main", http.StatusOK, source),
+		resp("POST", "/sap/bc/adt/checkruns", http.StatusOK, syntaxXML),
+	}}
+	cfg := NewConfig("https://sap.example.com:44300", "user", "pass")
+	client := NewClientWithTransport(cfg, NewTransportWithClient(cfg, mock))
+
+	result, err := client.StaticReview(context.Background(), "PROG", "zdemo_review", nil)
+	if err != nil {
+		t.Fatalf("StaticReview failed: %v", err)
+	}
+	if len(result.Errors) != 0 {
+		t.Fatalf("unexpected sub-check errors: %v", result.Errors)
+	}
+
+	want := []struct {
+		line   int
+		source string
+	}{
+		{2, "auth"},
+		{3, "sql"},
+		{4, "secret"}, // error sorts before the warning on the same line
+		{4, "syntax"},
+	}
+	if len(result.Findings) != len(want) {
+		t.Fatalf("expected %d findings, got %+v", len(want), result.Findings)
+	}
+	for i, w := range want {
+		f := result.Findings[i]
+		if f.Line != w.line || f.Source != w.source {
+			t.Errorf("finding %d = %s@%d, want %s@%d", i, f.Source, f.Line, w.source, w.line)
+		}
+	}
+}
+
+func TestStaticReview_Toggles(t *testing.T) {
+	source := "REPORT zdemo_review.\nSELECT * FROM sflight INTO TABLE @DATA(lt_all).\n"
+	mock := &methodPathMock{routes: []routedResponse{
+		resp("", "discovery", http.StatusOK, "ok"),
+		resp("GET", "/source/main", http.StatusOK, source),
+	}}
+	cfg := NewConfig("https://sap.example.com:44300", "user", "pass")
+	client := NewClientWithTransport(cfg, NewTransportWithClient(cfg, mock))
+
+	result, err := client.StaticReview(context.Background(), "PROG", "ZDEMO_REVIEW", &ReviewOptions{
+		SkipSyntaxCheck: true,
+		SkipSQLSmells:   true,
+	})
+	if err != nil {
+		t.Fatalf("StaticReview failed: %v", err)
+	}
+	if len(result.Findings) != 0 {
+		t.Errorf("expected no findings with SQL smells disabled, got %+v", result.Findings)
+	}
+	for _, call := range mock.calls {
+		if call.method == "POST" {
+			t.Errorf("syntax check should be skipped, got %s %s", call.method, call.path)
+		}
+	}
+}
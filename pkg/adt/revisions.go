@@ -41,6 +41,16 @@ func (c *Client) GetRevisions(ctx context.Context, objectType, name string, opts
 	return ParseRevisionFeed(resp.Body)
 }
 
+// ObjectVersion is one entry of an object's version history.
+type ObjectVersion = Revision
+
+// GetObjectVersions lists the version history of an object, newest first:
+// version, author, date and transport of each entry. It is GetRevisions
+// without options; use GetRevisions for class includes and function modules.
+func (c *Client) GetObjectVersions(ctx context.Context, objectType, name string) ([]ObjectVersion, error) {
+	return c.GetRevisions(ctx, objectType, name, nil)
+}
+
 // GetRevisionSource retrieves the source code of a specific object version.
 // The versionURI comes from GetRevisions output (the URI field of a Revision entry).
 func (c *Client) GetRevisionSource(ctx context.Context, versionURI string) (string, error) {
@@ -63,6 +73,38 @@ func (c *Client) GetRevisionSource(ctx context.Context, versionURI string) (stri
 	return string(resp.Body), nil
 }
 
+// GetObjectVersionSource retrieves the source of one historical version of
+// an object. version is the Version of an entry returned by
+// GetObjectVersions; its content URI is looked up in the version history,
+// so a version that has since been deleted is reported as not existing.
+func (c *Client) GetObjectVersionSource(ctx context.Context, objectType, name, version string) (string, error) {
+	if version == "" {
+		return "", fmt.Errorf("version is required")
+	}
+
+	versions, err := c.GetObjectVersions(ctx, objectType, name)
+	if err != nil {
+		return "", err
+	}
+
+	objectType = strings.ToUpper(objectType)
+	name = strings.ToUpper(name)
+	for _, v := range versions {
+		if v.Version != version {
+			continue
+		}
+		if v.URI == "" {
+			return "", fmt.Errorf("version %s of %s %s has no content URI", version, objectType, name)
+		}
+		source, err := c.GetRevisionSource(ctx, v.URI)
+		if IsNotFoundError(err) {
+			return "", fmt.Errorf("version %s of %s %s no longer exists: %w", version, objectType, name, err)
+		}
+		return source, err
+	}
+	return "", fmt.Errorf("version %s of %s %s does not exist (%d versions available)", version, objectType, name, len(versions))
+}
+
 // CompareVersions compares two versions of an ABAP object and returns a unified diff.
 // version1URI and version2URI are from GetRevisions output.
 // Use "current" as version2URI to compare against the active version.
@@ -103,9 +145,7 @@ func (c *Client) CompareVersions(ctx context.Context, objectType, name string, v
 		return result, nil
 	}
 
-	lines1 := strings.Split(source1, "\n")
-	lines2 := strings.Split(source2, "\n")
-	result.Diff = generateUnifiedDiff(label1, label2, lines1, lines2)
+	result.Diff = generateUnifiedDiff(label1, label2, source1, source2)
 
 	for _, line := range strings.Split(result.Diff, "\n") {
 		if strings.HasPrefix(line, "+") && !strings.HasPrefix(line, "+++") {
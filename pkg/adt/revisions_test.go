@@ -300,6 +300,66 @@ func TestClient_GetRevisions(t *testing.T) {
 	}
 }
 
+func TestClient_GetObjectVersions(t *testing.T) {
+	feedXML := `<?xml version="1.0" encoding="UTF-8"?>
+<atom:feed xmlns:atom="http://www.w3.org/2005/Atom"
+           xmlns:adtcore="http://www.sap.com/adt/core">
+  <atom:title>Versions of ZCL_DEMO_ORDER</atom:title>
+  <atom:entry>
+    <atom:id>00002</atom:id>
+    <atom:title>Active Version</atom:title>
+    <atom:updated>2026-03-02T10:00:00Z</atom:updated>
+    <atom:author><atom:name>TESTUSER</atom:name></atom:author>
+    <atom:content src="/sap/bc/adt/oo/classes/zcl_demo_order/includes/main/versions/20260302100000/00002/content" type="text/plain"/>
+    <atom:link href="/sap/bc/adt/cts/transportrequests/TR-EXAMPLE" rel="http://www.sap.com/adt/relations/transport"
+               type="application/vnd.sap.adt.transportrequests.v1+xml" adtcore:name="TR-EXAMPLE"/>
+  </atom:entry>
+  <atom:entry>
+    <atom:id>00001</atom:id>
+    <atom:title>Version 00001</atom:title>
+    <atom:updated>2026-02-10T09:30:00Z</atom:updated>
+    <atom:author><atom:name>DEVELOPER2</atom:name></atom:author>
+    <atom:content src="/sap/bc/adt/oo/classes/zcl_demo_order/includes/main/versions/20260210093000/00001/content" type="text/plain"/>
+    <atom:link href="/sap/bc/adt/cts/transportrequests/CR-EXAMPLE" rel="http://www.sap.com/adt/relations/transport"
+               type="application/vnd.sap.adt.transportrequests.v1+xml" adtcore:name="CR-EXAMPLE"/>
+  </atom:entry>
+  <atom:entry>
+    <atom:id>00000</atom:id>
+    <atom:title>Version 00000</atom:title>
+    <atom:updated>2026-01-05T08:00:00Z</atom:updated>
+    <atom:author><atom:name>TESTUSER</atom:name></atom:author>
+    <atom:content src="/sap/bc/adt/oo/classes/zcl_demo_order/includes/main/versions/20260105080000/00000/content" type="text/plain"/>
+  </atom:entry>
+</atom:feed>`
+
+	mock := &mockTransportClient{
+		responses: map[string]*http.Response{
+			"/sap/bc/adt/oo/classes/ZCL_DEMO_ORDER/includes/main/versions": newTestResponse(feedXML),
+		},
+	}
+	cfg := NewConfig("https://sap.example.com:44300", "user", "pass")
+	client := NewClientWithTransport(cfg, NewTransportWithClient(cfg, mock))
+
+	versions, err := client.GetObjectVersions(context.Background(), "CLAS", "zcl_demo_order")
+	if err != nil {
+		t.Fatalf("GetObjectVersions failed: %v", err)
+	}
+	want := []struct{ version, author, date, transport string }{
+		{"00002", "TESTUSER", "2026-03-02T10:00:00Z", "TR-EXAMPLE"},
+		{"00001", "DEVELOPER2", "2026-02-10T09:30:00Z", "CR-EXAMPLE"},
+		{"00000", "TESTUSER", "2026-01-05T08:00:00Z", ""},
+	}
+	if len(versions) != len(want) {
+		t.Fatalf("expected %d versions, got %d", len(want), len(versions))
+	}
+	for i, w := range want {
+		v := versions[i]
+		if v.Version != w.version || v.Author != w.author || v.Date != w.date || v.Transport != w.transport {
+			t.Errorf("versions[%d] = %+v, want %+v", i, v, w)
+		}
+	}
+}
+
 func TestClient_GetRevisionSource(t *testing.T) {
 	sourceCode := "REPORT ztest.\nWRITE 'Old version'."
 
@@ -323,6 +383,56 @@ func TestClient_GetRevisionSource(t *testing.T) {
 	}
 }
 
+func TestClient_GetObjectVersionSource(t *testing.T) {
+	feedXML := `<?xml version="1.0" encoding="UTF-8"?>
+<atom:feed xmlns:atom="http://www.w3.org/2005/Atom">
+  <atom:entry>
+    <atom:id>00001</atom:id>
+    <atom:content src="/sap/bc/adt/oo/classes/zcl_demo_order/includes/main/versions/20260210093000/00001/content" type="text/plain"/>
+  </atom:entry>
+  <atom:entry>
+    <atom:id>00000</atom:id>
+    <atom:content src="/sap/bc/adt/oo/classes/zcl_demo_order/includes/main/versions/20260105080000/00000/content" type="text/plain"/>
+  </atom:entry>
+</atom:feed>`
+
+	// Response bodies can be read once, so every call gets a fresh mock.
+	newClient := func() (*Client, *mockTransportClient) {
+		mock := &mockTransportClient{
+			responses: map[string]*http.Response{
+				"/sap/bc/adt/oo/classes/ZCL_DEMO_ORDER/includes/main/versions":                              newTestResponse(feedXML),
+				"/sap/bc/adt/oo/classes/zcl_demo_order/includes/main/versions/20260105080000/00000/content": newTestResponse("CLASS zcl_demo_order DEFINITION."),
+			},
+		}
+		cfg := NewConfig("https://sap.example.com:44300", "user", "pass")
+		return NewClientWithTransport(cfg, NewTransportWithClient(cfg, mock)), mock
+	}
+
+	client, mock := newClient()
+	source, err := client.GetObjectVersionSource(context.Background(), "CLAS", "ZCL_DEMO_ORDER", "00000")
+	if err != nil {
+		t.Fatalf("GetObjectVersionSource failed: %v", err)
+	}
+	if source != "CLASS zcl_demo_order DEFINITION." {
+		t.Errorf("source = %q", source)
+	}
+	if got := mock.requests[len(mock.requests)-1].URL.Path; got != "/sap/bc/adt/oo/classes/zcl_demo_order/includes/main/versions/20260105080000/00000/content" {
+		t.Errorf("requested %s, want the content URI of version 00000", got)
+	}
+
+	client, _ = newClient()
+	_, err = client.GetObjectVersionSource(context.Background(), "CLAS", "ZCL_DEMO_ORDER", "00007")
+	if err == nil || !strings.Contains(err.Error(), "version 00007 of CLAS ZCL_DEMO_ORDER does not exist") {
+		t.Errorf("missing version error = %v", err)
+	}
+	// Listed, but the content is gone.
+	client, _ = newClient()
+	_, err = client.GetObjectVersionSource(context.Background(), "CLAS", "ZCL_DEMO_ORDER", "00001")
+	if err == nil || !strings.Contains(err.Error(), "no longer exists") {
+		t.Errorf("deleted version error = %v", err)
+	}
+}
+
 func TestClient_GetRevisionSource_EmptyURI(t *testing.T) {
 	cfg := NewConfig("https://sap.example.com:44300", "user", "pass")
 	transport := NewTransportWithClient(cfg, &mockTransportClient{responses: map[string]*http.Response{}})
@@ -1,6 +1,7 @@
 package adt
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 )
@@ -39,10 +40,19 @@ type SafetyConfig struct {
 
 	// AllowedPackages restricts operations to specific packages (empty = all packages allowed)
 	// Example: []string{"$TMP", "ZTEST", "Z*"} - only allow local and test packages
-	// Supports wildcards: "Z*" matches all packages starting with Z
+	// Supports case-insensitive globs: "*" matches any run of characters
+	// (including the "/" of namespaces), "?" a single character
 	AllowedPackages []string
 
-	// DryRun mode - log operations but don't execute them (useful for testing)
+	// DenyPatterns are package globs that are always blocked, even when the
+	// package also matches AllowedPackages.
+	// Example: []string{"/SAP*", "S*"}
+	DenyPatterns []string
+
+	// DryRun mode - mutating operations (create, update, delete, activate,
+	// workflows, locks, transport and gCTS writes) fail with ErrDryRun
+	// instead of executing; reads go through. All other restrictions still
+	// apply first. Callers can detect ErrDryRun to report the action.
 	DryRun bool
 
 	// EnableTransports explicitly enables transport management operations
@@ -119,19 +129,26 @@ const (
 	OpTransport    OperationType = 'X' // Transport management (requires explicit opt-in)
 )
 
+// writeOperations are the operation types that modify the system.
+const writeOperations = "CDUAW" // Create, Delete, Update, Activate, Workflow
+
+// dryRunOperations are the operation types DryRun refuses to execute:
+// writeOperations plus Lock, which takes an enqueue on the server.
+// Transport operations mix reads and writes, so their mutating calls use
+// CheckWriteOperation or CheckTransport instead.
+const dryRunOperations = writeOperations + "L"
+
+// ErrDryRun is returned by CheckOperation for mutating operations when
+// SafetyConfig.DryRun is set.
+var ErrDryRun = errors.New("dry run: operation not executed")
+
 // IsOperationAllowed checks if an operation type is allowed by the safety config
 func (s *SafetyConfig) IsOperationAllowed(op OperationType) bool {
 	opChar := rune(op)
 
-	// Check DryRun - all operations are "allowed" but won't execute
-	if s.DryRun {
-		return true
-	}
-
 	// Check ReadOnly mode - blocks all write operations
 	if s.ReadOnly {
-		writeOps := "CDUAW" // Create, Delete, Update, Activate, Workflow
-		if strings.ContainsRune(writeOps, opChar) {
+		if strings.ContainsRune(writeOperations, opChar) {
 			return false
 		}
 	}
@@ -164,41 +181,96 @@ func (s *SafetyConfig) CheckOperation(op OperationType, opName string) error {
 	if !s.IsOperationAllowed(op) {
 		return fmt.Errorf("operation '%s' (type %c) is blocked by safety configuration", opName, op)
 	}
+	if s.DryRun && strings.ContainsRune(dryRunOperations, rune(op)) {
+		return fmt.Errorf("operation '%s' (type %c): %w", opName, op, ErrDryRun)
+	}
+	return nil
+}
+
+// CheckWriteOperation is CheckOperation for a call that modifies the system
+// even though its operation type also covers reads, such as releasing a
+// transport or pulling into a gCTS repository. Under DryRun it fails with
+// ErrDryRun once the operation is otherwise allowed.
+func (s *SafetyConfig) CheckWriteOperation(op OperationType, opName string) error {
+	if err := s.CheckOperation(op, opName); err != nil {
+		return err
+	}
+	if s.DryRun {
+		return fmt.Errorf("operation '%s' (type %c): %w", opName, op, ErrDryRun)
+	}
 	return nil
 }
 
-// IsPackageAllowed checks if operations on a given package are allowed
+// HasPackageRestrictions reports whether any package allow or deny list is
+// configured.
+func (s *SafetyConfig) HasPackageRestrictions() bool {
+	return len(s.AllowedPackages) > 0 || len(s.DenyPatterns) > 0
+}
+
+// IsPackageAllowed checks if operations on a given package are allowed.
+// DenyPatterns take precedence; otherwise the package must match
+// AllowedPackages, unless it is empty.
 func (s *SafetyConfig) IsPackageAllowed(pkg string) bool {
-	// Empty AllowedPackages = all packages allowed
+	for _, denied := range s.DenyPatterns {
+		if matchPackageGlob(denied, pkg) {
+			return false
+		}
+	}
+
+	// Empty list = all packages allowed
 	if len(s.AllowedPackages) == 0 {
 		return true
 	}
 
-	pkg = strings.ToUpper(pkg)
-
 	for _, allowed := range s.AllowedPackages {
-		allowed = strings.ToUpper(allowed)
-
-		// Exact match
-		if allowed == pkg {
+		if matchPackageGlob(allowed, pkg) {
 			return true
 		}
-
-		// Wildcard match (e.g., "Z*" matches "ZTEST", "ZRAY", etc.)
-		if strings.HasSuffix(allowed, "*") {
-			prefix := strings.TrimSuffix(allowed, "*")
-			if strings.HasPrefix(pkg, prefix) {
-				return true
-			}
-		}
 	}
 
 	return false
 }
 
+// matchPackageGlob matches a package name against a case-insensitive glob
+// where "*" matches any run of characters (including the "/" of namespaces)
+// and "?" matches exactly one character.
+func matchPackageGlob(pattern, name string) bool {
+	p := []rune(strings.ToUpper(pattern))
+	n := []rune(strings.ToUpper(name))
+
+	pi, ni := 0, 0
+	star, mark := -1, 0
+	for ni < len(n) {
+		switch {
+		case pi < len(p) && (p[pi] == '?' || p[pi] == n[ni]):
+			pi++
+			ni++
+		case pi < len(p) && p[pi] == '*':
+			star, mark = pi, ni
+			pi++
+		case star >= 0:
+			pi = star + 1
+			mark++
+			ni = mark
+		default:
+			return false
+		}
+	}
+	for pi < len(p) && p[pi] == '*' {
+		pi++
+	}
+	return pi == len(p)
+}
+
 // CheckPackage returns an error if the package is not allowed
 func (s *SafetyConfig) CheckPackage(pkg string) error {
 	if !s.IsPackageAllowed(pkg) {
+		for _, denied := range s.DenyPatterns {
+			if matchPackageGlob(denied, pkg) {
+				return fmt.Errorf("operations on package '%s' are blocked by safety configuration (denied by %q)",
+					pkg, denied)
+			}
+		}
 		return fmt.Errorf("operations on package '%s' are blocked by safety configuration (allowed: %v)",
 			pkg, s.AllowedPackages)
 	}
@@ -284,6 +356,10 @@ func (s *SafetyConfig) CheckTransport(transport, opName string, isWrite bool) er
 		}
 	}
 
+	if isWrite && s.DryRun {
+		return fmt.Errorf("transport operation '%s': %w", opName, ErrDryRun)
+	}
+
 	return nil
 }
 
@@ -369,6 +445,10 @@ func (s *SafetyConfig) String() string {
 		parts = append(parts, fmt.Sprintf("AllowedPackages=%v", s.AllowedPackages))
 	}
 
+	if len(s.DenyPatterns) > 0 {
+		parts = append(parts, fmt.Sprintf("DenyPatterns=%v", s.DenyPatterns))
+	}
+
 	if s.EnableTransports {
 		parts = append(parts, "TRANSPORTS-ENABLED")
 		if s.TransportReadOnly {
@@ -1,6 +1,9 @@
 package adt
 
 import (
+	"context"
+	"errors"
+	"net/http"
 	"testing"
 )
 
@@ -102,10 +105,10 @@ func TestSafetyConfig_IsOperationAllowed(t *testing.T) {
 			expected: false,
 		},
 		{
-			name:     "DryRun allows all",
+			name:     "DryRun keeps ReadOnly",
 			config:   SafetyConfig{DryRun: true, ReadOnly: true},
 			op:       OpCreate,
-			expected: true,
+			expected: false,
 		},
 		{
 			name:     "Unrestricted allows all",
@@ -141,6 +144,90 @@ func TestSafetyConfig_CheckOperation(t *testing.T) {
 	}
 }
 
+func TestSafetyConfig_CheckOperation_DryRun(t *testing.T) {
+	config := SafetyConfig{DryRun: true}
+
+	if err := config.CheckOperation(OpRead, "GetClass"); err != nil {
+		t.Errorf("CheckOperation(OpRead) should pass under dry-run, got: %v", err)
+	}
+	for _, op := range []OperationType{OpCreate, OpUpdate, OpDelete, OpActivate, OpWorkflow} {
+		err := config.CheckOperation(op, "Mutation")
+		if !errors.Is(err, ErrDryRun) {
+			t.Errorf("CheckOperation(%c) = %v, want ErrDryRun", op, err)
+		}
+	}
+}
+
+func TestSafetyConfig_DryRunKeepsRestrictions(t *testing.T) {
+	config := SafetyConfig{DryRun: true, BlockFreeSQL: true}
+	if err := config.CheckOperation(OpFreeSQL, "RunQuery"); err == nil || errors.Is(err, ErrDryRun) {
+		t.Errorf("RunQuery under dry-run with BlockFreeSQL = %v, want blocked", err)
+	}
+	if err := config.CheckOperation(OpLock, "LockObject"); !errors.Is(err, ErrDryRun) {
+		t.Errorf("LockObject under dry-run = %v, want ErrDryRun", err)
+	}
+
+	config = SafetyConfig{DryRun: true, EnableTransports: true, DisallowedOps: "X"}
+	if err := config.CheckWriteOperation(OpTransport, "ReleaseTransport"); err == nil || errors.Is(err, ErrDryRun) {
+		t.Errorf("ReleaseTransport under dry-run with DisallowedOps=X = %v, want blocked", err)
+	}
+	config = SafetyConfig{DryRun: true}
+	if err := config.CheckWriteOperation(OpTransport, "ReleaseTransport"); err == nil || errors.Is(err, ErrDryRun) {
+		t.Errorf("ReleaseTransport under dry-run without EnableTransports = %v, want blocked", err)
+	}
+	config = SafetyConfig{DryRun: true, EnableTransports: true}
+	if err := config.CheckOperation(OpTransport, "GetUserTransports"); err != nil {
+		t.Errorf("transport read under dry-run = %v, want nil", err)
+	}
+	if err := config.CheckWriteOperation(OpTransport, "ReleaseTransport"); !errors.Is(err, ErrDryRun) {
+		t.Errorf("ReleaseTransport under dry-run = %v, want ErrDryRun", err)
+	}
+	if err := config.CheckTransport("A4HK900110", "ReleaseTransport", true); !errors.Is(err, ErrDryRun) {
+		t.Errorf("CheckTransport write under dry-run = %v, want ErrDryRun", err)
+	}
+}
+
+func TestClient_DryRunReleaseTransport(t *testing.T) {
+	mock := &methodPathMock{routes: []routedResponse{
+		resp("", "", http.StatusOK, ""),
+	}}
+	cfg := NewConfig("https://sap.example.com:44300", "user", "pass")
+	cfg.Safety = SafetyConfig{DryRun: true, EnableTransports: true}
+	client := NewClientWithTransport(cfg, NewTransportWithClient(cfg, mock))
+
+	_, err := client.ReleaseTransport(context.Background(), "A4HK900110", false)
+	if !errors.Is(err, ErrDryRun) {
+		t.Fatalf("ReleaseTransport under dry-run = %v, want ErrDryRun", err)
+	}
+	if len(mock.calls) != 0 {
+		t.Errorf("dry-run release reached the server: %+v", mock.calls)
+	}
+}
+
+func TestClient_DryRunBlocksWrites(t *testing.T) {
+	mock := &methodPathMock{routes: []routedResponse{
+		resp(http.MethodGet, "/source/main", http.StatusOK, "REPORT zdemo_report."),
+		resp("", "", http.StatusOK, ""),
+	}}
+	cfg := NewConfig("https://sap.example.com:44300", "user", "pass")
+	cfg.Safety = SafetyConfig{DryRun: true}
+	client := NewClientWithTransport(cfg, NewTransportWithClient(cfg, mock))
+	ctx := context.Background()
+
+	if _, err := client.GetProgram(ctx, "ZDEMO_REPORT"); err != nil {
+		t.Fatalf("read should pass under dry-run: %v", err)
+	}
+	calls := len(mock.calls)
+
+	err := client.DeleteObject(ctx, "/sap/bc/adt/programs/programs/ZDEMO_REPORT", "LOCK1", "")
+	if !errors.Is(err, ErrDryRun) {
+		t.Fatalf("DeleteObject under dry-run = %v, want ErrDryRun", err)
+	}
+	if len(mock.calls) != calls {
+		t.Errorf("dry-run write reached the server: %+v", mock.calls[calls:])
+	}
+}
+
 func TestSafetyConfig_IsPackageAllowed(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -202,6 +289,41 @@ func TestSafetyConfig_IsPackageAllowed(t *testing.T) {
 	}
 }
 
+func TestSafetyConfig_PackagePatterns(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   SafetyConfig
+		pkg      string
+		expected bool
+	}{
+		{"empty allows all", SafetyConfig{}, "/SAPAPO/MAIN", true},
+		{"allowed glob", SafetyConfig{AllowedPackages: []string{"z*", "$TMP"}}, "ZDEMO_CORE", true},
+		{"allowed glob exact", SafetyConfig{AllowedPackages: []string{"z*", "$TMP"}}, "$tmp", true},
+		{"allowed glob miss", SafetyConfig{AllowedPackages: []string{"Z*", "$TMP"}}, "SAPDEMO", false},
+		{"question mark", SafetyConfig{AllowedPackages: []string{"$ZDEMO_?"}}, "$ZDEMO_1", true},
+		{"deny over allow", SafetyConfig{AllowedPackages: []string{"*"}, DenyPatterns: []string{"/SAP*"}}, "/SAPAPO/MAIN", false},
+		{"deny over allowed packages", SafetyConfig{AllowedPackages: []string{"Z*"}, DenyPatterns: []string{"ZDEMO_PROD*"}}, "ZDEMO_PROD_FI", false},
+		{"deny only", SafetyConfig{DenyPatterns: []string{"/SAP*"}}, "ZDEMO_CORE", true},
+		{"namespace glob", SafetyConfig{AllowedPackages: []string{"/DEMO/*"}}, "/demo/core/sub", true},
+		{"namespace glob miss", SafetyConfig{AllowedPackages: []string{"/DEMO/*"}}, "/DEMOX/CORE", false},
+		{"mid glob", SafetyConfig{DenyPatterns: []string{"*_PROD"}}, "ZDEMO_PROD", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.config.IsPackageAllowed(tt.pkg); got != tt.expected {
+				t.Errorf("IsPackageAllowed(%q) = %v, expected %v", tt.pkg, got, tt.expected)
+			}
+		})
+	}
+
+	config := SafetyConfig{AllowedPackages: []string{"*"}, DenyPatterns: []string{"/SAP*"}}
+	err := config.CheckPackage("/SAPAPO/MAIN")
+	if err == nil || !contains(err.Error(), `denied by "/SAP*"`) {
+		t.Errorf("CheckPackage should name the deny pattern, got %v", err)
+	}
+}
+
 func TestSafetyConfig_CheckPackage(t *testing.T) {
 	config := SafetyConfig{AllowedPackages: []string{"$TMP", "Z*"}}
 
@@ -24,8 +24,13 @@ func mockSAMLServer(t *testing.T, expectedUser, expectedPassword string) *httpte
 	t.Helper()
 	mux := http.NewServeMux()
 
-	// SAP SP: redirect to IdP login
+	// SAP SP: redirect to IdP login unless the SSO cookie is present
 	mux.HandleFunc("/sap/bc/adt/", func(w http.ResponseWriter, r *http.Request) {
+		if c, err := r.Cookie("MYSAPSSO2"); err == nil && c.Value == "sso2token" {
+			w.Header().Set("X-CSRF-Token", "test-token")
+			fmt.Fprintf(w, "<ok/>")
+			return
+		}
 		idpURL := "http://" + r.Host + "/idp/login?SAMLRequest=base64encodedrequest"
 		http.Redirect(w, r, idpURL, http.StatusFound)
 	})
@@ -239,6 +244,31 @@ func TestSAMLLogin_ReauthOn401(t *testing.T) {
 	}
 }
 
+func TestClient_RefreshCookies_SAMLLogin(t *testing.T) {
+	srv := mockSAMLServer(t, "admin@example.com", "secret123")
+	defer srv.Close()
+
+	client := NewClient(srv.URL, "", "", WithSAMLLogin(testCredProvider("admin@example.com", "secret123")))
+	if err := client.RefreshCookies(context.Background()); err != nil {
+		t.Fatalf("RefreshCookies failed: %v", err)
+	}
+	if got := client.config.Cookies["MYSAPSSO2"]; got != "sso2token" {
+		t.Fatalf("MYSAPSSO2 = %q, want the cookie set at the end of the redirect flow", got)
+	}
+
+	resp, err := client.transport.Request(context.Background(), "/sap/bc/adt/discovery", nil)
+	if err != nil {
+		t.Fatalf("Request with SSO cookies failed: %v", err)
+	}
+	if string(resp.Body) != "<ok/>" {
+		t.Errorf("expected the SAP response, got %q", resp.Body)
+	}
+
+	if err := NewClient(srv.URL, "", "").RefreshCookies(context.Background()); err == nil {
+		t.Error("expected an error without a configured login")
+	}
+}
+
 func TestSAMLLogin_ReauthConcurrent(t *testing.T) {
 	// Verify that concurrent 401s don't trigger multiple SAML dances.
 	// Use a real httptest server so fetchCSRFToken (called inside callReauthFunc) returns fast.
@@ -0,0 +1,135 @@
+package adt
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// --- Search Helps (SHLP) ---
+
+// SearchHelp describes a DDIC search help.
+type SearchHelp struct {
+	Name string `json:"name"`
+	// Type is "elementary" or "collective".
+	Type string `json:"type"`
+	// SelectionMethod is the table or view an elementary search help reads.
+	SelectionMethod string `json:"selectionMethod,omitempty"`
+	// DialogType is D (display values immediately), C (complex dialog) or
+	// A (dialog depends on the number of values).
+	DialogType string            `json:"dialogType,omitempty"`
+	Parameters []SearchHelpParam `json:"parameters"`
+	// Included lists the search helps a collective search help combines,
+	// in order.
+	Included []string `json:"included,omitempty"`
+}
+
+// SearchHelpParam is one parameter of a search help.
+type SearchHelpParam struct {
+	Name        string `json:"name"`
+	Position    int    `json:"position"`
+	Import      bool   `json:"import"`
+	Export      bool   `json:"export"`
+	SelPos      int    `json:"selPos,omitempty"`  // position on the restriction dialog, 0 if not shown
+	ListPos     int    `json:"listPos,omitempty"` // position in the hit list, 0 if not shown
+	DataElement string `json:"dataElement,omitempty"`
+	Default     string `json:"default,omitempty"`
+}
+
+// GetSearchHelp reads the active definition of a search help. ADT has no
+// search help endpoint: SHLP is a GUI-only object type there (the workbench
+// opens it in SE11) and no resource returns its definition. The definition
+// is therefore read through data preview from the DDIC tables DD30L
+// (header), DD32S (parameters, keyed by SHLPPARAM) and DD31S (included
+// search helps).
+func (c *Client) GetSearchHelp(ctx context.Context, name string) (*SearchHelp, error) {
+	if err := c.checkSafety(OpQuery, "GetSearchHelp"); err != nil {
+		return nil, err
+	}
+
+	name = strings.ToUpper(strings.TrimSpace(name))
+	if !tableNamePattern.MatchString(name) {
+		return nil, fmt.Errorf("invalid search help name %q", name)
+	}
+	where := fmt.Sprintf("SHLPNAME = '%s' AND AS4LOCAL = 'A'", name)
+
+	header, err := c.GetTableContents(ctx, "DD30L", 1, where)
+	if err != nil {
+		return nil, fmt.Errorf("reading search help %s: %w", name, err)
+	}
+	params, err := c.GetTableContents(ctx, "DD32S", 500, where)
+	if err != nil {
+		return nil, fmt.Errorf("reading parameters of search help %s: %w", name, err)
+	}
+	var included *TableContentsResult
+	if len(header.Rows) > 0 && getString(header.Rows[0], "ISSIMPLE") != "X" {
+		included, err = c.GetTableContents(ctx, "DD31S", 500, where)
+		if err != nil {
+			return nil, fmt.Errorf("reading included search helps of %s: %w", name, err)
+		}
+	}
+
+	return parseSearchHelp(name, header, params, included)
+}
+
+// parseSearchHelp assembles a SearchHelp from DD30L, DD32S and DD31S rows.
+// included may be nil for elementary search helps.
+func parseSearchHelp(name string, header, params, included *TableContentsResult) (*SearchHelp, error) {
+	if header == nil || len(header.Rows) == 0 {
+		return nil, fmt.Errorf("search help %s does not exist", name)
+	}
+	row := header.Rows[0]
+
+	sh := &SearchHelp{
+		Name:            name,
+		Type:            "collective",
+		SelectionMethod: getString(row, "SELMETHOD"),
+		DialogType:      getString(row, "DIALOGTYPE"),
+		Parameters:      []SearchHelpParam{},
+	}
+	if getString(row, "ISSIMPLE") == "X" {
+		sh.Type = "elementary"
+	}
+
+	if params != nil {
+		for _, p := range params.Rows {
+			sh.Parameters = append(sh.Parameters, SearchHelpParam{
+				Name:        getString(p, "SHLPPARAM"),
+				Position:    atoiOrZero(getString(p, "FLPOSITION")),
+				Import:      getString(p, "SHLPINPUT") == "X",
+				Export:      getString(p, "SHLPOUTPUT") == "X",
+				SelPos:      atoiOrZero(getString(p, "SHLPSELPOS")),
+				ListPos:     atoiOrZero(getString(p, "SHLPLISPOS")),
+				DataElement: getString(p, "ROLLNAME"),
+				Default:     getString(p, "DEFAULTVAL"),
+			})
+		}
+		sort.SliceStable(sh.Parameters, func(i, j int) bool {
+			return sh.Parameters[i].Position < sh.Parameters[j].Position
+		})
+	}
+
+	if included != nil {
+		type sub struct {
+			name string
+			pos  int
+		}
+		var subs []sub
+		for _, r := range included.Rows {
+			subs = append(subs, sub{getString(r, "SUBSHLP"), atoiOrZero(getString(r, "SHPOSITION"))})
+		}
+		sort.SliceStable(subs, func(i, j int) bool { return subs[i].pos < subs[j].pos })
+		for _, s := range subs {
+			sh.Included = append(sh.Included, s.name)
+		}
+	}
+
+	return sh, nil
+}
+
+func atoiOrZero(s string) int {
+	n, _ := strconv.Atoi(strings.TrimSpace(s))
+	return n
+}
@@ -0,0 +1,76 @@
+package adt
+
+import (
+	"reflect"
+	"testing"
+)
+
+func tableRows(rows ...map[string]interface{}) *TableContentsResult {
+	return &TableContentsResult{Rows: rows}
+}
+
+func TestParseSearchHelp_Elementary(t *testing.T) {
+	header := tableRows(map[string]interface{}{
+		"SHLPNAME": "ZDEMO_SH_ORDER", "AS4LOCAL": "A", "ISSIMPLE": "X",
+		"SELMETHOD": "ZDEMO_ORDERS", "DIALOGTYPE": "C",
+	})
+	params := tableRows(
+		map[string]interface{}{
+			"SHLPNAME": "ZDEMO_SH_ORDER", "SHLPPARAM": "CUSTOMER", "FLPOSITION": "0002",
+			"SHLPINPUT": "X", "SHLPOUTPUT": "", "SHLPSELPOS": "02", "SHLPLISPOS": "02",
+			"ROLLNAME": "ZDEMO_CUSTOMER", "DEFAULTVAL": "",
+		},
+		map[string]interface{}{
+			"SHLPNAME": "ZDEMO_SH_ORDER", "SHLPPARAM": "ORDER_ID", "FLPOSITION": "0001",
+			"SHLPINPUT": "X", "SHLPOUTPUT": "X", "SHLPSELPOS": "01", "SHLPLISPOS": "01",
+			"ROLLNAME": "ZDEMO_ORDER_ID", "DEFAULTVAL": "",
+		},
+		map[string]interface{}{
+			"SHLPNAME": "ZDEMO_SH_ORDER", "SHLPPARAM": "STATUS", "FLPOSITION": "0003",
+			"SHLPINPUT": "", "SHLPOUTPUT": "", "SHLPSELPOS": "00", "SHLPLISPOS": "03",
+			"ROLLNAME": "ZDEMO_STATUS", "DEFAULTVAL": "'OPEN'",
+		},
+	)
+
+	sh, err := parseSearchHelp("ZDEMO_SH_ORDER", header, params, nil)
+	if err != nil {
+		t.Fatalf("parseSearchHelp failed: %v", err)
+	}
+	want := &SearchHelp{
+		Name:            "ZDEMO_SH_ORDER",
+		Type:            "elementary",
+		SelectionMethod: "ZDEMO_ORDERS",
+		DialogType:      "C",
+		Parameters: []SearchHelpParam{
+			{Name: "ORDER_ID", Position: 1, Import: true, Export: true, SelPos: 1, ListPos: 1, DataElement: "ZDEMO_ORDER_ID"},
+			{Name: "CUSTOMER", Position: 2, Import: true, SelPos: 2, ListPos: 2, DataElement: "ZDEMO_CUSTOMER"},
+			{Name: "STATUS", Position: 3, ListPos: 3, DataElement: "ZDEMO_STATUS", Default: "'OPEN'"},
+		},
+	}
+	if !reflect.DeepEqual(sh, want) {
+		t.Errorf("search help = %+v\nwant %+v", sh, want)
+	}
+}
+
+func TestParseSearchHelp_Collective(t *testing.T) {
+	header := tableRows(map[string]interface{}{"SHLPNAME": "ZDEMO_SH_ALL", "ISSIMPLE": ""})
+	included := tableRows(
+		map[string]interface{}{"SUBSHLP": "ZDEMO_SH_BY_CUSTOMER", "SHPOSITION": "0002"},
+		map[string]interface{}{"SUBSHLP": "ZDEMO_SH_ORDER", "SHPOSITION": "0001"},
+	)
+
+	sh, err := parseSearchHelp("ZDEMO_SH_ALL", header, tableRows(), included)
+	if err != nil {
+		t.Fatalf("parseSearchHelp failed: %v", err)
+	}
+	if sh.Type != "collective" {
+		t.Errorf("Type = %q, want collective", sh.Type)
+	}
+	if want := []string{"ZDEMO_SH_ORDER", "ZDEMO_SH_BY_CUSTOMER"}; !reflect.DeepEqual(sh.Included, want) {
+		t.Errorf("Included = %v, want %v", sh.Included, want)
+	}
+
+	if _, err := parseSearchHelp("ZDEMO_SH_MISSING", tableRows(), tableRows(), nil); err == nil {
+		t.Error("expected an error for a missing search help")
+	}
+}
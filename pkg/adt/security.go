@@ -0,0 +1,303 @@
+package adt
+
+import (
+	"context"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/oisee/vibing-steampunk/pkg/abaplint"
+)
+
+// --- Security Profile ---
+
+// DangerousStatement is a statement flagged by the security source scan.
+type DangerousStatement struct {
+	Kind      string `json:"kind"` // native_sql, dynamic_sql, code_generation, kernel_call, os_command, call_transaction
+	Statement string `json:"statement"`
+	Line      int    `json:"line"`
+}
+
+// SecurityProfile is a read-only security overview of an object's source.
+type SecurityProfile struct {
+	ObjectType  string                 `json:"objectType"`
+	Name        string                 `json:"name"`
+	AuthChecks  []AuthCheck            `json:"authChecks"`
+	AuthObjects map[string]*AuthObject `json:"authObjects,omitempty"`
+	// Unresolved maps auth object names that could not be read to the reason.
+	Unresolved map[string]string    `json:"unresolved,omitempty"`
+	Dangerous  []DangerousStatement `json:"dangerous"`
+}
+
+// GetSecurityProfile reads the source of an object, lists its AUTHORITY-CHECK
+// statements with the referenced authorization objects, and flags native
+// SQL, dynamic SQL and other dangerous statements. Authorization objects
+// that cannot be read are reported in Unresolved instead of failing the call.
+func (c *Client) GetSecurityProfile(ctx context.Context, objectType, name string) (*SecurityProfile, error) {
+	source, err := c.GetSource(ctx, objectType, name, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	profile := &SecurityProfile{
+		ObjectType:  strings.ToUpper(objectType),
+		Name:        strings.ToUpper(name),
+		AuthChecks:  FindAuthorityChecks(source),
+		AuthObjects: map[string]*AuthObject{},
+		Dangerous:   FindDangerousStatements(source),
+	}
+	if profile.AuthChecks == nil {
+		profile.AuthChecks = []AuthCheck{}
+	}
+
+	for _, check := range profile.AuthChecks {
+		if check.Dynamic || check.Object == "" {
+			continue
+		}
+		objName := strings.ToUpper(check.Object)
+		if _, done := profile.AuthObjects[objName]; done {
+			continue
+		}
+		if _, failed := profile.Unresolved[objName]; failed {
+			continue
+		}
+		obj, err := c.GetAuthorizationObject(ctx, objName)
+		if err != nil {
+			if profile.Unresolved == nil {
+				profile.Unresolved = map[string]string{}
+			}
+			profile.Unresolved[objName] = err.Error()
+			continue
+		}
+		profile.AuthObjects[objName] = obj
+	}
+
+	return profile, nil
+}
+
+// dangerousStatementPrefixes maps leading keywords to a finding kind.
+var dangerousStatementPrefixes = []struct {
+	prefix []string
+	kind   string
+}{
+	{[]string{"EXEC", "SQL"}, "native_sql"},
+	{[]string{"GENERATE", "SUBROUTINE", "POOL"}, "code_generation"},
+	{[]string{"INSERT", "REPORT"}, "code_generation"},
+	{[]string{"DELETE", "REPORT"}, "code_generation"},
+	{[]string{"EDITOR", "-", "CALL"}, "code_generation"},
+}
+
+// dynamicSQLKeywords are Open SQL keywords after which a parenthesized
+// operand, e.g. FROM (lv_table), makes the statement dynamic.
+var dynamicSQLKeywords = map[string]bool{
+	"FROM": true, "WHERE": true, "UPDATE": true, "INTO": true, "SET": true, "FIELDS": true,
+}
+
+var openSQLStatements = map[string]bool{
+	"SELECT": true, "UPDATE": true, "DELETE": true, "MODIFY": true, "INSERT": true,
+}
+
+// FindDangerousStatements scans ABAP source for statements that deserve a
+// security review: native SQL, dynamic Open SQL, code generation, kernel
+// calls, OS commands via OPEN DATASET ... FILTER, and CALL TRANSACTION
+// without WITH AUTHORITY-CHECK.
+func FindDangerousStatements(source string) []DangerousStatement {
+	tokens := (&abaplint.Lexer{}).Run(source)
+	stmts := (&abaplint.StatementParser{}).Parse(tokens)
+
+	findings := []DangerousStatement{}
+	for _, stmt := range stmts {
+		if stmt.Type == "Comment" || stmt.Type == "Empty" || len(stmt.Tokens) == 0 {
+			continue
+		}
+		if kind := dangerousStatementKind(stmt.Tokens); kind != "" {
+			findings = append(findings, DangerousStatement{
+				Kind:      kind,
+				Statement: stmt.ConcatTokens(),
+				Line:      stmt.Tokens[0].Row,
+			})
+		}
+	}
+
+	sort.SliceStable(findings, func(i, j int) bool { return findings[i].Line < findings[j].Line })
+	return findings
+}
+
+func dangerousStatementKind(toks []abaplint.Token) string {
+	words := make([]string, len(toks))
+	for i, t := range toks {
+		words[i] = strings.ToUpper(t.Str)
+	}
+
+	for _, p := range dangerousStatementPrefixes {
+		if hasWordPrefix(words, p.prefix) {
+			return p.kind
+		}
+	}
+
+	switch {
+	case words[0] == "CALL" && len(toks) > 1 && toks[1].Type == abaplint.TokenString:
+		return "kernel_call"
+	case hasWordPrefix(words, []string{"OPEN", "DATASET"}) && containsWord(words, "FILTER"):
+		return "os_command"
+	case hasWordPrefix(words, []string{"CALL", "TRANSACTION"}) && !containsWord(words, "AUTHORITY"):
+		return "call_transaction"
+	case openSQLStatements[words[0]]:
+		for i := 1; i < len(words)-1; i++ {
+			if dynamicSQLKeywords[words[i]] && words[i+1] == "(" {
+				return "dynamic_sql"
+			}
+		}
+	}
+	return ""
+}
+
+func hasWordPrefix(words, prefix []string) bool {
+	if len(words) < len(prefix) {
+		return false
+	}
+	for i, p := range prefix {
+		if words[i] != p {
+			return false
+		}
+	}
+	return true
+}
+
+func containsWord(words []string, word string) bool {
+	for _, w := range words {
+		if w == word {
+			return true
+		}
+	}
+	return false
+}
+
+// --- Secret Scanner ---
+
+// SecretPattern describes one hardcoded-secret heuristic. A pattern with
+// NamePattern flags string literals assigned to (or passed as) a matching
+// name: `lv_password = '...'`, `VALUE '...'`, `EXPORTING password = '...'`.
+// A pattern with LiteralPattern flags any string literal whose content
+// matches, regardless of where it is used.
+type SecretPattern struct {
+	Kind           string
+	NamePattern    *regexp.Regexp
+	LiteralPattern *regexp.Regexp
+}
+
+// SecretPatterns is the pattern list used by FindSecrets. The defaults are
+// deliberately conservative; append to it to add project-specific rules.
+var SecretPatterns = []SecretPattern{
+	{
+		Kind:        "credential_assignment",
+		NamePattern: regexp.MustCompile(`(?i)(password|passwd|passwort|pwd|secret|api_?key|access_?key|private_?key|auth_?token|access_?token|bearer|client_?secret)`),
+	},
+	{
+		Kind:           "url_userinfo",
+		LiteralPattern: regexp.MustCompile(`(?i)^[a-z][a-z0-9+.-]*://[^/\s:@]+:[^/\s@]+@`),
+	},
+	{
+		Kind:           "private_key",
+		LiteralPattern: regexp.MustCompile(`-----BEGIN [A-Z ]*PRIVATE KEY-----`),
+	},
+}
+
+// SecretFinding is a likely hardcoded secret found in source.
+type SecretFinding struct {
+	Kind    string `json:"kind"`
+	Line    int    `json:"line"`
+	Snippet string `json:"snippet"` // statement with its literals redacted
+}
+
+// FindSecrets scans ABAP source for likely hardcoded passwords, keys and
+// credentials embedded in URLs, using SecretPatterns. Comments are ignored.
+// Snippets are redacted, so findings can be logged or shown safely.
+func FindSecrets(source string) []SecretFinding {
+	tokens := (&abaplint.Lexer{}).Run(source)
+	stmts := (&abaplint.StatementParser{}).Parse(tokens)
+
+	findings := []SecretFinding{}
+	for _, stmt := range stmts {
+		if stmt.Type == "Comment" || stmt.Type == "Empty" {
+			continue
+		}
+		toks := stmt.Tokens
+		for i, tok := range toks {
+			if tok.Type != abaplint.TokenString || isBlankLiteral(tok.Str) {
+				continue
+			}
+			if kind := secretKind(toks, i); kind != "" {
+				findings = append(findings, SecretFinding{
+					Kind:    kind,
+					Line:    tok.Row,
+					Snippet: redactedStatement(toks),
+				})
+			}
+		}
+	}
+	return findings
+}
+
+// secretKind returns the kind of the first pattern matching the literal at
+// toks[i], or "" if none does.
+func secretKind(toks []abaplint.Token, i int) string {
+	literal := unquoteLiteral(toks[i].Str)
+	name := literalTargetName(toks, i)
+	for _, p := range SecretPatterns {
+		if p.LiteralPattern != nil && p.LiteralPattern.MatchString(literal) {
+			return p.Kind
+		}
+		if p.NamePattern != nil && name != "" && p.NamePattern.MatchString(name) {
+			return p.Kind
+		}
+	}
+	return ""
+}
+
+// literalTargetName returns the name a literal is assigned to:
+// the operand before "=" in `name = 'x'`, or the declared name in
+// `DATA name TYPE string VALUE 'x'`.
+func literalTargetName(toks []abaplint.Token, i int) string {
+	if i >= 2 && toks[i-1].Str == "=" {
+		return toks[i-2].Str
+	}
+	if i >= 1 && strings.EqualFold(toks[i-1].Str, "VALUE") {
+		for j := 0; j+1 < i; j++ {
+			switch strings.ToUpper(toks[j].Str) {
+			case "DATA", "CONSTANTS", "STATICS", "CLASS-DATA":
+				return toks[j+1].Str
+			}
+		}
+	}
+	return ""
+}
+
+// redactedStatement renders a statement with every string literal masked,
+// so that other secrets in the same statement do not leak.
+func redactedStatement(toks []abaplint.Token) string {
+	parts := make([]string, len(toks))
+	for j, tok := range toks {
+		parts[j] = tok.Str
+		switch tok.Type {
+		case abaplint.TokenString, abaplint.TokenStringTemplate,
+			abaplint.TokenStringTemplateBegin, abaplint.TokenStringTemplateEnd,
+			abaplint.TokenStringTemplateMiddle:
+			if len(tok.Str) >= 2 {
+				parts[j] = tok.Str[:1] + "***" + tok.Str[len(tok.Str)-1:]
+			}
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+func unquoteLiteral(s string) string {
+	if len(s) >= 2 {
+		return s[1 : len(s)-1]
+	}
+	return s
+}
+
+func isBlankLiteral(s string) bool {
+	return strings.TrimSpace(unquoteLiteral(s)) == ""
+}
@@ -0,0 +1,132 @@
+package adt
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+)
+
+// Session is an explicit stateful ADT session. Requests made through
+// Session.Client share one server-side context (the sap-contextid cookie),
+// so lock handles, debugger attachments and other session-bound state
+// survive between calls. The parent client is unaffected and stays
+// stateless by default. Always Close a session to release the context.
+type Session struct {
+	client    *Client
+	transport *Transport
+
+	mu     sync.Mutex
+	closed bool
+}
+
+// OpenStatefulSession opens a stateful session sharing the client's
+// configuration and caches. The session gets its own HTTP client and
+// cookie jar so that the context cookie never reaches the parent client.
+func (c *Client) OpenStatefulSession(ctx context.Context) (*Session, error) {
+	t := &Transport{
+		config:         c.config,
+		httpClient:     newSessionHTTPClient(c.config, c.transport.httpClient),
+		stateful:       true,
+		sessionCookies: map[string]string{},
+		sources:        c.transport.sources,
+	}
+
+	// The CSRF fetch is the first stateful request; the server answers
+	// with the context cookie that identifies the session.
+	if err := t.fetchCSRFToken(ctx); err != nil {
+		return nil, fmt.Errorf("opening stateful session: %w", err)
+	}
+
+	return &Session{
+		client: &Client{
+			transport:     t,
+			config:        c.config,
+			confirmations: c.confirmations,
+			configErr:     c.configErr,
+			structures:    c.structures,
+			batchSlots:    c.batchSlots,
+			packages:      c.packages,
+			discovery:     c.discovery,
+		},
+		transport: t,
+	}, nil
+}
+
+// newSessionHTTPClient builds the HTTP client of a session. The jar keeps
+// every cookie except sap-contextid, which the session transport tracks
+// itself (see captureSessionCookies) so it is sent exactly once and is
+// gone after Close. Custom HTTPDoers (test doubles) are reused as is.
+func newSessionHTTPClient(cfg *Config, parent HTTPDoer) HTTPDoer {
+	if _, ok := parent.(*http.Client); !ok {
+		return parent
+	}
+	client := cfg.NewHTTPClient()
+	client.Timeout = 0 // applied per attempt, as in NewTransport
+	if client.Jar != nil {
+		client.Jar = sessionJar{client.Jar}
+	}
+	return client
+}
+
+// sessionJar is a cookie jar that ignores the sap-contextid cookie.
+type sessionJar struct {
+	http.CookieJar
+}
+
+func (j sessionJar) SetCookies(u *url.URL, cookies []*http.Cookie) {
+	kept := cookies[:0:0]
+	for _, cookie := range cookies {
+		if cookie.Name != "sap-contextid" {
+			kept = append(kept, cookie)
+		}
+	}
+	j.CookieJar.SetCookies(u, kept)
+}
+
+// Client returns a client whose requests all run in this session.
+func (s *Session) Client() *Client {
+	return s.client
+}
+
+// ID returns the server context ID of the session, or "" if the server has
+// not assigned one or the session is closed.
+func (s *Session) ID() string {
+	s.transport.sessionMu.RLock()
+	defer s.transport.sessionMu.RUnlock()
+	return s.transport.sessionCookies["sap-contextid"]
+}
+
+// Close ends the server-side context with a final stateless request and
+// forgets the session cookie. Closing twice is a no-op. The cookie is
+// cleared even if the final request fails.
+func (s *Session) Close(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return nil
+	}
+	s.closed = true
+
+	t := s.transport
+	t.sessionMu.Lock()
+	t.stateful = false
+	t.sessionMu.Unlock()
+
+	// A stateless request carrying the context cookie tells the server to
+	// drop the context.
+	_, err := t.Request(ctx, "/sap/bc/adt/core/discovery", &RequestOptions{
+		Method: http.MethodHead,
+	})
+
+	t.sessionMu.Lock()
+	t.sessionCookies = map[string]string{}
+	t.sessionMu.Unlock()
+	t.setCSRFToken("")
+
+	if err != nil {
+		return fmt.Errorf("closing stateful session: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,152 @@
+package adt
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+type sessionRequest struct {
+	method      string
+	path        string
+	sessionType string
+	contextID   string
+}
+
+func TestStatefulSession_CookieLifecycle(t *testing.T) {
+	var requests []sessionRequest
+	mock := &funcMockClient{doFunc: func(req *http.Request) (*http.Response, error) {
+		r := sessionRequest{
+			method:      req.Method,
+			path:        req.URL.Path,
+			sessionType: req.Header.Get("X-sap-adt-sessiontype"),
+		}
+		if cookie, err := req.Cookie("sap-contextid"); err == nil {
+			r.contextID = cookie.Value
+		}
+		requests = append(requests, r)
+
+		resp := newTestResponse("ok")
+		resp.Header.Set("X-CSRF-Token", "test-token")
+		if req.Method == http.MethodHead && r.sessionType == "stateful" && r.contextID == "" {
+			resp.Header.Add("Set-Cookie", "sap-contextid=SID%3aDEMO%3a1; path=/sap/bc/adt")
+		}
+		if r.sessionType == "stateless" && r.contextID != "" {
+			resp.Header.Add("Set-Cookie", "sap-contextid=; path=/sap/bc/adt; max-age=0")
+		}
+		return resp, nil
+	}}
+	cfg := NewConfig("https://sap.example.com:44300", "user", "pass")
+	client := NewClientWithTransport(cfg, NewTransportWithClient(cfg, mock))
+	ctx := context.Background()
+
+	session, err := client.OpenStatefulSession(ctx)
+	if err != nil {
+		t.Fatalf("OpenStatefulSession failed: %v", err)
+	}
+	if session.ID() == "" {
+		t.Fatal("expected the session to capture a context ID on open")
+	}
+	opened := len(requests)
+
+	for _, path := range []string{"/sap/bc/adt/programs/programs/ZDEMO_REPORT", "/sap/bc/adt/oo/classes/ZCL_DEMO"} {
+		if _, err := session.Client().transport.Request(ctx, path, nil); err != nil {
+			t.Fatalf("session request %s failed: %v", path, err)
+		}
+	}
+	for _, r := range requests[opened:] {
+		if r.sessionType != "stateful" || r.contextID != session.ID() {
+			t.Errorf("%s %s: session type %q, context %q; want stateful with %q", r.method, r.path, r.sessionType, r.contextID, session.ID())
+		}
+	}
+
+	// The parent client is not part of the session
+	if _, err := client.transport.Request(ctx, "/sap/bc/adt/programs/programs/ZDEMO_OTHER", nil); err != nil {
+		t.Fatalf("client request failed: %v", err)
+	}
+	if last := requests[len(requests)-1]; last.sessionType != "stateless" || last.contextID != "" {
+		t.Errorf("parent client request joined the session: %+v", last)
+	}
+
+	if err := session.Close(ctx); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	closing := requests[len(requests)-1]
+	if closing.sessionType != "stateless" || closing.contextID == "" {
+		t.Errorf("close must send a stateless request with the context cookie, got %+v", closing)
+	}
+	if session.ID() != "" {
+		t.Errorf("context ID = %q after close, want empty", session.ID())
+	}
+	if err := session.Close(ctx); err != nil {
+		t.Errorf("second Close should be a no-op, got %v", err)
+	}
+
+	before := len(requests)
+	if _, err := session.Client().transport.Request(ctx, "/sap/bc/adt/programs/programs/ZDEMO_REPORT", nil); err != nil {
+		t.Fatalf("request after close failed: %v", err)
+	}
+	for _, r := range requests[before:] {
+		if r.contextID != "" || strings.EqualFold(r.sessionType, "stateful") {
+			t.Errorf("request after close still uses the session: %+v", r)
+		}
+	}
+}
+
+func TestStatefulSession_OwnCookieJar(t *testing.T) {
+	var mu sync.Mutex
+	contextIDs := map[string][]string{} // session type -> context cookies seen
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sessionType := r.Header.Get("X-sap-adt-sessiontype")
+		var ids []string
+		for _, cookie := range r.Cookies() {
+			if cookie.Name == "sap-contextid" {
+				ids = append(ids, cookie.Value)
+			}
+		}
+		mu.Lock()
+		contextIDs[sessionType] = append(contextIDs[sessionType], strings.Join(ids, ","))
+		mu.Unlock()
+
+		w.Header().Set("X-CSRF-Token", "test-token")
+		if sessionType == "stateful" && len(ids) == 0 {
+			http.SetCookie(w, &http.Cookie{Name: "sap-contextid", Value: "SID1", Path: "/"})
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "user", "pass")
+	ctx := context.Background()
+
+	session, err := client.OpenStatefulSession(ctx)
+	if err != nil {
+		t.Fatalf("OpenStatefulSession failed: %v", err)
+	}
+	if _, err := session.Client().transport.Request(ctx, "/sap/bc/adt/programs/programs/ZDEMO", nil); err != nil {
+		t.Fatalf("session request failed: %v", err)
+	}
+	if _, err := client.transport.Request(ctx, "/sap/bc/adt/programs/programs/ZDEMO", nil); err != nil {
+		t.Fatalf("client request failed: %v", err)
+	}
+	if err := session.Close(ctx); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	if _, err := session.Client().transport.Request(ctx, "/sap/bc/adt/programs/programs/ZDEMO", nil); err != nil {
+		t.Fatalf("request after close failed: %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if got := contextIDs["stateful"]; len(got) < 2 || got[len(got)-1] != "SID1" {
+		t.Errorf("stateful requests carried %q, want the context cookie exactly once", got)
+	}
+	stateless := contextIDs["stateless"]
+	// parent request, close request, request after close
+	if len(stateless) != 3 || stateless[0] != "" || stateless[1] != "SID1" || stateless[2] != "" {
+		t.Errorf("stateless requests carried %q, want the context cookie only on close", stateless)
+	}
+}
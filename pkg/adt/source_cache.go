@@ -0,0 +1,107 @@
+package adt
+
+import (
+	"container/list"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// --- Source Response Cache ---
+
+// sourceCache is a bounded LRU of source GET responses together with their
+// ETag and Last-Modified validators. Reads of a cached source are sent
+// conditionally, and a 304 Not Modified is answered from the cache, so an
+// unchanged source crosses the network once. A nil cache caches nothing.
+type sourceCache struct {
+	mu      sync.Mutex
+	max     int
+	order   *list.List // front = most recently used
+	entries map[string]*list.Element
+}
+
+type sourceCacheEntry struct {
+	key          string
+	path         string
+	etag         string
+	lastModified string
+	body         []byte
+}
+
+func newSourceCache(maxEntries int) *sourceCache {
+	if maxEntries <= 0 {
+		return nil
+	}
+	return &sourceCache{
+		max:     maxEntries,
+		order:   list.New(),
+		entries: make(map[string]*list.Element),
+	}
+}
+
+// isSourcePath reports whether an ADT path addresses source code, e.g.
+// .../source/main or a class include.
+func isSourcePath(path string) bool {
+	return strings.Contains(path, "/source/") || strings.Contains(path, "/includes/")
+}
+
+// get returns a copy of the cached response for key.
+func (s *sourceCache) get(key string) (sourceCacheEntry, bool) {
+	if s == nil {
+		return sourceCacheEntry{}, false
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	el, ok := s.entries[key]
+	if !ok {
+		return sourceCacheEntry{}, false
+	}
+	s.order.MoveToFront(el)
+	return *el.Value.(*sourceCacheEntry), true
+}
+
+// put stores a response if the server sent a validator for it, and drops a
+// stale entry for key otherwise.
+func (s *sourceCache) put(key, path string, header http.Header, body []byte) {
+	if s == nil {
+		return
+	}
+	entry := &sourceCacheEntry{
+		key:          key,
+		path:         path,
+		etag:         header.Get("ETag"),
+		lastModified: header.Get("Last-Modified"),
+		body:         body,
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if el, ok := s.entries[key]; ok {
+		s.order.Remove(el)
+		delete(s.entries, key)
+	}
+	if entry.etag == "" && entry.lastModified == "" {
+		return
+	}
+	s.entries[key] = s.order.PushFront(entry)
+	if s.order.Len() > s.max {
+		oldest := s.order.Back()
+		s.order.Remove(oldest)
+		delete(s.entries, oldest.Value.(*sourceCacheEntry).key)
+	}
+}
+
+// invalidatePath drops every cached response read from path, e.g. after a
+// write to it.
+func (s *sourceCache) invalidatePath(path string) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for key, el := range s.entries {
+		if el.Value.(*sourceCacheEntry).path == path {
+			s.order.Remove(el)
+			delete(s.entries, key)
+		}
+	}
+}
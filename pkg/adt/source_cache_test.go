@@ -0,0 +1,125 @@
+package adt
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// etagServer serves one class source whose ETag changes with its content.
+type etagServer struct {
+	source   string
+	etag     string
+	requests int
+	notMod   int
+	writes   int
+}
+
+func (s *etagServer) Do(req *http.Request) (*http.Response, error) {
+	if strings.Contains(req.URL.Path, "discovery") {
+		return newMockResponse(200, "", map[string]string{"X-CSRF-Token": "test-token"}), nil
+	}
+	if req.Method == http.MethodPut {
+		s.writes++
+		return newMockResponse(200, "", nil), nil
+	}
+	s.requests++
+	if match := req.Header.Get("If-None-Match"); match != "" && match == s.etag {
+		s.notMod++
+		return newMockResponse(http.StatusNotModified, "", map[string]string{"ETag": s.etag}), nil
+	}
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(strings.NewReader(s.source)),
+		Header:     http.Header{"Etag": []string{s.etag}},
+	}, nil
+}
+
+func TestSourceCache_NotModifiedServesCachedSource(t *testing.T) {
+	server := &etagServer{source: "CLASS zcl_demo_order DEFINITION PUBLIC.", etag: `"v1"`}
+	cfg := NewConfig("https://sap.example.com:44300", "user", "pass", WithSourceCache(8))
+	client := NewClientWithTransport(cfg, NewTransportWithClient(cfg, server))
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		source, err := client.GetClassSource(ctx, "ZCL_DEMO_ORDER")
+		if err != nil {
+			t.Fatalf("read %d failed: %v", i, err)
+		}
+		if source != server.source {
+			t.Fatalf("read %d returned %q", i, source)
+		}
+	}
+	if server.requests != 3 || server.notMod != 2 {
+		t.Errorf("expected 1 full and 2 conditional reads, got %d requests, %d not modified", server.requests, server.notMod)
+	}
+}
+
+func TestSourceCache_ChangedETagReplacesEntry(t *testing.T) {
+	server := &etagServer{source: "REPORT zdemo_report.", etag: `"v1"`}
+	cfg := NewConfig("https://sap.example.com:44300", "user", "pass", WithSourceCache(8))
+	client := NewClientWithTransport(cfg, NewTransportWithClient(cfg, server))
+	ctx := context.Background()
+
+	if _, err := client.GetProgram(ctx, "ZDEMO_REPORT"); err != nil {
+		t.Fatal(err)
+	}
+	server.source, server.etag = "REPORT zdemo_report.\nWRITE 'changed'.", `"v2"`
+
+	source, err := client.GetProgram(ctx, "ZDEMO_REPORT")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if source != server.source {
+		t.Errorf("changed source not returned, got %q", source)
+	}
+	if server.notMod != 0 {
+		t.Errorf("a stale ETag must not be answered from cache")
+	}
+
+	// The new version is cached in turn.
+	if _, err := client.GetProgram(ctx, "ZDEMO_REPORT"); err != nil {
+		t.Fatal(err)
+	}
+	if server.notMod != 1 {
+		t.Errorf("expected the new version to be served from cache, got %d not modified", server.notMod)
+	}
+}
+
+func TestSourceCache_WriteInvalidates(t *testing.T) {
+	server := &etagServer{source: "REPORT zdemo_report.", etag: `"v1"`}
+	cfg := NewConfig("https://sap.example.com:44300", "user", "pass", WithSourceCache(8))
+	transport := NewTransportWithClient(cfg, server)
+	ctx := context.Background()
+	const path = "/sap/bc/adt/programs/programs/ZDEMO_REPORT/source/main"
+
+	if _, err := transport.Request(ctx, path, nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := transport.Request(ctx, path, &RequestOptions{Method: http.MethodPut, Body: []byte("REPORT x.")}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := transport.Request(ctx, path, nil); err != nil {
+		t.Fatal(err)
+	}
+	if server.notMod != 0 {
+		t.Errorf("read after a write must not be conditional, got %d not modified", server.notMod)
+	}
+}
+
+func TestSourceCache_Disabled(t *testing.T) {
+	server := &etagServer{source: "REPORT zdemo_report.", etag: `"v1"`}
+	cfg := NewConfig("https://sap.example.com:44300", "user", "pass")
+	client := NewClientWithTransport(cfg, NewTransportWithClient(cfg, server))
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.GetProgram(context.Background(), "ZDEMO_REPORT"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if server.notMod != 0 {
+		t.Errorf("reads must not be conditional without WithSourceCache")
+	}
+}
@@ -0,0 +1,116 @@
+package adt
+
+import (
+	"context"
+	"sync"
+)
+
+// --- Batch Source Retrieval ---
+
+const (
+	// DefaultBatchConcurrency is the number of parallel requests
+	// GetSourcesBatch uses when no concurrency is given.
+	DefaultBatchConcurrency = 8
+
+	// maxBatchConcurrency caps parallel requests so a large batch cannot
+	// exhaust the work processes of the SAP gateway.
+	maxBatchConcurrency = 32
+)
+
+// ObjectRef identifies an object for batch source retrieval. Type and Name
+// are as accepted by GetSource; Parent is the function group for FUNC.
+type ObjectRef struct {
+	Type   string `json:"type"`
+	Name   string `json:"name"`
+	Parent string `json:"parent,omitempty"`
+}
+
+// BatchSourceResult is the outcome of fetching one object in a batch.
+type BatchSourceResult struct {
+	Source string `json:"source,omitempty"`
+	Err    error  `json:"-"`
+}
+
+// GetSourcesBatch fetches the sources of many objects concurrently over a
+// bounded worker pool (concurrency <= 0 means DefaultBatchConcurrency).
+// Requests also count against the client-wide WithMaxConcurrency limit.
+// Every ref gets an entry in the result; a failing object only sets its own
+// Err, so one failure does not abort the batch. When ctx is cancelled, refs
+// not fetched yet are reported with the context error.
+func (c *Client) GetSourcesBatch(ctx context.Context, refs []ObjectRef, concurrency int) map[ObjectRef]BatchSourceResult {
+	if concurrency <= 0 {
+		concurrency = DefaultBatchConcurrency
+	}
+	if concurrency > maxBatchConcurrency {
+		concurrency = maxBatchConcurrency
+	}
+	if concurrency > len(refs) {
+		concurrency = len(refs)
+	}
+
+	results := make(map[ObjectRef]BatchSourceResult, len(refs))
+	var mu sync.Mutex
+	jobCh := make(chan ObjectRef)
+
+	var wg sync.WaitGroup
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for ref := range jobCh {
+				var result BatchSourceResult
+				if err := ctx.Err(); err != nil {
+					result.Err = err
+				} else if err := c.batchSlots.acquire(ctx); err != nil {
+					result.Err = err
+				} else {
+					result.Source, result.Err = c.GetSource(ctx, ref.Type, ref.Name, &GetSourceOptions{Parent: ref.Parent})
+					c.batchSlots.release()
+				}
+				mu.Lock()
+				results[ref] = result
+				mu.Unlock()
+			}
+		}()
+	}
+
+	for _, ref := range refs {
+		jobCh <- ref
+	}
+	close(jobCh)
+	wg.Wait()
+
+	return results
+}
+
+// batchLimiter is a counting semaphore shared by all batch operations of a
+// client. A nil limiter imposes no limit.
+type batchLimiter struct {
+	slots chan struct{}
+}
+
+func newBatchLimiter(n int) *batchLimiter {
+	if n <= 0 {
+		return nil
+	}
+	return &batchLimiter{slots: make(chan struct{}, n)}
+}
+
+// acquire blocks until a slot is free or ctx is done.
+func (l *batchLimiter) acquire(ctx context.Context) error {
+	if l == nil {
+		return ctx.Err()
+	}
+	select {
+	case l.slots <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (l *batchLimiter) release() {
+	if l != nil {
+		<-l.slots
+	}
+}
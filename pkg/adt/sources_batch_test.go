@@ -0,0 +1,142 @@
+package adt
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestGetSourcesBatch_FetchesAllAndIsolatesErrors(t *testing.T) {
+	var mu sync.Mutex
+	inFlight, maxInFlight := 0, 0
+	mock := &funcMockClient{
+		doFunc: func(req *http.Request) (*http.Response, error) {
+			mu.Lock()
+			inFlight++
+			if inFlight > maxInFlight {
+				maxInFlight = inFlight
+			}
+			mu.Unlock()
+			defer func() {
+				mu.Lock()
+				inFlight--
+				mu.Unlock()
+			}()
+			time.Sleep(2 * time.Millisecond)
+
+			if strings.Contains(req.URL.Path, "ZDEMO_BROKEN") {
+				return &http.Response{StatusCode: http.StatusNotFound, Body: io.NopCloser(strings.NewReader("not found")), Header: http.Header{}}, nil
+			}
+			return newTestResponse("* source of " + req.URL.Path), nil
+		},
+	}
+	cfg := NewConfig("https://sap.example.com:44300", "user", "pass")
+	client := NewClientWithTransport(cfg, NewTransportWithClient(cfg, mock))
+
+	var refs []ObjectRef
+	for i := 0; i < 20; i++ {
+		refs = append(refs, ObjectRef{Type: "PROG", Name: fmt.Sprintf("ZDEMO_PROG_%02d", i)})
+	}
+	refs = append(refs, ObjectRef{Type: "CLAS", Name: "ZCL_DEMO"}, ObjectRef{Type: "PROG", Name: "ZDEMO_BROKEN"})
+
+	results := client.GetSourcesBatch(context.Background(), refs, 4)
+	if len(results) != len(refs) {
+		t.Fatalf("got %d results, want %d", len(results), len(refs))
+	}
+	for _, ref := range refs {
+		res := results[ref]
+		if ref.Name == "ZDEMO_BROKEN" {
+			if !IsNotFoundError(res.Err) {
+				t.Errorf("%s: expected not-found error, got %v", ref.Name, res.Err)
+			}
+			continue
+		}
+		if res.Err != nil {
+			t.Errorf("%s: unexpected error %v", ref.Name, res.Err)
+		} else if !strings.Contains(res.Source, ref.Name) {
+			t.Errorf("%s: unexpected source %q", ref.Name, res.Source)
+		}
+	}
+	if maxInFlight > 4 {
+		t.Errorf("max in-flight requests = %d, want <= 4", maxInFlight)
+	}
+}
+
+func TestGetSourcesBatch_Cancelled(t *testing.T) {
+	mock := &funcMockClient{
+		doFunc: func(req *http.Request) (*http.Response, error) {
+			return newTestResponse("REPORT zdemo."), nil
+		},
+	}
+	cfg := NewConfig("https://sap.example.com:44300", "user", "pass")
+	client := NewClientWithTransport(cfg, NewTransportWithClient(cfg, mock))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	refs := []ObjectRef{{Type: "PROG", Name: "ZDEMO_A"}, {Type: "PROG", Name: "ZDEMO_B"}}
+	results := client.GetSourcesBatch(ctx, refs, 0)
+	for _, ref := range refs {
+		if !errors.Is(results[ref].Err, context.Canceled) {
+			t.Errorf("%s: expected context.Canceled, got %v", ref.Name, results[ref].Err)
+		}
+	}
+}
+
+func TestGetSourcesBatch_SharedConcurrencyLimit(t *testing.T) {
+	var mu sync.Mutex
+	inFlight, maxInFlight, total := 0, 0, 0
+	mock := &funcMockClient{
+		doFunc: func(req *http.Request) (*http.Response, error) {
+			mu.Lock()
+			inFlight++
+			total++
+			if inFlight > maxInFlight {
+				maxInFlight = inFlight
+			}
+			mu.Unlock()
+			time.Sleep(2 * time.Millisecond)
+			mu.Lock()
+			inFlight--
+			mu.Unlock()
+			return newTestResponse("REPORT zdemo."), nil
+		},
+	}
+	cfg := NewConfig("https://sap.example.com:44300", "user", "pass", WithMaxConcurrency(3))
+	client := NewClientWithTransport(cfg, NewTransportWithClient(cfg, mock))
+
+	batch := func(prefix string) []ObjectRef {
+		var refs []ObjectRef
+		for i := 0; i < 12; i++ {
+			refs = append(refs, ObjectRef{Type: "PROG", Name: fmt.Sprintf("%s_%02d", prefix, i)})
+		}
+		return refs
+	}
+
+	var wg sync.WaitGroup
+	for _, prefix := range []string{"ZDEMO_A", "ZDEMO_B"} {
+		wg.Add(1)
+		go func(refs []ObjectRef) {
+			defer wg.Done()
+			for ref, res := range client.GetSourcesBatch(context.Background(), refs, 4) {
+				if res.Err != nil {
+					t.Errorf("%s: %v", ref.Name, res.Err)
+				}
+			}
+		}(batch(prefix))
+	}
+	wg.Wait()
+
+	if total != 24 {
+		t.Errorf("expected 24 requests, got %d", total)
+	}
+	if maxInFlight > 3 {
+		t.Errorf("max in-flight requests across batches = %d, want <= 3", maxInFlight)
+	}
+}
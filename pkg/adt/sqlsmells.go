@@ -0,0 +1,113 @@
+package adt
+
+import (
+	"strings"
+
+	"github.com/oisee/vibing-steampunk/pkg/abaplint"
+)
+
+// --- Static SQL Smells ---
+
+// SQL smell categories reported by FindSQLSmells.
+const (
+	SQLSmellSelectStar   = "select_star"    // SELECT * reads every column
+	SQLSmellNoWhere      = "no_where"       // SELECT without WHERE reads the whole table
+	SQLSmellSelectInLoop = "select_in_loop" // SELECT inside LOOP/DO/WHILE (N+1 pattern)
+	SQLSmellMissingUpTo  = "missing_up_to"  // unrestricted multi-row SELECT without UP TO n ROWS
+)
+
+// SQLSmell is a static performance hint for an Open SQL statement.
+type SQLSmell struct {
+	Category  string `json:"category"`
+	Line      int    `json:"line"`
+	Statement string `json:"statement"`
+}
+
+// FindSQLSmells scans ABAP source for Open SQL performance smells:
+// SELECT *, SELECT without WHERE, SELECT inside a LOOP/DO/WHILE, and
+// multi-row SELECTs that are neither restricted by WHERE nor by UP TO n ROWS.
+// Statements are taken from the statement splitter, so SQL keywords inside
+// string literals and comments are not matched.
+func FindSQLSmells(source string) []SQLSmell {
+	tokens := (&abaplint.Lexer{}).Run(source)
+	stmts := (&abaplint.StatementParser{}).Parse(tokens)
+
+	smells := []SQLSmell{}
+	loopDepth := 0
+	for _, stmt := range stmts {
+		if stmt.Type == "Comment" || stmt.Type == "Empty" || len(stmt.Tokens) == 0 {
+			continue
+		}
+		switch stmt.FirstTokenStr() {
+		case "LOOP", "DO", "WHILE":
+			loopDepth++
+			continue
+		case "ENDLOOP", "ENDDO", "ENDWHILE":
+			if loopDepth > 0 {
+				loopDepth--
+			}
+			continue
+		case "SELECT":
+		default:
+			continue
+		}
+
+		words := make([]string, len(stmt.Tokens))
+		for i, t := range stmt.Tokens {
+			words[i] = strings.ToUpper(t.Str)
+		}
+		add := func(category string) {
+			smells = append(smells, SQLSmell{
+				Category:  category,
+				Line:      stmt.Tokens[0].Row,
+				Statement: stmt.ConcatTokens(),
+			})
+		}
+
+		single := len(words) > 1 && words[1] == "SINGLE"
+		hasWhere := containsWord(words, "WHERE")
+
+		if isSelectStar(words) {
+			add(SQLSmellSelectStar)
+		}
+		if !hasWhere {
+			add(SQLSmellNoWhere)
+		}
+		if loopDepth > 0 {
+			add(SQLSmellSelectInLoop)
+		}
+		if !single && !hasWhere && !hasUpToRows(words) {
+			add(SQLSmellMissingUpTo)
+		}
+	}
+	return smells
+}
+
+// isSelectStar reports SELECT *, SELECT SINGLE *, SELECT DISTINCT * and
+// the strict-mode form SELECT FROM ... FIELDS *.
+func isSelectStar(words []string) bool {
+	for i := 1; i < len(words); i++ {
+		switch words[i] {
+		case "SINGLE", "DISTINCT":
+			continue
+		case "*":
+			return true
+		}
+		break
+	}
+	for i := 1; i < len(words)-1; i++ {
+		if words[i] == "FIELDS" && words[i+1] == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+func hasUpToRows(words []string) bool {
+	for i := 0; i+1 < len(words); i++ {
+		if words[i] == "UP" && words[i+1] == "TO" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,55 @@
+package adt
+
+import "testing"
+
+func TestFindSQLSmells(t *testing.T) {
+	source := `REPORT zdemo_sql.
+SELECT * FROM sflight INTO TABLE @DATA(lt_all).
+SELECT SINGLE * FROM scarr WHERE carrid = @lv_carrid INTO @DATA(ls_carr).
+LOOP AT lt_all INTO DATA(ls_flight).
+  SELECT SINGLE carrname FROM scarr WHERE carrid = @ls_flight-carrid INTO @DATA(lv_name).
+ENDLOOP.
+SELECT carrid FROM scarr INTO TABLE @DATA(lt_ids) UP TO 10 ROWS.
+lv_text = 'SELECT * FROM sflight'.
+* SELECT * FROM sflight.
+`
+	smells := FindSQLSmells(source)
+
+	type key struct {
+		line     int
+		category string
+	}
+	want := map[key]bool{
+		{2, SQLSmellSelectStar}:   true,
+		{2, SQLSmellNoWhere}:      true,
+		{2, SQLSmellMissingUpTo}:  true,
+		{3, SQLSmellSelectStar}:   true,
+		{5, SQLSmellSelectInLoop}: true,
+		{7, SQLSmellNoWhere}:      true,
+	}
+	got := map[key]bool{}
+	for _, s := range smells {
+		got[key{s.Line, s.Category}] = true
+	}
+	for k := range want {
+		if !got[k] {
+			t.Errorf("missing smell %s at line %d", k.category, k.line)
+		}
+	}
+	for k := range got {
+		if !want[k] {
+			t.Errorf("unexpected smell %s at line %d", k.category, k.line)
+		}
+	}
+}
+
+func TestFindSQLSmells_Clean(t *testing.T) {
+	source := `SELECT carrid, connid FROM sflight
+  WHERE carrid = @lv_carrid
+  INTO TABLE @DATA(lt_flights)
+  UP TO 100 ROWS.
+`
+	if smells := FindSQLSmells(source); len(smells) != 0 {
+		t.Errorf("expected no smells, got %+v", smells)
+	}
+}
@@ -0,0 +1,79 @@
+package adt
+
+import (
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// --- Class Object Structure Cache ---
+
+// structureCache keeps parsed class objectstructures for a short time so
+// that method listing, method source extraction and structure reads of the
+// same class share a single request. A zero TTL disables caching.
+type structureCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[string]structureCacheEntry
+	now     func() time.Time
+}
+
+type structureCacheEntry struct {
+	structure *ClassObjectStructure
+	fetchedAt time.Time
+}
+
+func newStructureCache(ttl time.Duration) *structureCache {
+	return &structureCache{
+		ttl:     ttl,
+		entries: make(map[string]structureCacheEntry),
+		now:     time.Now,
+	}
+}
+
+// get returns the cached structure of a class if it is younger than the TTL.
+func (s *structureCache) get(className string) (*ClassObjectStructure, bool) {
+	if s == nil || s.ttl <= 0 {
+		return nil, false
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entry, ok := s.entries[className]
+	if !ok || s.now().Sub(entry.fetchedAt) >= s.ttl {
+		delete(s.entries, className)
+		return nil, false
+	}
+	return entry.structure, true
+}
+
+func (s *structureCache) put(className string, structure *ClassObjectStructure) {
+	if s == nil || s.ttl <= 0 {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries[className] = structureCacheEntry{structure: structure, fetchedAt: s.now()}
+}
+
+// invalidateURL drops the cached structure of the class an ADT URL belongs
+// to, e.g. after a write to /sap/bc/adt/oo/classes/ZCL_FOO/source/main.
+func (s *structureCache) invalidateURL(objectURL string) {
+	if s == nil || s.ttl <= 0 {
+		return
+	}
+	const prefix = "/sap/bc/adt/oo/classes/"
+	rest, ok := strings.CutPrefix(objectURL, prefix)
+	if !ok {
+		return
+	}
+	if i := strings.Index(rest, "/"); i >= 0 {
+		rest = rest[:i]
+	}
+	if name, err := url.PathUnescape(rest); err == nil {
+		rest = name
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.entries, strings.ToUpper(rest))
+}
@@ -0,0 +1,82 @@
+package adt
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+const sampleStructureXML = `<?xml version="1.0" encoding="UTF-8"?>
+<abapsource:objectStructureElement xmlns:abapsource="http://www.sap.com/adt/abapsource" xmlns:adtcore="http://www.sap.com/adt/core" name="ZCL_DEMO" type="CLAS/OC">
+  <abapsource:objectStructureElement name="RUN" type="CLAS/OM" visibility="public" level="instance">
+    <atom:link xmlns:atom="http://www.w3.org/2005/Atom" href="./source/main#start=2,0;end=4,0" rel="http://www.sap.com/adt/relations/source/implementationBlock"/>
+  </abapsource:objectStructureElement>
+</abapsource:objectStructureElement>`
+
+// structureRequests counts the objectstructure GETs seen by the mock.
+func structureRequests(mock *methodPathMock) int {
+	n := 0
+	for _, call := range mock.calls {
+		if call.method == "GET" && strings.HasSuffix(call.path, "/objectstructure") {
+			n++
+		}
+	}
+	return n
+}
+
+func TestStructureCache_SharedBetweenReaders(t *testing.T) {
+	source := "CLASS zcl_demo IMPLEMENTATION.\n  METHOD run.\n  ENDMETHOD.\nENDCLASS."
+	mock := &methodPathMock{routes: []routedResponse{
+		resp("", "discovery", http.StatusOK, "ok"),
+		resp("GET", "/objectstructure", http.StatusOK, sampleStructureXML),
+		resp("GET", "/source/main", http.StatusOK, source),
+		resp("PUT", "/source/main", http.StatusOK, ""),
+	}}
+	cfg := NewConfig("https://sap.example.com:44300", "user", "pass", WithStructureCache(time.Minute))
+	client := NewClientWithTransport(cfg, NewTransportWithClient(cfg, mock))
+	ctx := context.Background()
+
+	if _, err := client.GetClassObjectStructure(ctx, "ZCL_DEMO"); err != nil {
+		t.Fatalf("GetClassObjectStructure failed: %v", err)
+	}
+	if _, err := client.GetClassMethodSource(ctx, "zcl_demo", "RUN"); err != nil {
+		t.Fatalf("GetClassMethodSource failed: %v", err)
+	}
+	if n := structureRequests(mock); n != 1 {
+		t.Errorf("expected 1 objectstructure request within TTL, got %d", n)
+	}
+
+	// A write to the class invalidates its cached structure
+	if err := client.UpdateSource(ctx, "/sap/bc/adt/oo/classes/ZCL_DEMO/source/main", source, "TESTHANDLE", ""); err != nil {
+		t.Fatalf("UpdateSource failed: %v", err)
+	}
+	if _, err := client.GetClassMethods(ctx, "ZCL_DEMO"); err != nil {
+		t.Fatalf("GetClassMethods failed: %v", err)
+	}
+	if n := structureRequests(mock); n != 2 {
+		t.Errorf("expected refetch after write, got %d objectstructure requests", n)
+	}
+}
+
+func TestStructureCache_Expiry(t *testing.T) {
+	cache := newStructureCache(time.Minute)
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	cache.now = func() time.Time { return now }
+
+	cache.put("ZCL_DEMO", &ClassObjectStructure{})
+	if _, ok := cache.get("ZCL_DEMO"); !ok {
+		t.Fatal("expected cache hit within TTL")
+	}
+	now = now.Add(time.Minute)
+	if _, ok := cache.get("ZCL_DEMO"); ok {
+		t.Error("expected cache miss after TTL")
+	}
+
+	disabled := newStructureCache(0)
+	disabled.put("ZCL_DEMO", &ClassObjectStructure{})
+	if _, ok := disabled.get("ZCL_DEMO"); ok {
+		t.Error("zero TTL must disable caching")
+	}
+}
@@ -0,0 +1,34 @@
+package adt
+
+import (
+	"context"
+	"time"
+)
+
+type requestTimeoutKey struct{}
+
+// WithRequestTimeout returns a copy of ctx that makes Transport.Request
+// allow d for each attempt instead of Config.Timeout. d may be shorter or
+// longer than the client-wide timeout; 0 disables the timeout.
+func WithRequestTimeout(ctx context.Context, d time.Duration) context.Context {
+	return context.WithValue(ctx, requestTimeoutKey{}, d)
+}
+
+// RequestTimeout returns the override set by WithRequestTimeout.
+func RequestTimeout(ctx context.Context) (time.Duration, bool) {
+	d, ok := ctx.Value(requestTimeoutKey{}).(time.Duration)
+	return d, ok
+}
+
+// attemptContext bounds a single HTTP exchange by the per-request timeout
+// from ctx, or by Config.Timeout when there is none.
+func (t *Transport) attemptContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	d := t.config.Timeout
+	if override, ok := RequestTimeout(ctx); ok {
+		d = override
+	}
+	if d <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, d)
+}
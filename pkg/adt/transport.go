@@ -191,11 +191,62 @@ func parseUserTransports(data []byte) (*UserTransports, error) {
 			reqs[i].Type = "customizing"
 		}
 		result.Customizing = append(result.Customizing, reqs...)
+
+		releasedReqs := convertRequests(t.Released.Requests, t.Name)
+		for i := range releasedReqs {
+			releasedReqs[i].Type = "customizing"
+		}
+		result.Customizing = append(result.Customizing, releasedReqs...)
 	}
 
 	return result, nil
 }
 
+// Transport request status filters for GetTransports.
+const (
+	TransportStatusModifiable = "modifiable"
+	TransportStatusReleased   = "released"
+)
+
+// GetTransports lists the workbench and customizing requests of a user
+// (the session user when empty), including their tasks. status narrows the
+// list to TransportStatusModifiable or TransportStatusReleased requests; an
+// empty status returns both.
+func (c *Client) GetTransports(ctx context.Context, user, status string) ([]TransportRequest, error) {
+	status = strings.ToLower(status)
+	if status != "" && status != TransportStatusModifiable && status != TransportStatusReleased {
+		return nil, fmt.Errorf("unknown transport status %q: use %q or %q", status, TransportStatusModifiable, TransportStatusReleased)
+	}
+	if user == "" {
+		user = c.config.Username
+	}
+
+	transports, err := c.GetUserTransports(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []TransportRequest
+	for _, tr := range append(transports.Workbench, transports.Customizing...) {
+		if status == "" || transportStatusMatches(tr.Status, status) {
+			result = append(result, tr)
+		}
+	}
+	return result, nil
+}
+
+// transportStatusMatches maps an E070 request status to a GetTransports
+// filter: D (modifiable) and L (modifiable, protected) are modifiable;
+// everything else (R released, N released with import protection, O
+// release started) counts as released.
+func transportStatusMatches(code, status string) bool {
+	modifiable := code == "D" || code == "L"
+	if status == TransportStatusModifiable {
+		return modifiable
+	}
+	return !modifiable
+}
+
 // GetTransportInfo retrieves transport information for an object.
 // Returns available transports and whether the object is locked.
 func (c *Client) GetTransportInfo(ctx context.Context, objectURL string, devClass string) (*TransportInfo, error) {
@@ -267,7 +318,7 @@ func parseTransportInfo(data []byte) (*TransportInfo, error) {
 // Returns the transport number on success.
 func (c *Client) CreateTransport(ctx context.Context, objectURL string, description string, devClass string) (string, error) {
 	// Safety check
-	if err := c.checkSafety(OpTransport, "CreateTransport"); err != nil {
+	if err := c.checkWriteSafety(OpTransport, "CreateTransport"); err != nil {
 		return "", err
 	}
 
@@ -297,7 +348,7 @@ func (c *Client) CreateTransport(ctx context.Context, objectURL string, descript
 // Returns release reports/messages.
 func (c *Client) ReleaseTransport(ctx context.Context, transportNumber string, ignoreLocks bool) ([]string, error) {
 	// Safety check
-	if err := c.checkSafety(OpTransport, "ReleaseTransport"); err != nil {
+	if err := c.checkWriteSafety(OpTransport, "ReleaseTransport"); err != nil {
 		return nil, err
 	}
 
@@ -412,6 +463,7 @@ type CreateTransportOptions struct {
 	Package        string
 	TransportLayer string
 	Type           string // "workbench" or "customizing"
+	TargetSystem   string // Empty uses the transport route of the system
 }
 
 // ReleaseTransportOptions for releasing transports
@@ -722,36 +774,39 @@ func parseTransportDetail(data []byte) (*TransportDetails, error) {
 	return t, nil
 }
 
-// CreateTransportV2 creates a new transport request with options
-func (c *Client) CreateTransportV2(ctx context.Context, opts CreateTransportOptions) (string, error) {
+// CreateTransportV2 creates a new transport request with options and
+// returns it as reported by the transport organizer. The session user owns
+// the request's task.
+func (c *Client) CreateTransportV2(ctx context.Context, opts CreateTransportOptions) (*TransportRequest, error) {
 	// Safety check
 	if err := c.config.Safety.CheckTransport("", "CreateTransport", true); err != nil {
-		return "", err
+		return nil, err
 	}
 
 	if opts.Description == "" {
-		return "", fmt.Errorf("description is required")
+		return nil, fmt.Errorf("description is required")
 	}
 	if opts.Package == "" {
-		return "", fmt.Errorf("package is required")
+		return nil, fmt.Errorf("package is required")
 	}
 
 	// Default to workbench request
-	reqType := "K"
+	reqType, typeName := "K", "workbench"
 	if strings.ToLower(opts.Type) == "customizing" {
-		reqType = "W"
+		reqType, typeName = "W", "customizing"
 	}
 
 	owner := strings.ToUpper(c.config.Username)
 
 	body := fmt.Sprintf(`<?xml version="1.0" encoding="UTF-8"?>
 <tm:root xmlns:tm="http://www.sap.com/cts/adt/tm" tm:useraction="newrequest">
-  <tm:request tm:type="%s" tm:desc="%s" tm:target="" tm:cts_project="">
+  <tm:request tm:type="%s" tm:desc="%s" tm:target="%s" tm:cts_project="">
     <tm:task tm:owner="%s"/>
   </tm:request>
 </tm:root>`,
 		reqType,
 		escapeXMLAttr(opts.Description),
+		escapeXMLAttr(strings.ToUpper(opts.TargetSystem)),
 		owner)
 
 	query := make(map[string][]string)
@@ -767,10 +822,15 @@ func (c *Client) CreateTransportV2(ctx context.Context, opts CreateTransportOpti
 		Accept:      acceptTransportOrganizerV1,
 	})
 	if err != nil {
-		return "", fmt.Errorf("creating transport: %w", err)
+		return nil, fmt.Errorf("creating transport: %w", err)
 	}
 
-	return parseCreateTransportResponse(resp.Body)
+	tr, err := parseCreatedTransport(resp.Body, opts.Description, owner)
+	if err != nil {
+		return nil, err
+	}
+	tr.Type = typeName
+	return tr, nil
 }
 
 // parseCreateTransportResponse extracts the transport number from the XML response.
@@ -814,6 +874,94 @@ func parseCreateTransportResponse(data []byte) (string, error) {
 	return text, nil
 }
 
+// parseCreatedTransport reads the request returned by the transport
+// organizer after creation. Attributes missing from the response are taken
+// from what was requested; older systems that only return the number are
+// handled by parseCreateTransportResponse.
+func parseCreatedTransport(data []byte, description, owner string) (*TransportRequest, error) {
+	xmlStr := strings.ReplaceAll(string(data), "tm:", "")
+
+	type request struct {
+		Number string `xml:"number,attr"`
+		Desc   string `xml:"desc,attr"`
+		Owner  string `xml:"owner,attr"`
+		Status string `xml:"status,attr"`
+		Target string `xml:"target,attr"`
+	}
+	type root struct {
+		Request *request `xml:"request"`
+	}
+
+	tr := &TransportRequest{Description: description, Owner: owner, Status: "D", Type: "workbench"}
+	var resp root
+	if err := xml.Unmarshal([]byte(xmlStr), &resp); err == nil && resp.Request != nil && resp.Request.Number != "" {
+		tr.Number = strings.TrimSpace(resp.Request.Number)
+		if resp.Request.Desc != "" {
+			tr.Description = resp.Request.Desc
+		}
+		if resp.Request.Owner != "" {
+			tr.Owner = resp.Request.Owner
+		}
+		if resp.Request.Status != "" {
+			tr.Status = resp.Request.Status
+		}
+		tr.Target = resp.Request.Target
+		return tr, nil
+	}
+
+	number, err := parseCreateTransportResponse(data)
+	if err != nil {
+		return nil, err
+	}
+	tr.Number = number
+	return tr, nil
+}
+
+// AssignToTransport records an existing source-based object on a transport
+// request. The transport organizer has no separate assign call: like the
+// editor, this saves the unchanged source under the request, which adds the
+// object to the user's task.
+//
+// Workflow: Lock → GetSource → UpdateSource(corrNr) → Unlock
+func (c *Client) AssignToTransport(ctx context.Context, objectURI, transportNumber string) error {
+	if transportNumber == "" {
+		return fmt.Errorf("transport number is required")
+	}
+	transportNumber = strings.ToUpper(transportNumber)
+	objectURI = strings.TrimSuffix(objectURI, "/source/main")
+
+	// Unified mutation policy gate (op type + package + transport)
+	if err := c.checkMutation(ctx, MutationContext{
+		Op:        OpUpdate,
+		OpName:    "AssignToTransport",
+		ObjectURL: objectURI,
+		Transport: transportNumber,
+	}); err != nil {
+		return err
+	}
+
+	lock, err := c.LockObject(ctx, objectURI, "MODIFY")
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.transport.Request(ctx, objectURI+"/source/main", &RequestOptions{
+		Method: http.MethodGet,
+		Accept: "text/plain",
+	})
+	if err == nil {
+		err = c.UpdateSource(ctx, objectURI+"/source/main", string(resp.Body), lock.LockHandle, transportNumber)
+	} else {
+		err = fmt.Errorf("reading source: %w", err)
+	}
+	if err != nil {
+		_ = c.UnlockObject(ctx, objectURI, lock.LockHandle)
+		return fmt.Errorf("assigning to transport %s: %w", transportNumber, err)
+	}
+
+	return c.UnlockObject(ctx, objectURI, lock.LockHandle)
+}
+
 // ReleaseTransportV2 releases a transport request with options
 func (c *Client) ReleaseTransportV2(ctx context.Context, number string, opts ReleaseTransportOptions) error {
 	// Safety check
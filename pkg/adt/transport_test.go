@@ -1,6 +1,11 @@
 package adt
 
 import (
+	"context"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -286,3 +291,175 @@ func TestTransportTypes(t *testing.T) {
 		t.Errorf("TransportInfo.LockedByUser mismatch")
 	}
 }
+
+func TestClient_CreateTransportV2(t *testing.T) {
+	var body string
+	mock := &funcMockClient{doFunc: func(req *http.Request) (*http.Response, error) {
+		if strings.Contains(req.URL.Path, "discovery") {
+			resp := newTestResponse("OK")
+			resp.Header.Set("X-CSRF-Token", "test-token")
+			return resp, nil
+		}
+		b, _ := io.ReadAll(req.Body)
+		body = string(b)
+		return newTestResponse(`<?xml version="1.0" encoding="utf-8"?>
+<tm:root xmlns:tm="http://www.sap.com/cts/adt/tm" tm:useraction="newrequest">
+  <tm:request tm:number="TR-EXAMPLE" tm:desc="Demo &amp; test" tm:owner="TESTUSER" tm:status="D" tm:target="QAS"/>
+</tm:root>`), nil
+	}}
+	cfg := NewConfig("https://sap.example.com:44300", "testuser", "pass", WithEnableTransports())
+	client := NewClientWithTransport(cfg, NewTransportWithClient(cfg, mock))
+
+	tr, err := client.CreateTransportV2(context.Background(), CreateTransportOptions{
+		Description:  "Demo & test",
+		Package:      "ZDEMO",
+		TargetSystem: "qas",
+	})
+	if err != nil {
+		t.Fatalf("CreateTransportV2 failed: %v", err)
+	}
+	for _, want := range []string{`tm:useraction="newrequest"`, `tm:type="K"`, `tm:desc="Demo &amp; test"`, `tm:target="QAS"`, `tm:owner="TESTUSER"`} {
+		if !strings.Contains(body, want) {
+			t.Errorf("request body lacks %s:\n%s", want, body)
+		}
+	}
+	want := TransportRequest{Number: "TR-EXAMPLE", Description: "Demo & test", Owner: "TESTUSER", Status: "D", Target: "QAS", Type: "workbench"}
+	if !reflect.DeepEqual(*tr, want) {
+		t.Errorf("transport = %+v, want %+v", *tr, want)
+	}
+}
+
+func TestParseCreatedTransport_NumberOnly(t *testing.T) {
+	tr, err := parseCreatedTransport([]byte("/com.sap.cts/object_record/TR-EXAMPLE"), "Demo", "TESTUSER")
+	if err != nil {
+		t.Fatalf("parseCreatedTransport failed: %v", err)
+	}
+	if tr.Number != "TR-EXAMPLE" || tr.Description != "Demo" || tr.Owner != "TESTUSER" || tr.Status != "D" {
+		t.Errorf("unexpected transport: %+v", tr)
+	}
+}
+
+func TestClient_AssignToTransport(t *testing.T) {
+	var seq []string
+	var corrNr string
+	mock := &funcMockClient{doFunc: func(req *http.Request) (*http.Response, error) {
+		if strings.Contains(req.URL.Path, "discovery") {
+			resp := newTestResponse("OK")
+			resp.Header.Set("X-CSRF-Token", "test-token")
+			return resp, nil
+		}
+		entry := req.Method + " " + req.URL.Path
+		if action := req.URL.Query().Get("_action"); action != "" {
+			entry += " " + action
+		}
+		seq = append(seq, entry)
+		switch {
+		case req.URL.Query().Get("_action") == "LOCK":
+			return newTestResponse(lockResponseXML), nil
+		case req.Method == http.MethodPut:
+			corrNr = req.URL.Query().Get("corrNr")
+		case req.Method == http.MethodGet:
+			return newTestResponse("REPORT zdemo_report."), nil
+		}
+		return newTestResponse(""), nil
+	}}
+	cfg := NewConfig("https://sap.example.com:44300", "user", "pass", WithAllowTransportableEdits())
+	client := NewClientWithTransport(cfg, NewTransportWithClient(cfg, mock))
+
+	err := client.AssignToTransport(context.Background(), "/sap/bc/adt/programs/programs/ZDEMO_REPORT", "tr-example")
+	if err != nil {
+		t.Fatalf("AssignToTransport failed: %v", err)
+	}
+	if corrNr != "TR-EXAMPLE" {
+		t.Errorf("corrNr = %q, want TR-EXAMPLE", corrNr)
+	}
+	want := []string{
+		"POST /sap/bc/adt/programs/programs/ZDEMO_REPORT LOCK",
+		"GET /sap/bc/adt/programs/programs/ZDEMO_REPORT/source/main",
+		"PUT /sap/bc/adt/programs/programs/ZDEMO_REPORT/source/main",
+		"POST /sap/bc/adt/programs/programs/ZDEMO_REPORT UNLOCK",
+	}
+	if !reflect.DeepEqual(seq, want) {
+		t.Errorf("requests = %v, want %v", seq, want)
+	}
+}
+
+const transportWorklistXML = `<?xml version="1.0" encoding="utf-8"?>
+<tm:root xmlns:tm="http://www.sap.com/cts/adt/tm">
+  <tm:workbench>
+    <tm:target tm:name="QAS">
+      <tm:modifiable>
+        <tm:request tm:number="TR-EXAMPLE" tm:owner="TESTUSER" tm:desc="Demo feature" tm:status="D">
+          <tm:task tm:number="TR-EXAMPLE-1" tm:owner="TESTUSER" tm:desc="Demo feature" tm:status="D">
+            <tm:abap_object tm:pgmid="R3TR" tm:type="CLAS" tm:name="ZCL_DEMO_ORDER"/>
+          </tm:task>
+          <tm:task tm:number="TR-EXAMPLE-2" tm:owner="TESTUSER" tm:desc="Demo feature" tm:status="D">
+            <tm:abap_object tm:pgmid="R3TR" tm:type="PROG" tm:name="ZDEMO_REPORT"/>
+            <tm:abap_object tm:pgmid="R3TR" tm:type="INTF" tm:name="ZIF_DEMO_ORDER"/>
+          </tm:task>
+        </tm:request>
+      </tm:modifiable>
+      <tm:released>
+        <tm:request tm:number="TR-EXAMPLE-OLD" tm:owner="TESTUSER" tm:desc="Earlier work" tm:status="R"/>
+      </tm:released>
+    </tm:target>
+  </tm:workbench>
+  <tm:customizing>
+    <tm:target tm:name="QAS">
+      <tm:modifiable/>
+      <tm:released>
+        <tm:request tm:number="CR-EXAMPLE" tm:owner="TESTUSER" tm:desc="Settings" tm:status="R"/>
+      </tm:released>
+    </tm:target>
+  </tm:customizing>
+</tm:root>`
+
+func TestClient_GetTransports(t *testing.T) {
+	var users []string
+	mock := &funcMockClient{doFunc: func(req *http.Request) (*http.Response, error) {
+		if strings.Contains(req.URL.Path, "discovery") {
+			resp := newTestResponse("OK")
+			resp.Header.Set("X-CSRF-Token", "test-token")
+			return resp, nil
+		}
+		users = append(users, req.URL.Query().Get("user"))
+		return newTestResponse(transportWorklistXML), nil
+	}}
+	cfg := NewConfig("https://sap.example.com:44300", "testuser", "pass", WithEnableTransports())
+	client := NewClientWithTransport(cfg, NewTransportWithClient(cfg, mock))
+
+	open, err := client.GetTransports(context.Background(), "", TransportStatusModifiable)
+	if err != nil {
+		t.Fatalf("GetTransports failed: %v", err)
+	}
+	if len(open) != 1 || open[0].Number != "TR-EXAMPLE" {
+		t.Fatalf("modifiable transports = %+v, want only TR-EXAMPLE", open)
+	}
+	if len(open[0].Tasks) != 2 || len(open[0].Tasks[1].Objects) != 2 || open[0].Tasks[1].Objects[0].Name != "ZDEMO_REPORT" {
+		t.Errorf("tasks = %+v", open[0].Tasks)
+	}
+
+	released, err := client.GetTransports(context.Background(), "TESTUSER", TransportStatusReleased)
+	if err != nil {
+		t.Fatalf("GetTransports failed: %v", err)
+	}
+	var numbers []string
+	for _, tr := range released {
+		numbers = append(numbers, tr.Number+"/"+tr.Type)
+	}
+	if want := []string{"TR-EXAMPLE-OLD/workbench", "CR-EXAMPLE/customizing"}; !reflect.DeepEqual(numbers, want) {
+		t.Errorf("released transports = %v, want %v", numbers, want)
+	}
+
+	all, err := client.GetTransports(context.Background(), "", "")
+	if err != nil || len(all) != 3 {
+		t.Errorf("all transports: %d, %v", len(all), err)
+	}
+	if want := []string{"TESTUSER", "TESTUSER", "TESTUSER"}; !reflect.DeepEqual(users, want) {
+		t.Errorf("user params = %v, want %v", users, want)
+	}
+
+	if _, err := client.GetTransports(context.Background(), "", "open"); err == nil {
+		t.Error("expected an error for an unknown status filter")
+	}
+}
@@ -200,6 +200,77 @@ func (c *Client) WriteClass(ctx context.Context, className string, source string
 	return result, nil
 }
 
+// WriteProgramSource replaces the main source of a program: Lock -> UpdateSource -> Unlock.
+// Unlike WriteProgram it neither syntax-checks nor activates, so the new source
+// stays inactive. If another user holds the lock, the error is an *ObjectLockedError.
+func (c *Client) WriteProgramSource(ctx context.Context, programName string, source string) error {
+	programName = strings.ToUpper(programName)
+	objectURL := fmt.Sprintf("/sap/bc/adt/programs/programs/%s", url.PathEscape(programName))
+	return c.writeMainSource(ctx, "WriteProgramSource", objectURL, source)
+}
+
+// WriteClassSource replaces the main include of a class: Lock -> UpdateSource -> Unlock.
+// Unlike WriteClass it neither syntax-checks nor activates, so the new source
+// stays inactive. If another user holds the lock, the error is an *ObjectLockedError.
+func (c *Client) WriteClassSource(ctx context.Context, className string, source string) error {
+	className = strings.ToUpper(className)
+	objectURL := fmt.Sprintf("/sap/bc/adt/oo/classes/%s", url.PathEscape(className))
+	return c.writeMainSource(ctx, "WriteClassSource", objectURL, source)
+}
+
+// WriteClassInclude replaces one include part of a class ("main",
+// "definitions", "implementations", "macros" or "testclasses"):
+// Lock -> UpdateClassInclude -> Unlock. The lock is taken on the class
+// itself rather than the include, which also keeps the lock URI short for
+// long namespaced class names. Like WriteClassSource it does not activate.
+func (c *Client) WriteClassInclude(ctx context.Context, className, part, source string) error {
+	includeType, ok := classIncludePart(part)
+	if !ok {
+		return fmt.Errorf("unknown class include %q", part)
+	}
+	if err := c.checkSafety(OpUpdate, "WriteClassInclude"); err != nil {
+		return err
+	}
+
+	className = strings.ToUpper(className)
+	objectURL := GetObjectURL(ObjectTypeClass, className, "")
+	lock, err := c.LockObject(ctx, objectURL, "MODIFY")
+	if err != nil {
+		return err
+	}
+
+	if err := c.UpdateClassInclude(ctx, className, includeType, source, lock.LockHandle, ""); err != nil {
+		_ = c.UnlockObject(ctx, objectURL, lock.LockHandle)
+		return err
+	}
+
+	return c.UnlockObject(ctx, objectURL, lock.LockHandle)
+}
+
+// writeMainSource writes source/main of an object under its own lock.
+func (c *Client) writeMainSource(ctx context.Context, opName, objectURL, source string) error {
+	// Unified mutation policy gate (op type + package + transport)
+	if err := c.checkMutation(ctx, MutationContext{
+		Op:        OpUpdate,
+		OpName:    opName,
+		ObjectURL: objectURL,
+	}); err != nil {
+		return err
+	}
+
+	lock, err := c.LockObject(ctx, objectURL, "MODIFY")
+	if err != nil {
+		return err
+	}
+
+	if err := c.UpdateSource(ctx, objectURL+"/source/main", source, lock.LockHandle, ""); err != nil {
+		_ = c.UnlockObject(ctx, objectURL, lock.LockHandle)
+		return err
+	}
+
+	return c.UnlockObject(ctx, objectURL, lock.LockHandle)
+}
+
 // CreateProgramResult represents the result of creating a program.
 type CreateProgramResult struct {
 	Success      bool                `json:"success"`
@@ -0,0 +1,80 @@
+package adt
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// --- Destructive Batch Workflows ---
+
+// DeletePackageObjectsResult contains the result of deleting all objects in a package.
+type DeletePackageObjectsResult struct {
+	PackageName string   `json:"packageName"`
+	Deleted     []string `json:"deleted"`
+	Failed      []string `json:"failed,omitempty"`
+	Success     bool     `json:"success"`
+	Message     string   `json:"message,omitempty"`
+}
+
+// DeletePackageObjects deletes every object directly contained in a package.
+//
+// Workflow: GetPackage → Confirm → (Lock → Delete) per object
+//
+// This is a two-step operation. Called with an empty confirmToken it deletes
+// nothing and returns a *ConfirmationRequired error listing the objects that
+// would be destroyed. Re-invoking with that token (within DefaultConfirmationTTL,
+// and while the package content is unchanged) performs the deletion.
+func (c *Client) DeletePackageObjects(ctx context.Context, packageName, transport, confirmToken string) (*DeletePackageObjectsResult, error) {
+	packageName = strings.ToUpper(packageName)
+
+	if err := c.checkMutation(ctx, MutationContext{
+		Op:        OpDelete,
+		OpName:    "DeletePackageObjects",
+		Package:   packageName,
+		Transport: transport,
+	}); err != nil {
+		return nil, err
+	}
+
+	pkg, err := c.GetPackage(ctx, packageName)
+	if err != nil {
+		return nil, err
+	}
+
+	objects := make([]string, 0, len(pkg.Objects))
+	for _, obj := range pkg.Objects {
+		objects = append(objects, obj.Type+" "+obj.Name)
+	}
+
+	if err := c.confirmDestructive("DeletePackageObjects", "package "+packageName, objects, confirmToken); err != nil {
+		return nil, err
+	}
+
+	result := &DeletePackageObjectsResult{PackageName: packageName}
+	for _, obj := range pkg.Objects {
+		label := obj.Type + " " + obj.Name
+		if obj.URI == "" {
+			result.Failed = append(result.Failed, fmt.Sprintf("%s: no object URI", label))
+			continue
+		}
+
+		lock, err := c.LockObject(ctx, obj.URI, "MODIFY")
+		if err != nil {
+			result.Failed = append(result.Failed, fmt.Sprintf("%s: %v", label, err))
+			continue
+		}
+
+		if err := c.DeleteObject(ctx, obj.URI, lock.LockHandle, transport); err != nil {
+			_ = c.UnlockObject(ctx, obj.URI, lock.LockHandle)
+			result.Failed = append(result.Failed, fmt.Sprintf("%s: %v", label, err))
+			continue
+		}
+
+		result.Deleted = append(result.Deleted, label)
+	}
+
+	result.Success = len(result.Failed) == 0
+	result.Message = fmt.Sprintf("Deleted %d of %d objects in %s", len(result.Deleted), len(pkg.Objects), packageName)
+	return result, nil
+}
@@ -0,0 +1,358 @@
+package adt
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/oisee/vibing-steampunk/pkg/abaplint"
+)
+
+// --- Merged Class Source ---
+
+// classIncludeMarker prefixes the separator line between class includes in
+// a merged class source, e.g. `*"* vsp:include testclasses`. The `*"*`
+// form matches the comment banners SAP generates in class includes, so a
+// merged source remains valid ABAP when pasted into an editor.
+const classIncludeMarker = `*"* vsp:include `
+
+// mergedClassIncludeOrder is the order in which non-main includes appear in
+// a merged class source and are written back by WriteClassMerged.
+var mergedClassIncludeOrder = []ClassIncludeType{
+	ClassIncludeDefinitions,
+	ClassIncludeImplementations,
+	ClassIncludeMacros,
+	ClassIncludeTestClasses,
+}
+
+// MergeClassSource joins the includes of a class into a single source.
+// The main include comes first; every other non-empty include follows
+// behind a marker line naming it. SplitClassSource reverses the merge.
+func MergeClassSource(includes map[ClassIncludeType]string) string {
+	var sb strings.Builder
+	writePart := func(source string) {
+		sb.WriteString(source)
+		if source != "" && !strings.HasSuffix(source, "\n") {
+			sb.WriteString("\n")
+		}
+	}
+
+	writePart(includes[ClassIncludeMain])
+	for _, include := range mergedClassIncludeOrder {
+		source := includes[include]
+		if strings.TrimSpace(source) == "" {
+			continue
+		}
+		sb.WriteString(classIncludeMarker + string(include) + "\n")
+		writePart(source)
+	}
+	return sb.String()
+}
+
+// SplitClassSource splits a merged class source into its includes.
+// Text before the first marker line is the main include; a source without
+// markers is returned as the main include only.
+func SplitClassSource(merged string) (map[ClassIncludeType]string, error) {
+	known := map[ClassIncludeType]bool{}
+	for _, include := range mergedClassIncludeOrder {
+		known[include] = true
+	}
+
+	parts := map[ClassIncludeType]*strings.Builder{
+		ClassIncludeMain: {},
+	}
+	current := ClassIncludeMain
+
+	for _, line := range strings.SplitAfter(merged, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, classIncludeMarker) {
+			include := ClassIncludeType(strings.ToLower(strings.TrimSpace(strings.TrimPrefix(trimmed, classIncludeMarker))))
+			if !known[include] {
+				return nil, fmt.Errorf("unknown class include %q in merged source", include)
+			}
+			if _, dup := parts[include]; dup {
+				return nil, fmt.Errorf("class include %q appears more than once in merged source", include)
+			}
+			parts[include] = &strings.Builder{}
+			current = include
+			continue
+		}
+		parts[current].WriteString(line)
+	}
+
+	result := make(map[ClassIncludeType]string, len(parts))
+	for include, sb := range parts {
+		result[include] = sb.String()
+	}
+	return result, nil
+}
+
+// WriteClassMerged writes a merged class source (see MergeClassSource) back
+// to the system. The source is split into its includes, every include is
+// written under a single lock, and the class is activated at the end.
+// Includes missing from the merged source are left untouched; a missing
+// test class include is created on demand.
+func (c *Client) WriteClassMerged(ctx context.Context, className, mergedSource string) error {
+	className = strings.ToUpper(className)
+	objectURL := fmt.Sprintf("/sap/bc/adt/oo/classes/%s", url.PathEscape(className))
+
+	// Unified mutation policy gate (op type + package + transport)
+	if err := c.checkMutation(ctx, MutationContext{
+		Op:        OpWorkflow,
+		OpName:    "WriteClassMerged",
+		ObjectURL: objectURL,
+	}); err != nil {
+		return err
+	}
+
+	includes, err := SplitClassSource(mergedSource)
+	if err != nil {
+		return err
+	}
+	if strings.TrimSpace(includes[ClassIncludeMain]) == "" {
+		return fmt.Errorf("merged source for %s has no main include", className)
+	}
+
+	lock, err := c.LockObject(ctx, objectURL, "MODIFY")
+	if err != nil {
+		return fmt.Errorf("locking class %s: %w", className, err)
+	}
+
+	unlocked := false
+	defer func() {
+		if !unlocked {
+			_ = c.UnlockObject(ctx, objectURL, lock.LockHandle)
+		}
+	}()
+
+	if err := c.UpdateSource(ctx, objectURL+"/source/main", includes[ClassIncludeMain], lock.LockHandle, ""); err != nil {
+		return fmt.Errorf("writing main include of %s: %w", className, err)
+	}
+
+	for _, include := range mergedClassIncludeOrder {
+		source, ok := includes[include]
+		if !ok {
+			continue
+		}
+		err := c.UpdateClassInclude(ctx, className, include, source, lock.LockHandle, "")
+		if err != nil && include == ClassIncludeTestClasses {
+			// The test include does not exist until it is created explicitly
+			if createErr := c.CreateTestInclude(ctx, className, lock.LockHandle, ""); createErr == nil {
+				err = c.UpdateClassInclude(ctx, className, include, source, lock.LockHandle, "")
+			}
+		}
+		if err != nil {
+			return fmt.Errorf("writing %s include of %s: %w", include, className, err)
+		}
+	}
+
+	unlocked = true
+	if err := c.UnlockObject(ctx, objectURL, lock.LockHandle); err != nil {
+		return fmt.Errorf("unlocking class %s: %w", className, err)
+	}
+
+	activation, err := c.Activate(ctx, objectURL, className)
+	if err != nil {
+		return err
+	}
+	if !activation.Success {
+		return fmt.Errorf("activation of %s failed: %s", className, activationMessageSummary(activation))
+	}
+	return nil
+}
+
+// activationMessageSummary joins the error messages of a failed activation.
+func activationMessageSummary(result *ActivationResult) string {
+	var msgs []string
+	for _, m := range result.Messages {
+		if strings.ContainsAny(m.Type, "EAX") {
+			msgs = append(msgs, m.ShortText)
+		}
+	}
+	if len(msgs) == 0 {
+		return "check activation messages"
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// --- Local Test Classes ---
+
+// ClassTests is the "tests for this class" view: the local test classes
+// declared in the testclasses include, and optionally the outcome of a run.
+type ClassTests struct {
+	ClassName string           `json:"className"`
+	Source    string           `json:"source"`
+	Classes   []LocalTestClass `json:"classes"`
+}
+
+// LocalTestClass is a test class declared with FOR TESTING.
+type LocalTestClass struct {
+	Name      string            `json:"name"`
+	Line      int               `json:"line"`
+	RiskLevel string            `json:"riskLevel,omitempty"`
+	Duration  string            `json:"duration,omitempty"`
+	Methods   []LocalTestMethod `json:"methods"`
+}
+
+// LocalTestMethod is a test method of a local test class. Result is set
+// once the ClassTests has been linked to a unit test run.
+type LocalTestMethod struct {
+	Name   string          `json:"name"`
+	Line   int             `json:"line"`
+	Result *UnitTestMethod `json:"result,omitempty"`
+}
+
+// GetClassTests reads the testclasses include of a class and lists the
+// test classes and methods declared in it. A class without a test include
+// yields an empty result rather than an error.
+func (c *Client) GetClassTests(ctx context.Context, className string) (*ClassTests, error) {
+	className = strings.ToUpper(className)
+	tests := &ClassTests{ClassName: className, Classes: []LocalTestClass{}}
+
+	source, err := c.GetClassInclude(ctx, className, ClassIncludeTestClasses)
+	if err != nil {
+		if IsNotFoundError(err) {
+			return tests, nil
+		}
+		return nil, err
+	}
+
+	tests.Source = source
+	tests.Classes = ParseLocalTestClasses(source)
+	return tests, nil
+}
+
+// RunClassTests runs the unit tests of a class and returns its local test
+// classes linked to the run result.
+func (c *Client) RunClassTests(ctx context.Context, className string, flags *UnitTestRunFlags) (*ClassTests, error) {
+	tests, err := c.GetClassTests(ctx, className)
+	if err != nil {
+		return nil, err
+	}
+
+	objectURL := fmt.Sprintf("/sap/bc/adt/oo/classes/%s", url.PathEscape(tests.ClassName))
+	result, err := c.RunUnitTests(ctx, objectURL, flags)
+	if err != nil {
+		return nil, err
+	}
+	tests.Link(result)
+	return tests, nil
+}
+
+// Link attaches the method results of a unit test run to the matching
+// source-declared test methods. It returns the run's methods that have no
+// counterpart in the source, as "CLASS=>METHOD", which indicates the
+// source and the active version have diverged.
+func (t *ClassTests) Link(result *UnitTestResult) []string {
+	if result == nil {
+		return nil
+	}
+
+	byClass := map[string]*LocalTestClass{}
+	for i := range t.Classes {
+		byClass[strings.ToUpper(t.Classes[i].Name)] = &t.Classes[i]
+	}
+
+	var unmatched []string
+	for _, runClass := range result.Classes {
+		local := byClass[strings.ToUpper(runClass.Name)]
+		for i := range runClass.TestMethods {
+			runMethod := &runClass.TestMethods[i]
+			if !linkTestMethod(local, runMethod) {
+				unmatched = append(unmatched, strings.ToUpper(runClass.Name)+"=>"+strings.ToUpper(runMethod.Name))
+			}
+		}
+	}
+	return unmatched
+}
+
+func linkTestMethod(class *LocalTestClass, result *UnitTestMethod) bool {
+	if class == nil {
+		return false
+	}
+	for i := range class.Methods {
+		if strings.EqualFold(class.Methods[i].Name, result.Name) {
+			class.Methods[i].Result = result
+			return true
+		}
+	}
+	return false
+}
+
+// ParseLocalTestClasses finds the test classes (CLASS … DEFINITION … FOR
+// TESTING) in a source and the FOR TESTING methods they declare, including
+// those in chained METHODS statements.
+func ParseLocalTestClasses(source string) []LocalTestClass {
+	tokens := (&abaplint.Lexer{}).Run(source)
+	stmts := (&abaplint.StatementParser{}).Parse(tokens)
+
+	classes := []LocalTestClass{}
+	var current *LocalTestClass
+	for _, st := range stmts {
+		words := make([]string, len(st.Tokens))
+		for i, tok := range st.Tokens {
+			words[i] = strings.ToUpper(tok.Str)
+		}
+		if len(words) == 0 {
+			continue
+		}
+
+		switch words[0] {
+		case "CLASS":
+			if len(words) < 3 || words[2] != "DEFINITION" || !containsWordSequence(words, "FOR", "TESTING") {
+				continue
+			}
+			classes = append(classes, LocalTestClass{
+				Name:      words[1],
+				Line:      st.Tokens[1].Row,
+				RiskLevel: wordAfter(words, "RISK", "LEVEL"),
+				Duration:  wordAfter(words, "DURATION"),
+				Methods:   []LocalTestMethod{},
+			})
+			current = &classes[len(classes)-1]
+		case "ENDCLASS":
+			current = nil
+		case "METHODS":
+			if current == nil || len(words) < 2 || !containsWordSequence(words, "FOR", "TESTING") {
+				continue
+			}
+			current.Methods = append(current.Methods, LocalTestMethod{
+				Name: words[1],
+				Line: st.Tokens[1].Row,
+			})
+		}
+	}
+	return classes
+}
+
+// containsWordSequence reports whether seq appears contiguously in words.
+func containsWordSequence(words []string, seq ...string) bool {
+	for i := 0; i+len(seq) <= len(words); i++ {
+		match := true
+		for j := range seq {
+			if words[i+j] != seq[j] {
+				match = false
+				break
+			}
+		}
+		if match {
+			return true
+		}
+	}
+	return false
+}
+
+// wordAfter returns the word following the first occurrence of seq, or ""
+// if seq is absent or ends the statement.
+func wordAfter(words []string, seq ...string) string {
+	for i := 0; i+len(seq) < len(words); i++ {
+		if containsWordSequence(words[i:i+len(seq)], seq...) {
+			if next := words[i+len(seq)]; next != "." {
+				return next
+			}
+			return ""
+		}
+	}
+	return ""
+}
@@ -0,0 +1,212 @@
+package adt
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// bodyRecordingMock wraps methodPathMock and additionally records the body
+// of every PUT, keyed by request path.
+type bodyRecordingMock struct {
+	methodPathMock
+	puts map[string]string
+}
+
+func (m *bodyRecordingMock) Do(req *http.Request) (*http.Response, error) {
+	if req.Method == http.MethodPut && req.Body != nil {
+		data, _ := io.ReadAll(req.Body)
+		if m.puts == nil {
+			m.puts = map[string]string{}
+		}
+		m.puts[req.URL.Path] = string(data)
+	}
+	return m.methodPathMock.Do(req)
+}
+
+func TestMergeSplitClassSource_RoundTrip(t *testing.T) {
+	includes := map[ClassIncludeType]string{
+		ClassIncludeMain:            "CLASS zcl_demo DEFINITION PUBLIC.\nENDCLASS.\nCLASS zcl_demo IMPLEMENTATION.\nENDCLASS.\n",
+		ClassIncludeDefinitions:     "CLASS lcl_helper DEFINITION.\nENDCLASS.\n",
+		ClassIncludeImplementations: "CLASS lcl_helper IMPLEMENTATION.\nENDCLASS.\n",
+		ClassIncludeTestClasses:     "CLASS ltcl_demo DEFINITION FOR TESTING.\nENDCLASS.\n",
+	}
+
+	split, err := SplitClassSource(MergeClassSource(includes))
+	if err != nil {
+		t.Fatalf("SplitClassSource failed: %v", err)
+	}
+	if len(split) != len(includes) {
+		t.Fatalf("got %d includes, want %d: %v", len(split), len(includes), split)
+	}
+	for include, want := range includes {
+		if split[include] != want {
+			t.Errorf("%s = %q, want %q", include, split[include], want)
+		}
+	}
+}
+
+func TestSplitClassSource_Errors(t *testing.T) {
+	if _, err := SplitClassSource("CLASS x.\n" + classIncludeMarker + "bogus\n"); err == nil {
+		t.Error("expected error for unknown include")
+	}
+	dup := classIncludeMarker + "testclasses\nA\n" + classIncludeMarker + "testclasses\nB\n"
+	if _, err := SplitClassSource(dup); err == nil {
+		t.Error("expected error for duplicate include")
+	}
+}
+
+func TestWriteClassMerged_WritesEachInclude(t *testing.T) {
+	includes := map[ClassIncludeType]string{
+		ClassIncludeMain:            "CLASS zcl_demo_merge DEFINITION PUBLIC.\nENDCLASS.\n",
+		ClassIncludeDefinitions:     "CLASS lcl_helper DEFINITION.\nENDCLASS.\n",
+		ClassIncludeImplementations: "CLASS lcl_helper IMPLEMENTATION.\nENDCLASS.\n",
+		ClassIncludeTestClasses:     "CLASS ltcl_demo DEFINITION FOR TESTING.\nENDCLASS.\n",
+	}
+
+	mock := &bodyRecordingMock{methodPathMock: methodPathMock{routes: []routedResponse{
+		resp("", "discovery", http.StatusOK, "ok"),
+		resp("POST", "/sap/bc/adt/activation", http.StatusOK, ""),
+		resp("POST", "/sap/bc/adt/oo/classes/ZCL_DEMO_MERGE", http.StatusOK, lockResponseXML),
+		resp("PUT", "/sap/bc/adt/oo/classes/ZCL_DEMO_MERGE/", http.StatusOK, ""),
+	}}}
+	cfg := NewConfig("https://sap.example.com:44300", "user", "pass")
+	client := NewClientWithTransport(cfg, NewTransportWithClient(cfg, mock))
+
+	if err := client.WriteClassMerged(context.Background(), "zcl_demo_merge", MergeClassSource(includes)); err != nil {
+		t.Fatalf("WriteClassMerged failed: %v", err)
+	}
+
+	for include, want := range includes {
+		path := GetClassIncludeSourceURL("ZCL_DEMO_MERGE", include)
+		if got, ok := mock.puts[path]; !ok {
+			t.Errorf("%s include was not written (PUT %s)", include, path)
+		} else if got != want {
+			t.Errorf("%s include body = %q, want %q", include, got, want)
+		}
+	}
+	if _, ok := mock.puts[GetClassIncludeSourceURL("ZCL_DEMO_MERGE", ClassIncludeMacros)]; ok {
+		t.Error("macros include absent from merged source must not be written")
+	}
+
+	locks := 0
+	for _, call := range mock.calls {
+		if call.method == "POST" && call.path == "/sap/bc/adt/oo/classes/ZCL_DEMO_MERGE" {
+			locks++
+		}
+	}
+	if locks != 2 { // one LOCK, one UNLOCK
+		t.Errorf("expected a single lock/unlock pair, got %d lock-path POSTs", locks)
+	}
+	if last := mock.calls[len(mock.calls)-1]; last.path != "/sap/bc/adt/activation" {
+		t.Errorf("last call = %s %s, want activation", last.method, last.path)
+	}
+}
+
+func TestRunClassTests_LinksSourceMethodsToRunResult(t *testing.T) {
+	testSource := `CLASS ltcl_demo DEFINITION FINAL FOR TESTING
+  RISK LEVEL HARMLESS DURATION SHORT.
+  PRIVATE SECTION.
+    METHODS: setup,
+      calculates_total FOR TESTING,
+      rejects_empty_input FOR TESTING RAISING cx_static_check.
+ENDCLASS.
+
+CLASS lcl_helper DEFINITION.
+  PUBLIC SECTION.
+    METHODS build FOR TESTING.
+ENDCLASS.
+`
+	runResult := `<?xml version="1.0" encoding="utf-8"?>
+<aunit:runResult xmlns:aunit="http://www.sap.com/adt/aunit" xmlns:adtcore="http://www.sap.com/adt/core">
+  <program adtcore:uri="/sap/bc/adt/oo/classes/zcl_demo" adtcore:type="CLAS/OC" adtcore:name="ZCL_DEMO">
+    <testClasses>
+      <testClass adtcore:uri="/sap/bc/adt/oo/classes/zcl_demo/includes/testclasses#type=CLAS%2FOCL;name=LTCL_DEMO" adtcore:type="CLAS/OCL" adtcore:name="LTCL_DEMO">
+        <testMethods>
+          <testMethod adtcore:name="CALCULATES_TOTAL" executionTime="0.01"/>
+          <testMethod adtcore:name="REJECTS_EMPTY_INPUT" executionTime="0.02">
+            <alerts>
+              <alert kind="failedAssertion" severity="critical"><title>Expected error</title></alert>
+            </alerts>
+          </testMethod>
+        </testMethods>
+      </testClass>
+    </testClasses>
+  </program>
+</aunit:runResult>`
+
+	mock := &methodPathMock{routes: []routedResponse{
+		resp("", "discovery", http.StatusOK, "ok"),
+		resp(http.MethodGet, "/oo/classes/ZCL_DEMO/includes/testclasses", http.StatusOK, testSource),
+		resp(http.MethodPost, "/abapunit/testruns", http.StatusOK, runResult),
+	}}
+	cfg := NewConfig("https://sap.example.com:44300", "user", "pass")
+	client := NewClientWithTransport(cfg, NewTransportWithClient(cfg, mock))
+
+	tests, err := client.RunClassTests(context.Background(), "zcl_demo", nil)
+	if err != nil {
+		t.Fatalf("RunClassTests failed: %v", err)
+	}
+
+	if len(tests.Classes) != 1 {
+		t.Fatalf("expected 1 test class, got %d: %+v", len(tests.Classes), tests.Classes)
+	}
+	class := tests.Classes[0]
+	if class.Name != "LTCL_DEMO" || class.RiskLevel != "HARMLESS" || class.Duration != "SHORT" {
+		t.Errorf("unexpected test class: %+v", class)
+	}
+
+	var sourceNames, runNames []string
+	for _, m := range class.Methods {
+		sourceNames = append(sourceNames, m.Name)
+		if m.Result == nil {
+			t.Errorf("method %s not linked to the run result", m.Name)
+			continue
+		}
+		runNames = append(runNames, m.Result.Name)
+	}
+	want := []string{"CALCULATES_TOTAL", "REJECTS_EMPTY_INPUT"}
+	if strings.Join(sourceNames, ",") != strings.Join(want, ",") {
+		t.Errorf("source methods = %v, want %v", sourceNames, want)
+	}
+	if strings.Join(runNames, ",") != strings.Join(want, ",") {
+		t.Errorf("run methods = %v, want %v", runNames, want)
+	}
+	if class.Methods[1].Line != 6 {
+		t.Errorf("REJECTS_EMPTY_INPUT line = %d, want 6", class.Methods[1].Line)
+	}
+	if got := len(class.Methods[1].Result.Alerts); got != 1 {
+		t.Errorf("expected the failed assertion on REJECTS_EMPTY_INPUT, got %d alerts", got)
+	}
+}
+
+func TestClassTests_LinkReportsUnmatchedRunMethods(t *testing.T) {
+	tests := &ClassTests{Classes: ParseLocalTestClasses("CLASS ltcl_demo DEFINITION FOR TESTING.\n  PRIVATE SECTION.\n    METHODS first FOR TESTING.\nENDCLASS.\n")}
+	unmatched := tests.Link(&UnitTestResult{Classes: []UnitTestClass{{
+		Name:        "LTCL_DEMO",
+		TestMethods: []UnitTestMethod{{Name: "FIRST"}, {Name: "REMOVED"}},
+	}}})
+
+	if len(unmatched) != 1 || unmatched[0] != "LTCL_DEMO=>REMOVED" {
+		t.Errorf("unmatched = %v, want [LTCL_DEMO=>REMOVED]", unmatched)
+	}
+}
+
+func TestGetClassTests_NoTestInclude(t *testing.T) {
+	mock := &methodPathMock{routes: []routedResponse{
+		resp("", "discovery", http.StatusOK, "ok"),
+		resp(http.MethodGet, "/includes/testclasses", http.StatusNotFound, "not found"),
+	}}
+	cfg := NewConfig("https://sap.example.com:44300", "user", "pass")
+	client := NewClientWithTransport(cfg, NewTransportWithClient(cfg, mock))
+
+	tests, err := client.GetClassTests(context.Background(), "ZCL_DEMO")
+	if err != nil {
+		t.Fatalf("GetClassTests failed: %v", err)
+	}
+	if len(tests.Classes) != 0 {
+		t.Errorf("expected no test classes, got %+v", tests.Classes)
+	}
+}
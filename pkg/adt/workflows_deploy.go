@@ -469,6 +469,10 @@ func (c *Client) buildObjectURLWithParent(objType CreatableObjectType, name, par
 		return fmt.Sprintf("/sap/bc/adt/functions/groups/%s/fmodules/%s", encodedParent, encodedName), nil
 	case ObjectTypeInclude:
 		return fmt.Sprintf("/sap/bc/adt/programs/includes/%s", encodedName), nil
+	case ObjectTypeTransformation:
+		return fmt.Sprintf("/sap/bc/adt/xslt/transformations/%s", encodedName), nil
+	case ObjectTypeTypeGroup:
+		return fmt.Sprintf("/sap/bc/adt/ddic/typegroups/%s", encodedName), nil
 	// RAP object types
 	case ObjectTypeDDLS:
 		return fmt.Sprintf("/sap/bc/adt/ddic/ddl/sources/%s", encodedName), nil
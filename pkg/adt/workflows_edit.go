@@ -3,6 +3,7 @@ package adt
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"strings"
 )
 
@@ -219,7 +220,7 @@ func (c *Client) EditSourceWithOptions(ctx context.Context, objectURL, oldString
 	}
 
 	resp, err := c.transport.Request(ctx, sourceURL, &RequestOptions{
-		Method: "GET",
+		Method: http.MethodGet,
 		Accept: "text/plain",
 	})
 	if err != nil {
@@ -419,3 +420,126 @@ func (c *Client) EditSourceWithOptions(ctx context.Context, objectURL, oldString
 	return result, nil
 }
 
+// SourceEdit describes a line-based replacement for EditSourceRange: either
+// the lines StartLine..EndLine (1-based, inclusive) or, for classes, the
+// whole METHOD … ENDMETHOD block of Method is replaced by NewText. An empty
+// NewText deletes the lines.
+type SourceEdit struct {
+	StartLine int    `json:"startLine,omitempty"`
+	EndLine   int    `json:"endLine,omitempty"`
+	Method    string `json:"method,omitempty"`
+	NewText   string `json:"newText"`
+	Transport string `json:"transport,omitempty"` // Transport request number (required for non-$TMP packages)
+}
+
+// EditSourceRange replaces a line range or a class method in the main
+// source of an object, so large objects can be edited without sending the
+// whole source back from the caller. The object is left inactive.
+//
+// Workflow: GetSource → (GetClassMethods) → Splice → Lock → UpdateSource → Unlock
+func (c *Client) EditSourceRange(ctx context.Context, objType, name string, edit SourceEdit) (*EditSourceResult, error) {
+	t, ok := objectTypeFromShort(objType)
+	if !ok || t == ObjectTypeFunctionMod {
+		return nil, fmt.Errorf("unsupported object type for EditSourceRange: %s", objType)
+	}
+	byMethod := edit.Method != ""
+	switch {
+	case byMethod && (edit.StartLine != 0 || edit.EndLine != 0):
+		return nil, fmt.Errorf("edit either a line range or a method, not both")
+	case byMethod && t != ObjectTypeClass:
+		return nil, fmt.Errorf("method edits are only supported for classes, not %s", objType)
+	case !byMethod && (edit.StartLine < 1 || edit.EndLine < edit.StartLine):
+		return nil, fmt.Errorf("invalid line range %d-%d", edit.StartLine, edit.EndLine)
+	}
+
+	name = strings.ToUpper(name)
+	objectURL := GetObjectURL(t, name, "")
+	sourceURL := objectURL + "/source/main"
+
+	// Unified mutation policy gate (op type + package + transport)
+	if err := c.checkMutation(ctx, MutationContext{
+		Op:        OpUpdate,
+		OpName:    "EditSourceRange",
+		ObjectURL: objectURL,
+		Transport: edit.Transport,
+	}); err != nil {
+		return nil, err
+	}
+
+	result := &EditSourceResult{ObjectURL: objectURL, ObjectName: name, Method: strings.ToUpper(edit.Method)}
+
+	resp, err := c.transport.Request(ctx, sourceURL, &RequestOptions{
+		Method: http.MethodGet,
+		Accept: "text/plain",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("reading source: %w", err)
+	}
+	source := normalizeLineEndings(string(resp.Body))
+	lines := strings.Split(source, "\n")
+
+	start, end := edit.StartLine, edit.EndLine
+	if byMethod {
+		start, end, err = c.classMethodLines(ctx, name, edit.Method, source)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if end > len(lines) {
+		return nil, fmt.Errorf("line range %d-%d exceeds source lines (%d)", start, end, len(lines))
+	}
+
+	var newLines []string
+	newLines = append(newLines, lines[:start-1]...)
+	if edit.NewText != "" {
+		newLines = append(newLines, strings.Split(normalizeLineEndings(edit.NewText), "\n")...)
+	}
+	newLines = append(newLines, lines[end:]...)
+	result.OldString = strings.Join(lines[start-1:end], "\n")
+	result.NewString = edit.NewText
+	result.MatchCount = 1
+
+	lock, err := c.LockObject(ctx, objectURL, "MODIFY")
+	if err != nil {
+		return nil, err
+	}
+	if err := c.UpdateSource(ctx, sourceURL, strings.Join(newLines, "\n"), lock.LockHandle, edit.Transport); err != nil {
+		_ = c.UnlockObject(ctx, objectURL, lock.LockHandle)
+		return nil, err
+	}
+	if err := c.UnlockObject(ctx, objectURL, lock.LockHandle); err != nil {
+		return nil, err
+	}
+
+	result.Success = true
+	if byMethod {
+		result.Message = fmt.Sprintf("Replaced method %s (lines %d-%d) of %s", result.Method, start, end, name)
+	} else {
+		result.Message = fmt.Sprintf("Replaced lines %d-%d of %s", start, end, name)
+	}
+	return result, nil
+}
+
+// classMethodLines returns the line range of a method's METHOD … ENDMETHOD
+// block in source, the main source of className.
+func (c *Client) classMethodLines(ctx context.Context, className, methodName, source string) (int, int, error) {
+	methodName = strings.ToUpper(methodName)
+	methods, err := c.GetClassMethods(ctx, className)
+	if err != nil {
+		return 0, 0, fmt.Errorf("getting class methods: %w", err)
+	}
+	for _, m := range methods {
+		if m.Name != methodName {
+			continue
+		}
+		if m.ImplementationStart == 0 || m.ImplementationEnd == 0 {
+			return 0, 0, fmt.Errorf("method %s has no implementation", methodName)
+		}
+		start, end := methodBlockRange(source, methodName, m.ImplementationStart, m.ImplementationEnd)
+		if s, e, ok := snapMethodLines(strings.Split(source, "\n"), methodName, start, end); ok {
+			start, end = s, e
+		}
+		return start, end, nil
+	}
+	return 0, 0, fmt.Errorf("method %s not found in class %s", methodName, className)
+}
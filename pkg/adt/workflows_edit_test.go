@@ -0,0 +1,114 @@
+package adt
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// editSourceMock serves one main source and object structure and records
+// the source written back.
+func editSourceMock(source, structure string) (*funcMockClient, *string) {
+	var written string
+	mock := &funcMockClient{doFunc: func(req *http.Request) (*http.Response, error) {
+		resp := newTestResponse("")
+		resp.Header.Set("X-CSRF-Token", "test-token")
+		switch {
+		case req.URL.Query().Get("_action") == "LOCK":
+			resp = newTestResponse(lockResponseXML)
+		case req.Method == http.MethodPut:
+			body, _ := io.ReadAll(req.Body)
+			written = string(body)
+		case req.Method == http.MethodGet && strings.HasSuffix(req.URL.Path, "/objectstructure"):
+			resp = newTestResponse(structure)
+		case req.Method == http.MethodGet && strings.HasSuffix(req.URL.Path, "/source/main"):
+			resp = newTestResponse(source)
+		}
+		return resp, nil
+	}}
+	return mock, &written
+}
+
+func TestClient_EditSourceRange_Lines(t *testing.T) {
+	source := "REPORT zdemo_report.\r\n\r\nWRITE 'one'.\r\nWRITE 'two'.\r\nWRITE 'three'."
+	mock, written := editSourceMock(source, "")
+	cfg := NewConfig("https://sap.example.com:44300", "user", "pass")
+	client := NewClientWithTransport(cfg, NewTransportWithClient(cfg, mock))
+
+	result, err := client.EditSourceRange(context.Background(), "PROG", "zdemo_report", SourceEdit{
+		StartLine: 3,
+		EndLine:   4,
+		NewText:   "WRITE 'replaced'.",
+	})
+	if err != nil {
+		t.Fatalf("EditSourceRange failed: %v", err)
+	}
+	if !result.Success || result.OldString != "WRITE 'one'.\nWRITE 'two'." {
+		t.Errorf("unexpected result: %+v", result)
+	}
+	want := "REPORT zdemo_report.\n\nWRITE 'replaced'.\nWRITE 'three'."
+	if *written != want {
+		t.Errorf("written source = %q, want %q", *written, want)
+	}
+
+	if _, err := client.EditSourceRange(context.Background(), "PROG", "zdemo_report", SourceEdit{StartLine: 4, EndLine: 9}); err == nil {
+		t.Error("expected an error for a range past the end of the source")
+	}
+	if _, err := client.EditSourceRange(context.Background(), "PROG", "zdemo_report", SourceEdit{Method: "RUN"}); err == nil {
+		t.Error("expected an error for a method edit outside a class")
+	}
+}
+
+func TestClient_EditSourceRange_Method(t *testing.T) {
+	source := strings.Join([]string{
+		"CLASS zcl_demo IMPLEMENTATION.",
+		"  METHOD first.",
+		"    WRITE 'first'.",
+		"  ENDMETHOD.",
+		"",
+		"  METHOD second.",
+		"    WRITE 'second'.",
+		"  ENDMETHOD.",
+		"ENDCLASS.",
+	}, "\n")
+	structure := `<?xml version="1.0" encoding="UTF-8"?>
+<abapsource:objectStructureElement xmlns:abapsource="http://www.sap.com/adt/abapsource" xmlns:adtcore="http://www.sap.com/adt/core" name="ZCL_DEMO" type="CLAS/OC">
+  <abapsource:objectStructureElement name="FIRST" type="CLAS/OM" visibility="public" level="instance">
+    <atom:link xmlns:atom="http://www.w3.org/2005/Atom" href="./source/main#start=2,0;end=5,0" rel="http://www.sap.com/adt/relations/source/implementationBlock"/>
+  </abapsource:objectStructureElement>
+  <abapsource:objectStructureElement name="SECOND" type="CLAS/OM" visibility="public" level="instance">
+    <atom:link xmlns:atom="http://www.w3.org/2005/Atom" href="./source/main#start=6,0;end=8,0" rel="http://www.sap.com/adt/relations/source/implementationBlock"/>
+  </abapsource:objectStructureElement>
+</abapsource:objectStructureElement>`
+	mock, written := editSourceMock(source, structure)
+	cfg := NewConfig("https://sap.example.com:44300", "user", "pass")
+	client := NewClientWithTransport(cfg, NewTransportWithClient(cfg, mock))
+
+	result, err := client.EditSourceRange(context.Background(), "CLAS", "zcl_demo", SourceEdit{
+		Method:  "first",
+		NewText: "  METHOD first.\n    WRITE 'changed'.\n    WRITE 'twice'.\n  ENDMETHOD.",
+	})
+	if err != nil {
+		t.Fatalf("EditSourceRange failed: %v", err)
+	}
+	if !result.Success || result.Method != "FIRST" {
+		t.Errorf("unexpected result: %+v", result)
+	}
+	want := strings.Join([]string{
+		"CLASS zcl_demo IMPLEMENTATION.",
+		"  METHOD first.",
+		"    WRITE 'changed'.",
+		"    WRITE 'twice'.",
+		"  ENDMETHOD.",
+		"",
+		"  METHOD second.",
+		"    WRITE 'second'.",
+		"  ENDMETHOD.",
+		"ENDCLASS.",
+	}, "\n")
+	if *written != want {
+		t.Errorf("written source:\n%s\nwant:\n%s", *written, want)
+	}
+}
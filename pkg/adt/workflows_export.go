@@ -0,0 +1,232 @@
+package adt
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// --- abapGit Package Export ---
+
+// ExportOptions configures ExportPackage.
+type ExportOptions struct {
+	IncludeSubpackages bool // Export subpackages into prefix-named folders
+	WriteManifest      bool // Write a .abapgit.xml repository manifest
+}
+
+// ExportIssue describes an object that was not exported.
+type ExportIssue struct {
+	Type    string `json:"type"`
+	Name    string `json:"name"`
+	Package string `json:"package"`
+	Reason  string `json:"reason"`
+}
+
+// ExportPackageResult summarizes an ExportPackage run.
+type ExportPackageResult struct {
+	Package   string         `json:"package"`
+	TargetDir string         `json:"targetDir"`
+	Files     []string       `json:"files"`
+	Counts    map[string]int `json:"counts"` // Exported objects per object type, e.g. "CLAS": 3
+	Skipped   []ExportIssue  `json:"skipped,omitempty"`
+	Failed    []ExportIssue  `json:"failed,omitempty"`
+}
+
+// abapGitManifest is the .abapgit.xml written by ExportPackage.
+const abapGitManifest = `<?xml version="1.0" encoding="utf-8"?>
+<asx:abap xmlns:asx="http://www.sap.com/abapxml" version="1.0">
+ <asx:values>
+  <DATA>
+   <MASTER_LANGUAGE>E</MASTER_LANGUAGE>
+   <STARTING_FOLDER>/src/</STARTING_FOLDER>
+   <FOLDER_LOGIC>PREFIX</FOLDER_LOGIC>
+  </DATA>
+ </asx:values>
+</asx:abap>
+`
+
+// ExportPackage writes the sources of a package to targetDir in abapGit
+// layout: objects go to src/, subpackages to folders named after the part of
+// their name following the parent's prefix (ZDEMO_UI under ZDEMO → src/ui/).
+// File names follow SaveToFile, with namespaces written as #ns#name.
+//
+// Workflow: GetPackage (GetPackageRecursive with subpackages) → SaveToFile per object
+//
+// Programs bring their includes along; includes not referenced by an
+// exported program are saved on their own. Classes are exported with all
+// their include parts. Objects without a source (tables, message classes,
+// ...) are reported as skipped, read and write errors as failed; neither
+// stops the export.
+func (c *Client) ExportPackage(ctx context.Context, packageName, targetDir string, opts ExportOptions) (*ExportPackageResult, error) {
+	packageName = strings.ToUpper(packageName)
+	if targetDir == "" {
+		targetDir = "."
+	}
+
+	var (
+		content *PackageContent
+		err     error
+	)
+	if opts.IncludeSubpackages {
+		content, err = c.GetPackageRecursive(ctx, packageName, 0)
+	} else {
+		content, err = c.GetPackage(ctx, packageName)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !opts.IncludeSubpackages {
+		content.SubPackages = nil
+	}
+
+	folders := exportFolders(packageName, content.SubPackages, filepath.Join(targetDir, "src"))
+	for _, dir := range folders {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return nil, fmt.Errorf("creating export folder: %w", err)
+		}
+	}
+
+	result := &ExportPackageResult{
+		Package:   packageName,
+		TargetDir: targetDir,
+		Files:     []string{},
+		Counts:    map[string]int{},
+	}
+
+	if opts.WriteManifest {
+		manifest := filepath.Join(targetDir, ".abapgit.xml")
+		if err := os.WriteFile(manifest, []byte(abapGitManifest), 0644); err != nil {
+			return nil, fmt.Errorf("writing manifest: %w", err)
+		}
+		result.Files = append(result.Files, manifest)
+	}
+
+	// Includes are exported with the programs that use them, so they are
+	// only saved on their own once all programs are done.
+	exportedIncludes := map[string]bool{}
+	var includes []PackageObject
+	for _, obj := range content.Objects {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+		objType, ok := objectTypeFromShort(obj.Type)
+		if ok && objType == ObjectTypeProgram && strings.HasSuffix(strings.ToUpper(obj.Type), "/I") {
+			includes = append(includes, obj)
+			continue
+		}
+		c.exportObject(ctx, obj, folders[obj.Package], result, exportedIncludes)
+	}
+	for _, obj := range includes {
+		if exportedIncludes[strings.ToUpper(obj.Name)] {
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+		c.exportObject(ctx, PackageObject{Type: string(ObjectTypeInclude), Name: obj.Name, Package: obj.Package}, folders[obj.Package], result, exportedIncludes)
+	}
+
+	return result, nil
+}
+
+// exportObject saves one package object to dir and records the outcome.
+func (c *Client) exportObject(ctx context.Context, obj PackageObject, dir string, result *ExportPackageResult, exportedIncludes map[string]bool) {
+	short := strings.ToUpper(strings.SplitN(obj.Type, "/", 2)[0])
+	issue := ExportIssue{Type: obj.Type, Name: obj.Name, Package: obj.Package}
+
+	objType, ok := objectTypeFromShort(obj.Type)
+	if obj.Type == string(ObjectTypeInclude) {
+		objType, ok, short = ObjectTypeInclude, true, "INCL"
+	}
+	switch {
+	case !ok, objType == ObjectTypeTable, objType == ObjectTypePackage, objType == ObjectTypeSRVB:
+		issue.Reason = "object type has no exportable source"
+		result.Skipped = append(result.Skipped, issue)
+		return
+	case objType == ObjectTypeClass:
+		c.exportClass(ctx, obj, dir, result)
+		return
+	}
+
+	saved, err := c.SaveToFile(ctx, objType, obj.Name, "", dir)
+	if err != nil {
+		issue.Reason = err.Error()
+		result.Failed = append(result.Failed, issue)
+		return
+	}
+	if !saved.Success {
+		issue.Reason = saved.Message
+		result.Failed = append(result.Failed, issue)
+		return
+	}
+	result.Files = append(result.Files, saved.FilePath)
+	result.Counts[short]++
+	for _, inc := range saved.Includes {
+		if inc.Success {
+			exportedIncludes[inc.ObjectName] = true
+			result.Files = append(result.Files, inc.FilePath)
+		}
+	}
+}
+
+// exportClass saves every include part of a class to dir.
+func (c *Client) exportClass(ctx context.Context, obj PackageObject, dir string, result *ExportPackageResult) {
+	sources, err := c.GetClassIncludes(ctx, obj.Name)
+	if err != nil {
+		result.Failed = append(result.Failed, ExportIssue{Type: obj.Type, Name: obj.Name, Package: obj.Package, Reason: err.Error()})
+		return
+	}
+
+	base := strings.ReplaceAll(strings.ToLower(obj.Name), "/", "#")
+	for _, part := range classIncludeParts {
+		source, ok := sources[string(part)]
+		if !ok {
+			continue
+		}
+		path := filepath.Join(dir, base+classIncludeFileExt(part))
+		if err := os.WriteFile(path, []byte(source), 0644); err != nil {
+			result.Failed = append(result.Failed, ExportIssue{Type: obj.Type, Name: obj.Name, Package: obj.Package, Reason: fmt.Sprintf("writing %s: %v", path, err)})
+			return
+		}
+		result.Files = append(result.Files, path)
+	}
+	result.Counts["CLAS"]++
+}
+
+// exportFolders maps the root package and its subpackages to folders using
+// abapGit's PREFIX folder logic. A subpackage's parent is taken to be the
+// longest other package whose name plus "_" prefixes it; packages without
+// such a parent get a folder named after their full name below the root.
+func exportFolders(root string, subPackages []string, rootDir string) map[string]string {
+	packages := append([]string{root}, subPackages...)
+	sorted := append([]string(nil), packages...)
+	sort.Slice(sorted, func(i, j int) bool { return len(sorted[i]) < len(sorted[j]) })
+
+	folderName := func(name string) string {
+		return strings.ReplaceAll(strings.ToLower(strings.TrimPrefix(name, "$")), "/", "#")
+	}
+
+	folders := map[string]string{root: rootDir}
+	for _, pkg := range sorted {
+		if pkg == root {
+			continue
+		}
+		parent := ""
+		for _, candidate := range sorted {
+			if candidate != pkg && strings.HasPrefix(pkg, candidate+"_") && len(candidate) > len(parent) {
+				if _, placed := folders[candidate]; placed {
+					parent = candidate
+				}
+			}
+		}
+		if parent == "" {
+			folders[pkg] = filepath.Join(rootDir, folderName(pkg))
+			continue
+		}
+		folders[pkg] = filepath.Join(folders[parent], folderName(pkg[len(parent)+1:]))
+	}
+	return folders
+}
@@ -0,0 +1,150 @@
+package adt
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// newExportTestClient serves package $ZDEMO with subpackage $ZDEMO_UI.
+func newExportTestClient() *Client {
+	tree := map[string]string{
+		"$ZDEMO": nodeStructureResponse(
+			[2]string{"DEVC/K", "$ZDEMO_UI"},
+			[2]string{"PROG/P", "ZDEMO_MAIN"},
+			[2]string{"PROG/I", "ZDEMO_MAIN_TOP"},
+			[2]string{"PROG/I", "ZDEMO_FORMS"},
+			[2]string{"TABL/DT", "ZDEMO_ORDERS"},
+		),
+		"$ZDEMO_UI": nodeStructureResponse(
+			[2]string{"CLAS/OC", "ZCL_DEMO_VIEW"},
+		),
+	}
+	sources := map[string]string{
+		"/programs/programs/zdemo_main/source/main":      "REPORT zdemo_main.\nINCLUDE zdemo_main_top.",
+		"/programs/includes/zdemo_main_top/source/main":  "DATA gv_count TYPE i.",
+		"/programs/includes/zdemo_forms/source/main":     "FORM main.\nENDFORM.",
+		"/oo/classes/zcl_demo_view/source/main":          "CLASS zcl_demo_view DEFINITION PUBLIC.",
+		"/oo/classes/zcl_demo_view/includes/testclasses": "CLASS ltcl_view DEFINITION FOR TESTING.",
+	}
+	mock := &funcMockClient{doFunc: func(req *http.Request) (*http.Response, error) {
+		path := strings.ToLower(req.URL.Path)
+		switch {
+		case strings.Contains(path, "discovery"):
+			r := newTestResponse("ok")
+			r.Header.Set("X-CSRF-Token", "test-token")
+			return r, nil
+		case strings.Contains(path, "/repository/nodestructure"):
+			return newTestResponse(tree[req.URL.Query().Get("parent_name")]), nil
+		}
+		for suffix, source := range sources {
+			if strings.HasSuffix(path, suffix) {
+				return newTestResponse(source), nil
+			}
+		}
+		return &http.Response{StatusCode: http.StatusNotFound, Body: http.NoBody, Header: http.Header{}}, nil
+	}}
+	cfg := NewConfig("https://sap.example.com:44300", "user", "pass")
+	return NewClientWithTransport(cfg, NewTransportWithClient(cfg, mock))
+}
+
+// exportedFiles lists the files below dir, slash-separated and sorted.
+func exportedFiles(dir string) []string {
+	var files []string
+	filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err == nil && !info.IsDir() {
+			rel, _ := filepath.Rel(dir, path)
+			files = append(files, filepath.ToSlash(rel))
+		}
+		return nil
+	})
+	sort.Strings(files)
+	return files
+}
+
+func TestClient_ExportPackage(t *testing.T) {
+	client := newExportTestClient()
+	dir := t.TempDir()
+	result, err := client.ExportPackage(context.Background(), "$zdemo", dir, ExportOptions{IncludeSubpackages: true, WriteManifest: true})
+	if err != nil {
+		t.Fatalf("ExportPackage failed: %v", err)
+	}
+
+	files := exportedFiles(dir)
+	want := []string{
+		".abapgit.xml",
+		"src/ui/zcl_demo_view.clas.abap",
+		"src/ui/zcl_demo_view.clas.testclasses.abap",
+		"src/zdemo_forms.abap",
+		"src/zdemo_main.prog.abap",
+		"src/zdemo_main.prog.zdemo_main_top.incl.abap",
+	}
+	if strings.Join(files, "\n") != strings.Join(want, "\n") {
+		t.Errorf("exported files:\n%s\nwant:\n%s", strings.Join(files, "\n"), strings.Join(want, "\n"))
+	}
+	if len(result.Files) != len(want) {
+		t.Errorf("result lists %d files, want %d: %v", len(result.Files), len(want), result.Files)
+	}
+
+	if result.Counts["PROG"] != 1 || result.Counts["CLAS"] != 1 || result.Counts["INCL"] != 1 {
+		t.Errorf("unexpected counts: %v", result.Counts)
+	}
+	if len(result.Skipped) != 1 || result.Skipped[0].Name != "ZDEMO_ORDERS" {
+		t.Errorf("expected the table to be skipped, got %+v", result.Skipped)
+	}
+	if len(result.Failed) != 0 {
+		t.Errorf("unexpected failures: %+v", result.Failed)
+	}
+}
+
+func TestClient_ExportPackage_WithoutSubpackages(t *testing.T) {
+	client := newExportTestClient()
+
+	// Relative paths would land in the working directory instead of dir.
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	before := exportedFiles(wd)
+
+	dir := t.TempDir()
+	result, err := client.ExportPackage(context.Background(), "$ZDEMO", dir, ExportOptions{})
+	if err != nil {
+		t.Fatalf("ExportPackage failed: %v", err)
+	}
+
+	files := exportedFiles(dir)
+	want := []string{
+		"src/zdemo_forms.abap",
+		"src/zdemo_main.prog.abap",
+		"src/zdemo_main.prog.zdemo_main_top.incl.abap",
+	}
+	if strings.Join(files, "\n") != strings.Join(want, "\n") {
+		t.Errorf("exported files:\n%s\nwant:\n%s", strings.Join(files, "\n"), strings.Join(want, "\n"))
+	}
+	if result.Counts["CLAS"] != 0 {
+		t.Errorf("subpackage class was exported: %v", result.Counts)
+	}
+	if after := exportedFiles(wd); len(after) != len(before) {
+		t.Errorf("export wrote %d files outside the target directory", len(after)-len(before))
+	}
+}
+
+func TestExportFolders(t *testing.T) {
+	folders := exportFolders("ZDEMO", []string{"ZDEMO_UI", "ZDEMO_UI_WIDGETS", "ZOTHER"}, "src")
+	want := map[string]string{
+		"ZDEMO":            "src",
+		"ZDEMO_UI":         filepath.Join("src", "ui"),
+		"ZDEMO_UI_WIDGETS": filepath.Join("src", "ui", "widgets"),
+		"ZOTHER":           filepath.Join("src", "zother"),
+	}
+	for pkg, dir := range want {
+		if folders[pkg] != dir {
+			t.Errorf("folder of %s = %q, want %q", pkg, folders[pkg], dir)
+		}
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strings"
 )
 
@@ -142,6 +143,10 @@ type SaveToFileResult struct {
 	LineCount  int    `json:"lineCount"`
 	Success    bool   `json:"success"`
 	Message    string `json:"message,omitempty"`
+	// ParentName is the main program of an exported include
+	ParentName string `json:"parentName,omitempty"`
+	// Includes lists the includes exported alongside a program
+	Includes []*SaveToFileResult `json:"includes,omitempty"`
 }
 
 // SaveToFile saves an ABAP object's source code to a local file.
@@ -149,6 +154,9 @@ type SaveToFileResult struct {
 // Workflow: GetSource → WriteFile
 //
 // The file extension is automatically determined based on object type.
+// For programs, every include referenced by an INCLUDE statement (recursively)
+// is exported next to the main file as {program}.prog.{include}.incl.abap,
+// so the parent program is preserved for re-import via UpdateFromFile.
 func (c *Client) SaveToFile(ctx context.Context, objType CreatableObjectType, objectName, parentName, outputPath string) (*SaveToFileResult, error) {
 	result := &SaveToFileResult{
 		ObjectName: objectName,
@@ -177,6 +185,10 @@ func (c *Client) SaveToFile(ctx context.Context, objType CreatableObjectType, ob
 		ext = ".bdef.asbdef"
 	case ObjectTypeSRVD:
 		ext = ".srvd.srvdsrv"
+	case ObjectTypeTransformation:
+		ext = ".xslt.source.xml"
+	case ObjectTypeTypeGroup:
+		ext = ".type.abap"
 	default:
 		ext = ".abap"
 	}
@@ -222,9 +234,89 @@ func (c *Client) SaveToFile(ctx context.Context, objType CreatableObjectType, ob
 
 	result.Success = true
 	result.Message = fmt.Sprintf("Saved %s %s to %s (%d lines)", objType, objectName, result.FilePath, result.LineCount)
+
+	// 5. Programs: export referenced includes with their parent context
+	if objType == ObjectTypeProgram {
+		parent := strings.ToUpper(result.ObjectName)
+		c.saveProgramIncludes(ctx, parent, source, filepath.Dir(result.FilePath), result, map[string]bool{parent: true})
+		if len(result.Includes) > 0 {
+			result.Message += fmt.Sprintf(", %d include(s)", len(result.Includes))
+		}
+	}
+
 	return result, nil
 }
 
+// saveProgramIncludes exports the includes referenced in source to dir and
+// appends them to result.Includes. Nested includes are followed; all of them
+// record the main program as their parent.
+func (c *Client) saveProgramIncludes(ctx context.Context, parent, source, dir string, result *SaveToFileResult, seen map[string]bool) {
+	for _, include := range findProgramIncludes(source) {
+		if seen[include] {
+			continue
+		}
+		seen[include] = true
+
+		incResult := &SaveToFileResult{
+			ObjectName: include,
+			ObjectType: string(ObjectTypeInclude),
+			ParentName: parent,
+			FilePath:   filepath.Join(dir, includeFileName(parent, include)),
+		}
+		result.Includes = append(result.Includes, incResult)
+
+		incSource, err := c.GetInclude(ctx, include)
+		if err != nil {
+			incResult.Message = fmt.Sprintf("Failed to read include: %v", err)
+			continue
+		}
+		incResult.LineCount = len(strings.Split(incSource, "\n"))
+
+		if err := os.WriteFile(incResult.FilePath, []byte(incSource), 0644); err != nil {
+			incResult.Message = fmt.Sprintf("Failed to write file: %v", err)
+			continue
+		}
+		incResult.Success = true
+		incResult.Message = fmt.Sprintf("Saved include %s of %s to %s (%d lines)", include, parent, incResult.FilePath, incResult.LineCount)
+
+		c.saveProgramIncludes(ctx, parent, incSource, dir, result, seen)
+	}
+}
+
+// includeFileName returns the export file name of a program include.
+// Pattern: {program}.prog.{include}.incl.abap (namespace slashes become #).
+func includeFileName(parent, include string) string {
+	safe := func(name string) string {
+		return strings.ReplaceAll(strings.ToLower(name), "/", "#")
+	}
+	return safe(parent) + ".prog." + safe(include) + ".incl.abap"
+}
+
+// programIncludeRegex matches INCLUDE statements that pull in program includes.
+// INCLUDE TYPE / INCLUDE STRUCTURE (structure includes) are excluded by the caller.
+var programIncludeRegex = regexp.MustCompile(`(?i)^\s*INCLUDE\s+([A-Z0-9_/]+)(\s+IF\s+FOUND)?\s*\.`)
+
+// findProgramIncludes returns the names of the includes referenced by
+// INCLUDE statements in source, in order of appearance.
+func findProgramIncludes(source string) []string {
+	var includes []string
+	for _, line := range strings.Split(source, "\n") {
+		if strings.HasPrefix(line, "*") || strings.HasPrefix(strings.TrimSpace(line), "\"") {
+			continue
+		}
+		m := programIncludeRegex.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		name := strings.ToUpper(m[1])
+		if name == "TYPE" || name == "STRUCTURE" {
+			continue
+		}
+		includes = append(includes, name)
+	}
+	return includes
+}
+
 // SaveClassIncludeToFile saves a class include's source code to a local file.
 //
 // Workflow: GetClassInclude → WriteFile
@@ -242,21 +334,7 @@ func (c *Client) SaveClassIncludeToFile(ctx context.Context, className string, i
 	}
 
 	// 1. Determine file extension based on include type
-	var ext string
-	switch includeType {
-	case ClassIncludeTestClasses:
-		ext = ".clas.testclasses.abap"
-	case ClassIncludeDefinitions:
-		ext = ".clas.locals_def.abap"
-	case ClassIncludeImplementations:
-		ext = ".clas.locals_imp.abap"
-	case ClassIncludeMacros:
-		ext = ".clas.macros.abap"
-	case ClassIncludeMain, "":
-		ext = ".clas.abap"
-	default:
-		ext = ".clas.abap"
-	}
+	ext := classIncludeFileExt(includeType)
 
 	// 2. Build file path
 	if outputPath == "" {
@@ -292,3 +370,19 @@ func (c *Client) SaveClassIncludeToFile(ctx context.Context, className string, i
 	result.Message = fmt.Sprintf("Saved %s %s.%s to %s (%d lines)", "CLAS", className, includeType, result.FilePath, result.LineCount)
 	return result, nil
 }
+
+// classIncludeFileExt returns the abapGit file extension of a class include.
+func classIncludeFileExt(includeType ClassIncludeType) string {
+	switch includeType {
+	case ClassIncludeTestClasses:
+		return ".clas.testclasses.abap"
+	case ClassIncludeDefinitions:
+		return ".clas.locals_def.abap"
+	case ClassIncludeImplementations:
+		return ".clas.locals_imp.abap"
+	case ClassIncludeMacros:
+		return ".clas.macros.abap"
+	default:
+		return ".clas.abap"
+	}
+}
@@ -0,0 +1,274 @@
+package adt
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// --- abapGit Package Import ---
+
+// ImportOptions configures ImportPackage.
+type ImportOptions struct {
+	Transport         string // Transport request for created and changed objects
+	OverwriteExisting bool   // Replace the source of objects that already exist
+	Activate          bool   // Activate all written objects in one run at the end
+}
+
+// ImportFileResult is the outcome of importing one file.
+type ImportFileResult struct {
+	FilePath   string `json:"filePath"`
+	ObjectType string `json:"objectType,omitempty"`
+	ObjectName string `json:"objectName,omitempty"`
+	Success    bool   `json:"success"`
+	Created    bool   `json:"created,omitempty"`
+	Skipped    bool   `json:"skipped,omitempty"`
+	Message    string `json:"message,omitempty"`
+}
+
+// ImportPackageResult summarizes an ImportPackage run.
+type ImportPackageResult struct {
+	Package    string             `json:"package"`
+	Files      []ImportFileResult `json:"files"`
+	Created    int                `json:"created"`
+	Updated    int                `json:"updated"`
+	Skipped    int                `json:"skipped"`
+	Failed     int                `json:"failed"`
+	Activation *ActivationResult  `json:"activation,omitempty"`
+	Message    string             `json:"message,omitempty"`
+}
+
+// importFileSuffixes lists the file endings ImportPackage picks up.
+var importFileSuffixes = []string{".abap", ".asddls", ".asbdef", ".srvdsrv", ".xslt.source.xml"}
+
+// importRank orders imported objects so that what others build on comes
+// first: DDIC sources, then interfaces and classes, then function groups and
+// programs. Class includes follow their class, program includes their program.
+func importRank(info *ABAPFileInfo) int {
+	switch info.ObjectType {
+	case ObjectTypeDDLS, ObjectTypeTypeGroup:
+		return 0
+	case ObjectTypeBDEF:
+		return 1
+	case ObjectTypeSRVD:
+		return 2
+	case ObjectTypeInterface:
+		return 3
+	case ObjectTypeClass:
+		if info.ClassIncludeType != "" && info.ClassIncludeType != ClassIncludeMain {
+			return 5
+		}
+		return 4
+	case ObjectTypeFunctionGroup:
+		return 6
+	case ObjectTypeFunctionMod:
+		return 7
+	case ObjectTypeProgram:
+		return 8
+	case ObjectTypeInclude:
+		return 9
+	default:
+		return 10
+	}
+}
+
+// ImportPackage creates or updates the objects stored below sourceDir (in
+// any folder layout, e.g. one written by ExportPackage) in targetPackage.
+//
+// Workflow: Parse all → Sort → (Create if missing → Lock → Write → Unlock) per file → ActivateObjects
+//
+// Objects are written inactive without a syntax check, since they may
+// depend on each other; with opts.Activate they are activated together at
+// the end, DDL sources in dependency order. Existing objects are left alone
+// unless opts.OverwriteExisting is set. Failures are recorded per file and
+// do not stop the import.
+func (c *Client) ImportPackage(ctx context.Context, sourceDir, targetPackage string, opts ImportOptions) (*ImportPackageResult, error) {
+	targetPackage = strings.ToUpper(targetPackage)
+
+	// Unified mutation policy gate (op type + package + transport)
+	if err := c.checkMutation(ctx, MutationContext{
+		Op:        OpWorkflow,
+		OpName:    "ImportPackage",
+		Package:   targetPackage,
+		Transport: opts.Transport,
+	}); err != nil {
+		return nil, err
+	}
+
+	result := &ImportPackageResult{Package: targetPackage, Files: []ImportFileResult{}}
+
+	type parsedFile struct {
+		info   *ABAPFileInfo
+		result int // index into result.Files
+	}
+	var files []parsedFile
+	err := filepath.WalkDir(sourceDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !hasImportSuffix(d.Name()) {
+			return nil
+		}
+		result.Files = append(result.Files, ImportFileResult{FilePath: path})
+		info, err := ParseABAPFile(path)
+		if err != nil {
+			result.Files[len(result.Files)-1].Message = err.Error()
+			result.Failed++
+			return nil
+		}
+		files = append(files, parsedFile{info: info, result: len(result.Files) - 1})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", sourceDir, err)
+	}
+
+	sort.SliceStable(files, func(i, j int) bool {
+		return importRank(files[i].info) < importRank(files[j].info)
+	})
+
+	var refs []ActivationRef
+	activating := map[string]bool{}
+	created := map[string]bool{} // object URLs created by this import
+	for _, f := range files {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+		fr := &result.Files[f.result]
+		objectURL := c.importFile(ctx, f.info, targetPackage, opts, created, fr)
+		switch {
+		case fr.Skipped:
+			result.Skipped++
+		case !fr.Success:
+			result.Failed++
+		case fr.Created:
+			result.Created++
+		default:
+			result.Updated++
+		}
+		if fr.Success && !fr.Skipped && !activating[objectURL] {
+			activating[objectURL] = true
+			refs = append(refs, ActivationRef{URI: objectURL, Name: f.info.ObjectName})
+		}
+	}
+
+	result.Message = fmt.Sprintf("Imported %d file(s) into %s: %d created, %d updated, %d skipped, %d failed",
+		len(result.Files), targetPackage, result.Created, result.Updated, result.Skipped, result.Failed)
+
+	if opts.Activate && len(refs) > 0 {
+		activation, err := c.ActivateObjectsWithOptions(ctx, refs, &ActivateObjectsOptions{SortByDependencies: true})
+		result.Activation = activation
+		if err != nil {
+			result.Message += fmt.Sprintf("; activation failed: %v", err)
+		} else {
+			result.Message += fmt.Sprintf("; activated %d object(s)", len(refs))
+		}
+	}
+
+	return result, nil
+}
+
+// importFile writes one parsed file and fills fr. It returns the URL of the
+// object the file belongs to (the class for class includes).
+func (c *Client) importFile(ctx context.Context, info *ABAPFileInfo, targetPackage string, opts ImportOptions, created map[string]bool, fr *ImportFileResult) string {
+	fr.ObjectName = info.ObjectName
+	fr.ObjectType = string(info.ObjectType)
+	isClassInclude := info.ObjectType == ObjectTypeClass &&
+		info.ClassIncludeType != "" &&
+		info.ClassIncludeType != ClassIncludeMain
+	if isClassInclude {
+		fr.ObjectType = fmt.Sprintf("%s.%s", info.ObjectType, info.ClassIncludeType)
+	}
+
+	objectURL, err := c.buildObjectURLWithParent(info.ObjectType, info.ObjectName, info.ParentName)
+	if err != nil {
+		fr.Message = err.Error()
+		return ""
+	}
+
+	sourceBytes, err := os.ReadFile(fr.FilePath)
+	if err != nil {
+		fr.Message = fmt.Sprintf("reading file: %v", err)
+		return objectURL
+	}
+
+	exists, err := c.objectExistsByURL(ctx, objectURL)
+	if err != nil {
+		fr.Message = fmt.Sprintf("checking existence: %v", err)
+		return objectURL
+	}
+	switch {
+	case isClassInclude && !exists:
+		fr.Message = fmt.Sprintf("parent class %s does not exist", info.ObjectName)
+		return objectURL
+	case exists && !created[objectURL] && !opts.OverwriteExisting:
+		fr.Skipped = true
+		fr.Success = true
+		fr.Message = fmt.Sprintf("%s %s already exists", info.ObjectType, info.ObjectName)
+		return objectURL
+	case !exists:
+		err := c.CreateObject(ctx, CreateObjectOptions{
+			ObjectType:  info.ObjectType,
+			Name:        info.ObjectName,
+			ParentName:  info.ParentName,
+			Description: info.Description,
+			PackageName: targetPackage,
+			Transport:   opts.Transport,
+		})
+		if err != nil {
+			fr.Message = fmt.Sprintf("create failed: %v", err)
+			return objectURL
+		}
+		created[objectURL] = true
+		fr.Created = true
+	}
+
+	lock, err := c.LockObject(ctx, objectURL, "MODIFY")
+	if err != nil {
+		fr.Message = fmt.Sprintf("lock failed: %v", err)
+		return objectURL
+	}
+
+	source := string(sourceBytes)
+	if isClassInclude {
+		err = c.UpdateClassInclude(ctx, info.ObjectName, info.ClassIncludeType, source, lock.LockHandle, opts.Transport)
+		if err != nil && info.ClassIncludeType == ClassIncludeTestClasses {
+			if createErr := c.CreateTestInclude(ctx, info.ObjectName, lock.LockHandle, opts.Transport); createErr == nil {
+				err = c.UpdateClassInclude(ctx, info.ObjectName, info.ClassIncludeType, source, lock.LockHandle, opts.Transport)
+			}
+		}
+	} else {
+		err = c.UpdateSource(ctx, objectURL+"/source/main", source, lock.LockHandle, opts.Transport)
+	}
+	unlockErr := c.UnlockObject(ctx, objectURL, lock.LockHandle)
+	if err != nil {
+		fr.Message = fmt.Sprintf("write source failed: %v", err)
+		return objectURL
+	}
+	if unlockErr != nil {
+		fr.Message = fmt.Sprintf("source written but unlock failed: %v", unlockErr)
+		return objectURL
+	}
+
+	fr.Success = true
+	if fr.Created {
+		fr.Message = fmt.Sprintf("Created %s %s", fr.ObjectType, info.ObjectName)
+	} else {
+		fr.Message = fmt.Sprintf("Updated %s %s", fr.ObjectType, info.ObjectName)
+	}
+	return objectURL
+}
+
+func hasImportSuffix(name string) bool {
+	lower := strings.ToLower(name)
+	for _, suffix := range importFileSuffixes {
+		if strings.HasSuffix(lower, suffix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,117 @@
+package adt
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestClient_ImportPackage(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"src/zcl_demo_order.clas.abap":             "CLASS zcl_demo_order DEFINITION PUBLIC.\nENDCLASS.",
+		"src/zcl_demo_order.clas.testclasses.abap": "CLASS ltcl_order DEFINITION FOR TESTING.\nENDCLASS.",
+		"src/ui/zcl_demo_view.clas.abap":           "CLASS zcl_demo_view DEFINITION PUBLIC.\nENDCLASS.",
+		"src/ui/zcl_demo_legacy.clas.abap":         "CLASS zcl_demo_legacy DEFINITION PUBLIC.\nENDCLASS.",
+		"src/zdemo_order.devc.xml":                 "<asx:abap/>",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	existing := map[string]bool{"/sap/bc/adt/oo/classes/zcl_demo_legacy": true}
+	var seq []string
+	mock := &funcMockClient{doFunc: func(req *http.Request) (*http.Response, error) {
+		path := req.URL.Path
+		switch {
+		case strings.Contains(path, "discovery"):
+			r := newTestResponse("ok")
+			r.Header.Set("X-CSRF-Token", "test-token")
+			return r, nil
+		case strings.Contains(path, "/repository/nodestructure"):
+			return newTestResponse(nodeStructureResponse()), nil
+		}
+
+		entry := req.Method + " " + path
+		if action := req.URL.Query().Get("_action"); action != "" {
+			entry += " " + action
+		}
+		switch {
+		case req.Method == http.MethodGet:
+			if !existing[strings.ToLower(path)] {
+				return &http.Response{StatusCode: http.StatusNotFound, Body: http.NoBody, Header: http.Header{}}, nil
+			}
+			return newTestResponse(""), nil
+		case req.Method == http.MethodPost && path == "/sap/bc/adt/oo/classes":
+			body, _ := io.ReadAll(req.Body)
+			for _, name := range []string{"ZCL_DEMO_ORDER", "ZCL_DEMO_VIEW"} {
+				if strings.Contains(string(body), `adtcore:name="`+name+`"`) {
+					existing["/sap/bc/adt/oo/classes/"+strings.ToLower(name)] = true
+					entry += " " + name
+				}
+			}
+		case strings.Contains(path, "/activation"):
+			body, _ := io.ReadAll(req.Body)
+			entry += fmt.Sprintf(" %d", strings.Count(string(body), "<adtcore:objectReference "))
+		}
+		seq = append(seq, entry)
+		if req.URL.Query().Get("_action") == "LOCK" {
+			return newTestResponse(lockResponseXML), nil
+		}
+		return newTestResponse(""), nil
+	}}
+	cfg := NewConfig("https://sap.example.com:44300", "user", "pass")
+	client := NewClientWithTransport(cfg, NewTransportWithClient(cfg, mock))
+
+	result, err := client.ImportPackage(context.Background(), dir, "$zdemo", ImportOptions{Activate: true})
+	if err != nil {
+		t.Fatalf("ImportPackage failed: %v", err)
+	}
+	if result.Created != 2 || result.Updated != 1 || result.Skipped != 1 || result.Failed != 0 {
+		t.Fatalf("unexpected summary: %s\n%+v", result.Message, result.Files)
+	}
+	if result.Activation == nil {
+		t.Fatalf("expected an activation result: %s", result.Message)
+	}
+
+	var creates, activations []string
+	for _, entry := range seq {
+		if strings.HasPrefix(entry, "POST /sap/bc/adt/oo/classes ") {
+			creates = append(creates, strings.TrimPrefix(entry, "POST /sap/bc/adt/oo/classes "))
+		}
+		if strings.Contains(entry, "/activation") {
+			activations = append(activations, entry)
+		}
+	}
+	if strings.Join(creates, ",") != "ZCL_DEMO_VIEW,ZCL_DEMO_ORDER" {
+		t.Errorf("expected both new classes to be created in walk order, got %v", creates)
+	}
+	if len(activations) != 1 || !strings.HasSuffix(activations[0], " 2") {
+		t.Errorf("expected one activation of both classes, got %v", activations)
+	}
+
+	// The test include is written after its class was created.
+	created, include := -1, -1
+	for i, entry := range seq {
+		if entry == "POST /sap/bc/adt/oo/classes ZCL_DEMO_ORDER" {
+			created = i
+		}
+		if strings.HasPrefix(entry, "PUT ") && strings.HasSuffix(entry, "/includes/testclasses") {
+			include = i
+		}
+	}
+	if created < 0 || include < created {
+		t.Errorf("test include must be written after its class is created:\n%s", strings.Join(seq, "\n"))
+	}
+}
@@ -41,6 +41,8 @@ type GetSourceOptions struct {
 //   - SRVD: Service Definitions (name = SRVD name) - RAP service exposure
 //   - SRVB: Service Bindings (name = SRVB name) - RAP protocol binding (returns JSON metadata)
 //   - MSAG: Message classes (name = message class name) - returns JSON with all messages
+//   - XSLT: Transformations (name = transformation name) - XSLT or Simple Transformation source
+//   - TYPE: Type groups (name = type group name) - TYPE-POOL source
 func (c *Client) GetSource(ctx context.Context, objectType, name string, opts *GetSourceOptions) (string, error) {
 	// Safety check for read operations
 	if err := c.checkSafety(OpRead, "GetSource"); err != nil {
@@ -106,6 +108,12 @@ func (c *Client) GetSource(ctx context.Context, objectType, name string, opts *G
 	case "SRVD":
 		return c.GetSRVD(ctx, name)
 
+	case "XSLT":
+		return c.GetTransformation(ctx, name)
+
+	case "TYPE":
+		return c.GetTypeGroup(ctx, name)
+
 	case "SRVB":
 		// GetSRVB returns metadata structure, serialize to JSON
 		sb, err := c.GetSRVB(ctx, name)
@@ -132,7 +140,7 @@ func (c *Client) GetSource(ctx context.Context, objectType, name string, opts *G
 		return string(data), nil
 
 	default:
-		return "", fmt.Errorf("unsupported object type: %s (supported: PROG, CLAS, INTF, FUNC, FUGR, INCL, DDLS, VIEW, BDEF, SRVD, SRVB, MSAG)", objectType)
+		return "", fmt.Errorf("unsupported object type: %s (supported: PROG, CLAS, INTF, FUNC, FUGR, INCL, DDLS, VIEW, BDEF, SRVD, SRVB, MSAG, XSLT, TYPE)", objectType)
 	}
 }
 
@@ -960,11 +968,14 @@ func (c *Client) writeClassMethodUpdate(ctx context.Context, className, methodNa
 		return result, nil
 	}
 
+	// Snap the reported range to the actual METHOD/ENDMETHOD statements
+	implStart, implEnd := methodBlockRange(currentSource, methodName, foundMethod.ImplementationStart, foundMethod.ImplementationEnd)
+
 	// Reconstruct source with new method implementation
 	var newSourceLines []string
-	newSourceLines = append(newSourceLines, sourceLines[:foundMethod.ImplementationStart-1]...)
+	newSourceLines = append(newSourceLines, sourceLines[:implStart-1]...)
 	newSourceLines = append(newSourceLines, strings.Split(methodSource, "\n")...)
-	newSourceLines = append(newSourceLines, sourceLines[foundMethod.ImplementationEnd:]...)
+	newSourceLines = append(newSourceLines, sourceLines[implEnd:]...)
 	newSource := strings.Join(newSourceLines, "\n")
 
 	// Syntax check
@@ -1069,10 +1080,7 @@ func (c *Client) CompareSource(ctx context.Context, type1, name1, type2, name2 s
 	}
 
 	// Generate unified diff
-	lines1 := strings.Split(source1, "\n")
-	lines2 := strings.Split(source2, "\n")
-
-	diff := generateUnifiedDiff(result.Object1, result.Object2, lines1, lines2)
+	diff := generateUnifiedDiff(result.Object1, result.Object2, source1, source2)
 	result.Diff = diff
 
 	// Count added/removed lines
@@ -1087,125 +1095,15 @@ func (c *Client) CompareSource(ctx context.Context, type1, name1, type2, name2 s
 	return result, nil
 }
 
-// generateUnifiedDiff creates a unified diff between two sets of lines.
-func generateUnifiedDiff(name1, name2 string, lines1, lines2 []string) string {
+// generateUnifiedDiff formats the DiffSources hunks between two sources
+// as a unified diff with the given file labels.
+func generateUnifiedDiff(name1, name2, source1, source2 string) string {
 	var diff strings.Builder
-
 	diff.WriteString(fmt.Sprintf("--- %s\n", name1))
 	diff.WriteString(fmt.Sprintf("+++ %s\n", name2))
-
-	// Simple LCS-based diff algorithm
-	m, n := len(lines1), len(lines2)
-
-	// Build LCS table
-	lcs := make([][]int, m+1)
-	for i := range lcs {
-		lcs[i] = make([]int, n+1)
-	}
-	for i := 1; i <= m; i++ {
-		for j := 1; j <= n; j++ {
-			if lines1[i-1] == lines2[j-1] {
-				lcs[i][j] = lcs[i-1][j-1] + 1
-			} else if lcs[i-1][j] > lcs[i][j-1] {
-				lcs[i][j] = lcs[i-1][j]
-			} else {
-				lcs[i][j] = lcs[i][j-1]
-			}
-		}
-	}
-
-	// Backtrack to generate diff
-	type diffLine struct {
-		op   byte // ' ', '+', '-'
-		text string
-	}
-	var diffLines []diffLine
-
-	i, j := m, n
-	for i > 0 || j > 0 {
-		if i > 0 && j > 0 && lines1[i-1] == lines2[j-1] {
-			diffLines = append([]diffLine{{' ', lines1[i-1]}}, diffLines...)
-			i--
-			j--
-		} else if j > 0 && (i == 0 || lcs[i][j-1] >= lcs[i-1][j]) {
-			diffLines = append([]diffLine{{'+', lines2[j-1]}}, diffLines...)
-			j--
-		} else {
-			diffLines = append([]diffLine{{'-', lines1[i-1]}}, diffLines...)
-			i--
-		}
+	for _, h := range DiffSources(source1, source2) {
+		diff.WriteString(h.String())
 	}
-
-	// Output hunks with context
-	const contextLines = 3
-	inHunk := false
-	hunkStart1, hunkStart2 := 0, 0
-	hunkLen1, hunkLen2 := 0, 0
-	var hunkContent strings.Builder
-	contextBefore := make([]diffLine, 0, contextLines)
-	pendingContext := 0
-
-	flushHunk := func() {
-		if hunkLen1 > 0 || hunkLen2 > 0 {
-			diff.WriteString(fmt.Sprintf("@@ -%d,%d +%d,%d @@\n", hunkStart1, hunkLen1, hunkStart2, hunkLen2))
-			diff.WriteString(hunkContent.String())
-		}
-		hunkContent.Reset()
-		inHunk = false
-		hunkLen1, hunkLen2 = 0, 0
-	}
-
-	line1, line2 := 1, 1
-	for _, dl := range diffLines {
-		if dl.op == ' ' {
-			if inHunk {
-				pendingContext++
-				hunkContent.WriteString(fmt.Sprintf(" %s\n", dl.text))
-				hunkLen1++
-				hunkLen2++
-				if pendingContext >= contextLines*2 {
-					// Too much context, close hunk
-					flushHunk()
-					contextBefore = contextBefore[:0]
-				}
-			} else {
-				// Accumulate context before a hunk
-				if len(contextBefore) >= contextLines {
-					contextBefore = contextBefore[1:]
-				}
-				contextBefore = append(contextBefore, dl)
-			}
-			line1++
-			line2++
-		} else {
-			pendingContext = 0
-			if !inHunk {
-				// Start new hunk
-				inHunk = true
-				hunkStart1 = line1 - len(contextBefore)
-				hunkStart2 = line2 - len(contextBefore)
-				if hunkStart1 < 1 { hunkStart1 = 1 }
-				if hunkStart2 < 1 { hunkStart2 = 1 }
-				// Add context before
-				for _, ctx := range contextBefore {
-					hunkContent.WriteString(fmt.Sprintf(" %s\n", ctx.text))
-					hunkLen1++
-					hunkLen2++
-				}
-				contextBefore = contextBefore[:0]
-			}
-			hunkContent.WriteString(fmt.Sprintf("%c%s\n", dl.op, dl.text))
-			if dl.op == '-' {
-				hunkLen1++
-				line1++
-			} else {
-				hunkLen2++
-				line2++
-			}
-		}
-	}
-	flushHunk()
-
 	return diff.String()
 }
 
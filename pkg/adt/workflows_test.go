@@ -2,8 +2,10 @@ package adt
 
 import (
 	"context"
+	"errors"
 	"io"
 	"net/http"
+	"path/filepath"
 	"strings"
 	"testing"
 )
@@ -552,3 +554,213 @@ func TestReplaceMatches_LineEndings(t *testing.T) {
 		t.Errorf("replaceMatches result = %q, want %q", result, expected)
 	}
 }
+
+func TestClient_SaveToFile_ProgramWithInclude(t *testing.T) {
+	reportSource := `REPORT zdemo_report.
+* INCLUDE zdemo_commented.
+INCLUDE zdemo_report_top.
+INCLUDE STRUCTURE zdemo_struc.
+START-OF-SELECTION.
+  PERFORM main.`
+	includeSource := `DATA gv_count TYPE i.`
+
+	mock := &mockWorkflowTransport{
+		responses: map[string]*http.Response{
+			"/sap/bc/adt/programs/programs/zdemo_report/source/main":     newWorkflowTestResponse(reportSource),
+			"/sap/bc/adt/programs/includes/ZDEMO_REPORT_TOP/source/main": newWorkflowTestResponse(includeSource),
+		},
+	}
+
+	cfg := NewConfig("https://sap.example.com:44300", "user", "pass")
+	client := NewClientWithTransport(cfg, NewTransportWithClient(cfg, mock))
+
+	dir := t.TempDir()
+	result, err := client.SaveToFile(context.Background(), ObjectTypeProgram, "ZDEMO_REPORT", "", dir)
+	if err != nil {
+		t.Fatalf("SaveToFile failed: %v", err)
+	}
+	if !result.Success {
+		t.Fatalf("SaveToFile not successful: %s", result.Message)
+	}
+
+	files, _ := filepath.Glob(filepath.Join(dir, "*"))
+	if len(files) != 2 {
+		t.Fatalf("expected 2 files, got %v", files)
+	}
+
+	if len(result.Includes) != 1 {
+		t.Fatalf("expected 1 include, got %d", len(result.Includes))
+	}
+	inc := result.Includes[0]
+	if !inc.Success || inc.ParentName != "ZDEMO_REPORT" {
+		t.Fatalf("unexpected include result: %+v", inc)
+	}
+	if filepath.Base(inc.FilePath) != "zdemo_report.prog.zdemo_report_top.incl.abap" {
+		t.Errorf("include file = %s", inc.FilePath)
+	}
+
+	info, err := ParseABAPFile(inc.FilePath)
+	if err != nil {
+		t.Fatalf("ParseABAPFile failed: %v", err)
+	}
+	if info.ObjectType != ObjectTypeInclude || info.ObjectName != "ZDEMO_REPORT_TOP" || info.ParentName != "ZDEMO_REPORT" {
+		t.Errorf("unexpected parsed include: %+v", info)
+	}
+}
+
+func TestFindProgramIncludes(t *testing.T) {
+	source := `REPORT zdemo.
+INCLUDE zdemo_top.
+  include zdemo_f01 IF FOUND.
+" INCLUDE zdemo_comment.
+INCLUDE TYPE zdemo_s.
+INCLUDE <icon>.`
+
+	got := findProgramIncludes(source)
+	want := []string{"ZDEMO_TOP", "ZDEMO_F01"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("findProgramIncludes = %v, want %v", got, want)
+	}
+}
+
+// lifecycleMock records "METHOD path [_action]" for every non-discovery request.
+func lifecycleMock(lockStatus int, lockBody string) (*funcMockClient, *[]string) {
+	var seq []string
+	mock := &funcMockClient{
+		doFunc: func(req *http.Request) (*http.Response, error) {
+			if strings.Contains(req.URL.Path, "discovery") {
+				resp := newTestResponse("OK")
+				resp.Header.Set("X-CSRF-Token", "test-token")
+				return resp, nil
+			}
+			entry := req.Method + " " + req.URL.EscapedPath()
+			action := req.URL.Query().Get("_action")
+			if action != "" {
+				entry += " " + action
+			}
+			seq = append(seq, entry)
+			if action == "LOCK" {
+				return &http.Response{StatusCode: lockStatus, Body: io.NopCloser(strings.NewReader(lockBody)), Header: http.Header{}}, nil
+			}
+			return newTestResponse(""), nil
+		},
+	}
+	return mock, &seq
+}
+
+func TestClient_WriteClassSource_LockPutUnlock(t *testing.T) {
+	mock, seq := lifecycleMock(http.StatusOK, lockResponseXML)
+	cfg := NewConfig("https://sap.example.com:44300", "user", "pass")
+	client := NewClientWithTransport(cfg, NewTransportWithClient(cfg, mock))
+
+	if err := client.WriteClassSource(context.Background(), "/dmo/cl_demo", "CLASS /dmo/cl_demo DEFINITION."); err != nil {
+		t.Fatalf("WriteClassSource failed: %v", err)
+	}
+
+	const objectURL = "/sap/bc/adt/oo/classes/%2FDMO%2FCL_DEMO"
+	want := []string{
+		"POST " + objectURL + " LOCK",
+		"PUT " + objectURL + "/source/main",
+		"POST " + objectURL + " UNLOCK",
+	}
+	if strings.Join(*seq, "\n") != strings.Join(want, "\n") {
+		t.Errorf("request sequence:\n%s\nwant:\n%s", strings.Join(*seq, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestClient_WriteProgramSource_LockedByOtherUser(t *testing.T) {
+	mock, seq := lifecycleMock(http.StatusForbidden, "User TESTUSER is currently editing ZDEMO_REPORT")
+	cfg := NewConfig("https://sap.example.com:44300", "user", "pass")
+	client := NewClientWithTransport(cfg, NewTransportWithClient(cfg, mock))
+
+	err := client.WriteProgramSource(context.Background(), "zdemo_report", "REPORT zdemo_report.")
+	var lockErr *ObjectLockedError
+	if !errors.As(err, &lockErr) {
+		t.Fatalf("expected *ObjectLockedError, got %v", err)
+	}
+	if lockErr.LockedBy != "TESTUSER" {
+		t.Errorf("LockedBy = %q, want TESTUSER", lockErr.LockedBy)
+	}
+	for _, entry := range *seq {
+		if strings.HasPrefix(entry, "PUT ") {
+			t.Errorf("source must not be written without a lock: %s", entry)
+		}
+	}
+}
+
+func TestClient_WriteClassInclude_LocksClass(t *testing.T) {
+	mock, seq := lifecycleMock(http.StatusOK, lockResponseXML)
+	cfg := NewConfig("https://sap.example.com:44300", "user", "pass")
+	client := NewClientWithTransport(cfg, NewTransportWithClient(cfg, mock))
+
+	if err := client.WriteClassInclude(context.Background(), "/dmo/cl_demo", "TestClasses", "CLASS ltcl_demo DEFINITION FOR TESTING."); err != nil {
+		t.Fatalf("WriteClassInclude failed: %v", err)
+	}
+
+	const objectURL = "/sap/bc/adt/oo/classes/%2FDMO%2FCL_DEMO"
+	want := []string{
+		"POST " + objectURL + " LOCK",
+		"PUT " + objectURL + "/includes/testclasses",
+		"POST " + objectURL + " UNLOCK",
+	}
+	if strings.Join(*seq, "\n") != strings.Join(want, "\n") {
+		t.Errorf("request sequence:\n%s\nwant:\n%s", strings.Join(*seq, "\n"), strings.Join(want, "\n"))
+	}
+
+	*seq = nil
+	if err := client.WriteClassInclude(context.Background(), "/dmo/cl_demo", "locals", "*"); err == nil {
+		t.Error("expected an unknown include part to be rejected")
+	}
+	if len(*seq) != 0 {
+		t.Errorf("no request expected for an unknown part, got %v", *seq)
+	}
+}
+
+func TestClient_WriteClassSource_RecoversExpiredCSRFToken(t *testing.T) {
+	var seq []string
+	rejected := false
+	mock := &funcMockClient{doFunc: func(req *http.Request) (*http.Response, error) {
+		if strings.Contains(req.URL.Path, "discovery") {
+			seq = append(seq, "FETCH TOKEN")
+			r := newTestResponse("OK")
+			r.Header.Set("X-CSRF-Token", "test-token")
+			return r, nil
+		}
+		entry := req.Method + " " + req.URL.EscapedPath()
+		if action := req.URL.Query().Get("_action"); action != "" {
+			entry += " " + action
+		}
+		seq = append(seq, entry)
+		if req.Method == http.MethodPut && !rejected {
+			rejected = true
+			return &http.Response{
+				StatusCode: http.StatusForbidden,
+				Body:       io.NopCloser(strings.NewReader("CSRF token validation failed")),
+				Header:     http.Header{"X-Csrf-Token": []string{"Required"}},
+			}, nil
+		}
+		if req.URL.Query().Get("_action") == "LOCK" {
+			return newTestResponse(lockResponseXML), nil
+		}
+		return newTestResponse(""), nil
+	}}
+	cfg := NewConfig("https://sap.example.com:44300", "user", "pass")
+	client := NewClientWithTransport(cfg, NewTransportWithClient(cfg, mock))
+
+	if err := client.WriteClassSource(context.Background(), "zcl_demo_order", "CLASS zcl_demo_order DEFINITION PUBLIC."); err != nil {
+		t.Fatalf("WriteClassSource failed: %v", err)
+	}
+
+	const objectURL = "/sap/bc/adt/oo/classes/ZCL_DEMO_ORDER"
+	want := []string{
+		"FETCH TOKEN",
+		"POST " + objectURL + " LOCK",
+		"PUT " + objectURL + "/source/main",
+		"FETCH TOKEN",
+		"PUT " + objectURL + "/source/main",
+		"POST " + objectURL + " UNLOCK",
+	}
+	if strings.Join(seq, "\n") != strings.Join(want, "\n") {
+		t.Errorf("request sequence:\n%s\nwant:\n%s", strings.Join(seq, "\n"), strings.Join(want, "\n"))
+	}
+}
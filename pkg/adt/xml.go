@@ -126,6 +126,7 @@ type PackageObject struct {
 	Name        string `json:"name"`
 	URI         string `json:"uri,omitempty"`
 	Description string `json:"description,omitempty"`
+	Package     string `json:"package,omitempty"` // Package the object was listed in
 }
 
 // FunctionGroup represents a function group structure.
@@ -2,6 +2,7 @@ package dsl
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -57,6 +58,46 @@ type BatchExportResult struct {
 	Results      []ExportResult `json:"results"`
 }
 
+// ExportManifestEntry is one exported file as listed in an export manifest.
+type ExportManifestEntry struct {
+	ObjectType  string `json:"objectType"`
+	ObjectName  string `json:"objectName"`
+	IncludeType string `json:"includeType,omitempty"`
+	File        string `json:"file"`
+	LineCount   int    `json:"lineCount"`
+}
+
+// Manifest renders the successfully exported files as indented JSON, sorted
+// by type, name and include. The output depends only on what was exported,
+// never on the order in which objects were fetched, so manifests of two
+// exports of the same objects are byte-identical and diff cleanly in VCS.
+func (r *BatchExportResult) Manifest() ([]byte, error) {
+	results := make([]ExportResult, 0, len(r.Results))
+	for _, res := range r.Results {
+		if res.Success {
+			results = append(results, res)
+		}
+	}
+	sortExportResults(results)
+
+	entries := make([]ExportManifestEntry, 0, len(results))
+	for _, res := range results {
+		entries = append(entries, ExportManifestEntry{
+			ObjectType:  res.ObjectType,
+			ObjectName:  strings.ToUpper(res.ObjectName),
+			IncludeType: res.IncludeType,
+			File:        filepath.ToSlash(filepath.Base(res.FilePath)),
+			LineCount:   res.LineCount,
+		})
+	}
+
+	data, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("encoding export manifest: %w", err)
+	}
+	return append(data, '\n'), nil
+}
+
 // ImportBuilder provides a fluent interface for batch imports.
 type ImportBuilder struct {
 	client      *adt.Client
@@ -433,7 +474,8 @@ func (b *ExportBuilder) OnError(fn func(obj ExportObject, err error)) *ExportBui
 	return b
 }
 
-// Execute runs the batch export.
+// Execute runs the batch export. Objects are exported in type/name order
+// regardless of the order in which they were added.
 func (b *ExportBuilder) Execute(ctx context.Context) (*BatchExportResult, error) {
 	result := &BatchExportResult{
 		TotalObjects: len(b.objects),
@@ -447,7 +489,7 @@ func (b *ExportBuilder) Execute(ctx context.Context) (*BatchExportResult, error)
 		}
 	}
 
-	for _, obj := range b.objects {
+	for _, obj := range sortedExportObjects(b.objects) {
 		select {
 		case <-ctx.Done():
 			return result, ctx.Err()
@@ -517,6 +559,58 @@ func (b *ExportBuilder) exportObject(ctx context.Context, obj ExportObject) Expo
 
 // --- Helper Functions ---
 
+// exportIncludeRank orders class includes within a class: main first,
+// then the remaining includes in the order Classes() adds them.
+func exportIncludeRank(include string) int {
+	switch adt.ClassIncludeType(include) {
+	case "", adt.ClassIncludeMain:
+		return 0
+	case adt.ClassIncludeTestClasses:
+		return 1
+	case adt.ClassIncludeDefinitions:
+		return 2
+	case adt.ClassIncludeImplementations:
+		return 3
+	case adt.ClassIncludeMacros:
+		return 4
+	default:
+		return 5
+	}
+}
+
+// exportLess orders export entries by object type, then name, then include.
+func exportLess(typeA, nameA, incA, typeB, nameB, incB string) bool {
+	if typeA != typeB {
+		return typeA < typeB
+	}
+	if na, nb := strings.ToUpper(nameA), strings.ToUpper(nameB); na != nb {
+		return na < nb
+	}
+	if ra, rb := exportIncludeRank(incA), exportIncludeRank(incB); ra != rb {
+		return ra < rb
+	}
+	return incA < incB
+}
+
+// sortedExportObjects returns the objects in deterministic export order.
+func sortedExportObjects(objects []ExportObject) []ExportObject {
+	sorted := make([]ExportObject, len(objects))
+	copy(sorted, objects)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, b := sorted[i], sorted[j]
+		return exportLess(string(a.Type), a.Name, string(a.IncludeType), string(b.Type), b.Name, string(b.IncludeType))
+	})
+	return sorted
+}
+
+// sortExportResults sorts results in the same order as sortedExportObjects.
+func sortExportResults(results []ExportResult) {
+	sort.SliceStable(results, func(i, j int) bool {
+		a, b := results[i], results[j]
+		return exportLess(a.ObjectType, a.ObjectName, a.IncludeType, b.ObjectType, b.ObjectName, b.IncludeType)
+	})
+}
+
 // ScanDirectory scans a directory for ABAP source files.
 func ScanDirectory(dir string) ([]ImportFile, error) {
 	var files []ImportFile
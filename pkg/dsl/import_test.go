@@ -0,0 +1,85 @@
+package dsl
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/oisee/vibing-steampunk/pkg/adt"
+)
+
+func TestExportManifest_Deterministic(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "/source/main") {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte("* source of " + r.URL.Path + "\n"))
+	}))
+	defer server.Close()
+
+	client := adt.NewClient(server.URL, "user", "pass")
+	ctx := context.Background()
+
+	// Same package content, added in different orders
+	first, err := Export(client).
+		Programs("ZDEMO_REPORT", "ZDEMO_ALV").
+		Interfaces("ZIF_DEMO_API").
+		ClassMain("ZCL_DEMO_B", "ZCL_DEMO_A").
+		ToDirectory(t.TempDir()).
+		Execute(ctx)
+	if err != nil {
+		t.Fatalf("first export failed: %v", err)
+	}
+	second, err := Export(client).
+		ClassMain("ZCL_DEMO_A", "ZCL_DEMO_B").
+		Interfaces("ZIF_DEMO_API").
+		Programs("ZDEMO_ALV", "ZDEMO_REPORT").
+		ToDirectory(t.TempDir()).
+		Execute(ctx)
+	if err != nil {
+		t.Fatalf("second export failed: %v", err)
+	}
+
+	m1, err := first.Manifest()
+	if err != nil {
+		t.Fatal(err)
+	}
+	m2, err := second.Manifest()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(m1, m2) {
+		t.Fatalf("manifests differ:\n%s\n---\n%s", m1, m2)
+	}
+
+	var order []string
+	for _, r := range first.Results {
+		order = append(order, r.ObjectType+" "+r.ObjectName)
+	}
+	want := "CLAS/OC ZCL_DEMO_A,CLAS/OC ZCL_DEMO_B,INTF/OI ZIF_DEMO_API,PROG/P ZDEMO_ALV,PROG/P ZDEMO_REPORT"
+	if got := strings.Join(order, ","); got != want {
+		t.Errorf("export order = %s, want %s", got, want)
+	}
+}
+
+func TestExportManifest_IgnoresResultOrder(t *testing.T) {
+	results := []ExportResult{
+		{ObjectType: "CLAS/OC", ObjectName: "ZCL_DEMO", IncludeType: "testclasses", FilePath: "/a/zcl_demo.clas.testclasses.abap", Success: true},
+		{ObjectType: "CLAS/OC", ObjectName: "ZCL_DEMO", IncludeType: "main", FilePath: "/a/zcl_demo.clas.abap", Success: true},
+		{ObjectType: "CLAS/OC", ObjectName: "ZCL_DEMO", IncludeType: "locals_def", FilePath: "/a/zcl_demo.clas.locals_def.abap", Success: true},
+	}
+	reversed := []ExportResult{results[2], results[1], results[0]}
+
+	m1, _ := (&BatchExportResult{Results: results}).Manifest()
+	m2, _ := (&BatchExportResult{Results: reversed}).Manifest()
+	if !bytes.Equal(m1, m2) {
+		t.Fatalf("manifest depends on result order:\n%s\n---\n%s", m1, m2)
+	}
+	if i, j := bytes.Index(m1, []byte(`"main"`)), bytes.Index(m1, []byte(`"testclasses"`)); i < 0 || j < i {
+		t.Errorf("main include must precede test classes:\n%s", m1)
+	}
+}